@@ -3,58 +3,194 @@ package main
 import (
 	"context"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kourai55k/booking-service/internal/app"
+	"github.com/kourai55k/booking-service/internal/cache"
+	"github.com/kourai55k/booking-service/internal/clients/notification"
 	"github.com/kourai55k/booking-service/internal/config"
+	"github.com/kourai55k/booking-service/internal/data/cached"
 	"github.com/kourai55k/booking-service/internal/data/postgres"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/internal/features"
+	"github.com/kourai55k/booking-service/internal/health"
+	"github.com/kourai55k/booking-service/internal/live"
+	"github.com/kourai55k/booking-service/internal/metrics"
+	"github.com/kourai55k/booking-service/internal/outbox"
+	"github.com/kourai55k/booking-service/internal/ratelimit"
 	"github.com/kourai55k/booking-service/internal/service"
+	"github.com/kourai55k/booking-service/internal/sweeper"
+	"github.com/kourai55k/booking-service/internal/tracing"
+	"github.com/kourai55k/booking-service/internal/transport/handlers/grpc/grpcHandler"
 	"github.com/kourai55k/booking-service/internal/transport/handlers/http/authHandler"
+	"github.com/kourai55k/booking-service/internal/transport/handlers/http/middleware"
+	"github.com/kourai55k/booking-service/internal/transport/handlers/http/reservationHandler"
 	"github.com/kourai55k/booking-service/internal/transport/handlers/http/router"
 	"github.com/kourai55k/booking-service/internal/transport/handlers/http/userHandler"
+	jwthelper "github.com/kourai55k/booking-service/pkg/jwtHelper"
+	"github.com/kourai55k/booking-service/pkg/logctx"
 	prettyslog "github.com/kourai55k/booking-service/pkg/prettySlog"
+	"github.com/redis/go-redis/v9"
 )
 
-const (
-	envLocal = "local"
-	envDev   = "dev"
-	envProd  = "prod"
-)
+const envLocal = "local"
 
 func main() {
 	// load environment variables and initialize config
 	cfg := config.MustLoad()
 
 	// setup logger
-	log := setupLogger(cfg.Env)
-	log.Debug("config loaded", "config", cfg) // maybe shouldn't show config in logs
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(cfg.Level())
+	log := setupLogger(cfg.Env, logLevel)
+	log.Debug("config loaded", "config", cfg)
 	log.Debug("logger initialized")
 
+	jwthelper.Configure([]byte(cfg.Auth.JWTSecret.Value()), cfg.Auth.TokenTTL)
+
+	// setup tracing, spans are still created for logs and propagation if exporting is disabled
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:     cfg.Tracing.Exporter,
+		ServiceName:  cfg.Tracing.ServiceName,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure: cfg.Tracing.OTLPInsecure,
+		SampleRatio:  cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		log.Error("failed to setup tracing", "err", err.Error())
+		os.Exit(1)
+	}
+
 	// dependency injection
 
-	// connecting to postgres
-	pgPool, err := postgres.ConnectPool(context.Background(), cfg.PostgresConnString)
+	// connecting to postgres, the service is useless without it so it doesn't start
+	pgPool, err := connectPostgres(cfg.PostgresConnString.Value(), cfg.Postgres, log)
 	if err != nil {
 		log.Error("failed to connect to database", "err", err.Error())
-	} else {
-		log.Debug("connected to database successfully")
+		os.Exit(1)
 	}
+	log.Debug("connected to database successfully")
+
+	appMetrics := metrics.New()
+	appMetrics.RegisterPool(pgPool)
+
+	appHealth := health.New(cfg.Health.CheckTimeout, log)
+	appHealth.AddCheck("postgres", pgPool.Ping, true)
 
 	userRepo := postgres.NewUserRepo(pgPool)
 	userRepo.CreateUserTable()
-	userService := service.NewUserService(userRepo)
-	authService := service.NewAuthService(userRepo)
+	restaurantRepo := postgres.NewRestaurantRepo(pgPool)
+	restaurantRepo.CreateRestaurantTables()
+	tableRepo := postgres.NewTableRepo(pgPool)
+	tableRepo.CreateTableTable()
+	reservationRepo := postgres.NewReservationRepo(pgPool)
+	reservationRepo.CreateReservationTable()
+	reservationRepo.CreateHoldTable()
+	outboxRepo := postgres.NewOutboxRepo(pgPool)
+	outboxRepo.CreateOutboxTable()
+	waitlistRepo := postgres.NewWaitlistRepo(pgPool)
+	waitlistRepo.CreateWaitlistTable()
+	walkInRepo := postgres.NewWalkInRepo(pgPool)
+	walkInRepo.CreateWalkInTable()
+
+	// read-through cache of restaurants and tables, nil if disabled
+	repoCache := setupCache(cfg.Cache, appMetrics, appHealth, log)
+	var restaurants service.RestaurantRepository = restaurantRepo
+	var tables service.TableRepository = tableRepo
+	if repoCache != nil {
+		restaurants = cached.NewRestaurantRepo(restaurantRepo, repoCache)
+		tables = cached.NewTableRepo(tableRepo, repoCache)
+	}
+
+	txManager := postgres.NewTxManager(pgPool, postgres.TxOptions{
+		Isolation:    pgx.TxIsoLevel(cfg.Postgres.Tx.Isolation),
+		MaxRetries:   cfg.Postgres.Tx.MaxRetries,
+		RetryBackoff: cfg.Postgres.Tx.RetryBackoff,
+	}, func(db postgres.DB) service.Repositories {
+		reservations := postgres.NewReservationRepo(db)
+		repos := service.Repositories{
+			Users:        postgres.NewUserRepo(db),
+			Restaurants:  postgres.NewRestaurantRepo(db),
+			Tables:       postgres.NewTableRepo(db),
+			Reservations: reservations,
+			Holds:        reservations,
+			Outbox:       postgres.NewOutboxRepo(db),
+			Waitlist:     postgres.NewWaitlistRepo(db),
+			WalkIns:      postgres.NewWalkInRepo(db),
+		}
+		if repoCache != nil {
+			repos.Restaurants = cached.NewTxRestaurantRepo(repos.Restaurants, repoCache)
+			repos.Tables = cached.NewTxTableRepo(repos.Tables, repoCache)
+		}
+		return repos
+	})
+
+	// notification service client, the outbox events are dropped if it's not configured
+	var publisher outbox.Publisher = dropEvents(log)
+	var notificationClient *notification.Client
+	if cfg.Notification.Addr != "" {
+		notificationClient, err = app.NewNotificationClient(cfg.Notification.Addr, cfg.Notification, log)
+		if err != nil {
+			log.Error("failed to create notification client", "err", err.Error())
+		} else {
+			publisher = notificationClient
+			// notifications are best effort, the service is still ready without them
+			appHealth.AddCheck("notification", notificationClient.Check, false)
+		}
+	}
+	stopDispatcher := app.RunInBackground(app.NewDispatcher(cfg.Outbox, outboxRepo, publisher, appMetrics, log).Run)
+
+	featureFlags := features.New(features.Set{Registration: !cfg.Features.DisableRegistration})
+	cors := middleware.NewCORS(cfg.HTTP.CORS.AllowedOrigins)
+
+	authService := service.NewAuthService(userRepo, appMetrics, featureFlags)
+	// the changes committed through this instance are streamed to the host stands
+	liveHub := live.New(live.Options{History: cfg.Live.History, Buffer: cfg.Live.Buffer})
+	userService := service.NewUserService(userRepo, txManager, appMetrics, liveHub, cfg.Waitlist.OfferTTL)
+	restaurantService := service.NewRestaurantService(tables, restaurants, txManager, appMetrics, liveHub, cfg.Waitlist.OfferTTL)
+	reservationService := service.NewReservationService(
+		reservationRepo, reservationRepo, tables, restaurants, txManager, appMetrics, liveHub, cfg.Holds.TTL, cfg.Waitlist.OfferTTL,
+	)
+	waitlistService := service.NewWaitlistService(waitlistRepo, restaurants, txManager, cfg.Waitlist.OfferTTL)
+	walkInService := service.NewWalkInService(walkInRepo, tables, restaurants, reservationRepo, txManager, liveHub)
+	stopHoldSweeper := app.RunInBackground(sweeper.New("holds", cfg.Holds.SweepInterval, reservationService.ExpireHolds, log).Run)
+	stopOfferSweeper := app.RunInBackground(sweeper.New("waitlist offers", cfg.Waitlist.SweepInterval, waitlistService.ExpireOffers, log).Run)
 	httpUserHandler := userHandler.NewUserHandler(userService, log)
 	httpAuthHandler := authHandler.NewAuthHandler(authService, log)
-	r := router.NewRouter(httpUserHandler, httpAuthHandler)
-	// TODO: use config file to configure server
-	server := http.Server{
-		Addr:    ":8080",
-		Handler: r,
+	httpReservationHandler := reservationHandler.NewReservationHandler(
+		reservationService, waitlistService, walkInService, liveHub, cfg.Live.HeartbeatInterval, cors, log,
+	)
+	limiter, err := setupRateLimiter(cfg.RateLimit, appHealth, log)
+	if err != nil {
+		log.Error("failed to setup rate limiter", "err", err.Error())
+		os.Exit(1)
 	}
+	r := router.NewRouter(httpUserHandler, httpAuthHandler, httpReservationHandler, limiter, cors, appMetrics, appHealth, log)
+	// the reloadable parts of the config are swapped in on SIGHUP
+	reloader := config.NewReloader(cfg, func(next *config.Config) {
+		logLevel.Set(next.Level())
+		limiter.SetPolicies(app.RateLimitPolicies(next.RateLimit))
+		cors.SetAllowedOrigins(next.HTTP.CORS.AllowedOrigins)
+		featureFlags.Set(features.Set{Registration: !next.Features.DisableRegistration})
+	}, log)
+	reloadCtx, stopReloading := context.WithCancel(context.Background())
+	defer stopReloading()
+	go reloader.Run(reloadCtx)
+
+	server, err := app.SetupHTTPServer(cfg.HTTP, r, log)
+	if err != nil {
+		log.Error("failed to setup http server", "err", err.Error())
+		os.Exit(1)
+	}
+	adminServer := app.SetupAdminServer(cfg.HTTP, appMetrics.Handler())
+	grpcServer := grpcHandler.NewServer(userService, authService, restaurantService, limiter, log)
 	log.Debug("dependencies injected")
 
 	// Channel to listen for OS signals
@@ -63,62 +199,199 @@ func main() {
 
 	// Start the server in a goroutine
 	go func() {
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := app.ListenAndServe(server); err != nil && err != http.ErrServerClosed {
 			log.Error("ListenAndServe error:", "err", err.Error())
 			stop <- os.Interrupt
 			return
 		}
 	}()
 	log.Debug("server started", "addr", server.Addr)
+
+	// Start the admin server in a goroutine
+	go func() {
+		if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error("admin ListenAndServe error:", "err", err.Error())
+			stop <- os.Interrupt
+			return
+		}
+	}()
+	log.Debug("admin server started", "addr", adminServer.Addr)
+
+	// Start the gRPC server in a goroutine
+	grpcListener, err := net.Listen("tcp", cfg.GRPC.Addr)
+	if err != nil {
+		log.Error("failed to listen for grpc", "err", err.Error())
+		stop <- os.Interrupt
+	} else {
+		go func() {
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				log.Error("grpc Serve error:", "err", err.Error())
+				stop <- os.Interrupt
+			}
+		}()
+		log.Debug("grpc server started", "addr", cfg.GRPC.Addr)
+	}
 	log.Info("app started")
 
 	// Block until we receive a termination signal
 	<-stop
+	stopReloading()
+
+	// Fail the readiness probe first, so no new traffic is routed here while the servers stop
+	appHealth.SetShuttingDown()
+	time.Sleep(cfg.HTTP.ShutdownDelay)
 
 	// Create a context with a timeout for graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 	defer cancel()
 
+	// End the live streams first: the server doesn't wait for hijacked WebSockets, and waits for SSE until the timeout.
+	// Their clients reconnect on their own, new streams are refused from now on.
+	if err := liveHub.Shutdown(ctx); err != nil {
+		log.Error("live streams shutdown error:", "err", err.Error())
+	}
+
 	// Attempt to gracefully shutdown the server
 	if err := server.Shutdown(ctx); err != nil {
 		log.Error("server shutdown error:", "err", err.Error())
 	}
+	if err := adminServer.Shutdown(ctx); err != nil {
+		log.Error("admin server shutdown error:", "err", err.Error())
+	}
+
+	// Gracefully stop the gRPC server, forcing it if the shutdown timeout is exceeded
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+		log.Error("grpc server shutdown error:", "err", ctx.Err().Error())
+	}
+
+	// Stop delivering outbox events, the undelivered ones are sent after the next start
+	if err := stopDispatcher(ctx); err != nil {
+		log.Error("outbox dispatcher shutdown error:", "err", err.Error())
+	}
+	if err := stopHoldSweeper(ctx); err != nil {
+		log.Error("hold sweeper shutdown error:", "err", err.Error())
+	}
+	if err := stopOfferSweeper(ctx); err != nil {
+		log.Error("waitlist offer sweeper shutdown error:", "err", err.Error())
+	}
+	if notificationClient != nil {
+		if err := notificationClient.Close(); err != nil {
+			log.Error("notification client close error:", "err", err.Error())
+		}
+	}
+
+	// Export the remaining spans
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error("tracing shutdown error:", "err", err.Error())
+	}
 
 	log.Debug("server stopped gracefully")
 	log.Info("app stopped")
 }
 
-func setupLogger(env string) *slog.Logger {
-	var log *slog.Logger
-
-	switch env {
-	case envLocal:
-		log = setupPrettySlog()
-	case envDev:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
-		)
-	case envProd:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
-	default: // If env config is invalid, set prod settings by default due to security
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
-	}
-
-	return log
+// dropEvents is the outbox publisher when notifications are disabled
+func dropEvents(log *slog.Logger) outbox.Publisher {
+	return outbox.PublisherFunc(func(ctx context.Context, event models.ReservationEvent) error {
+		log.DebugContext(ctx, "notifications are disabled, dropping event", "type", event.Type, "reservation_id", event.Reservation.ID)
+		return nil
+	})
 }
 
-func setupPrettySlog() *slog.Logger {
-	opts := prettyslog.PrettyHandlerOptions{
-		SlogOpts: &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		},
+// connectPostgres connects to the database, retrying with doubling backoff while it's unreachable
+func connectPostgres(connString string, cfg config.PostgresConfig, log *slog.Logger) (*pgxpool.Pool, error) {
+	backoff := cfg.ConnectBackoff
+	for attempt := 1; ; attempt++ {
+		pool, err := postgres.ConnectPool(context.Background(), connString, postgres.PoolOptions{
+			MaxConns:          cfg.Pool.MaxConns,
+			MinConns:          cfg.Pool.MinConns,
+			MaxConnLifetime:   cfg.Pool.MaxConnLifetime,
+			MaxConnIdleTime:   cfg.Pool.MaxConnIdleTime,
+			HealthCheckPeriod: cfg.Pool.HealthCheckPeriod,
+		})
+		if err == nil {
+			return pool, nil
+		}
+		if attempt >= cfg.ConnectAttempts {
+			return nil, err
+		}
+
+		log.Warn("database is unreachable, retrying", "attempt", attempt, "retry_in", backoff, "err", err.Error())
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// setupRateLimiter creates the HTTP rate limiter, it returns nil if rate limiting is disabled
+func setupRateLimiter(cfg config.RateLimitConfig, appHealth *health.Health, log *slog.Logger) (*middleware.RateLimiter, error) {
+	if cfg.Disabled {
+		return nil, nil
+	}
+
+	trustedProxies, err := cfg.TrustedProxyPrefixes()
+	if err != nil {
+		return nil, err
 	}
 
-	handler := opts.NewPrettyHandler(os.Stdout)
+	var store ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.RedisAddr != "" {
+		client := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr})
+		store = ratelimit.NewRedisStore(client, "ratelimit:")
+		// the limiter fails open, so Redis being down doesn't make the service unready
+		appHealth.AddCheck("redis", func(ctx context.Context) error { return client.Ping(ctx).Err() }, false)
+	}
+
+	return middleware.NewRateLimiter(store, app.RateLimitPolicies(cfg), trustedProxies, log), nil
+}
+
+// setupCache creates the read-through cache of restaurants and tables, shared through Redis if configured.
+// It returns nil if caching is disabled.
+func setupCache(cfg config.CacheConfig, appMetrics *metrics.Metrics, appHealth *health.Health, log *slog.Logger) *cache.Cache {
+	if cfg.Disabled {
+		return nil
+	}
+
+	var store cache.Store = cache.NewMemoryStore(cfg.Size)
+	if cfg.RedisAddr != "" {
+		client := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr})
+		store = cache.NewRedisStore(client, "cache:")
+		// reads fall back to the database, so Redis being down doesn't make the service unready
+		appHealth.AddCheck("cache-redis", func(ctx context.Context) error { return client.Ping(ctx).Err() }, false)
+	}
+
+	return cache.New(store, cfg.TTL, appMetrics, log)
+}
+
+// setupLogger creates the logger of the environment, its level can be changed through level.
+// Secrets are redacted, and records logged with a context get its request ID, user ID and trace ID.
+func setupLogger(env string, level *slog.LevelVar) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: prettyslog.DefaultRedactor().ReplaceAttr,
+	}
+
+	var handler slog.Handler
+	if env == envLocal {
+		handler = setupPrettySlog(opts)
+	} else {
+		// dev and prod log JSON, as does an invalid env
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	}
+
+	return slog.New(prettyslog.NewContextHandler(handler, logctx.Attrs))
+}
+
+func setupPrettySlog(slogOpts *slog.HandlerOptions) slog.Handler {
+	opts := prettyslog.PrettyHandlerOptions{
+		SlogOpts: slogOpts,
+	}
 
-	return slog.New(handler)
+	return opts.NewPrettyHandler(os.Stdout)
 }
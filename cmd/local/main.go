@@ -10,48 +10,160 @@ package main
 import (
 	"context"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
+	"github.com/kourai55k/booking-service/internal/app"
+	"github.com/kourai55k/booking-service/internal/cache"
+	"github.com/kourai55k/booking-service/internal/clients/notification/fake"
 	"github.com/kourai55k/booking-service/internal/config"
 	"github.com/kourai55k/booking-service/internal/data"
+	"github.com/kourai55k/booking-service/internal/data/cached"
+	"github.com/kourai55k/booking-service/internal/features"
+	"github.com/kourai55k/booking-service/internal/health"
+	"github.com/kourai55k/booking-service/internal/live"
+	"github.com/kourai55k/booking-service/internal/metrics"
+	"github.com/kourai55k/booking-service/internal/ratelimit"
 	"github.com/kourai55k/booking-service/internal/service"
+	"github.com/kourai55k/booking-service/internal/sweeper"
+	"github.com/kourai55k/booking-service/internal/tracing"
+	"github.com/kourai55k/booking-service/internal/transport/handlers/grpc/grpcHandler"
 	"github.com/kourai55k/booking-service/internal/transport/handlers/http/authHandler"
+	"github.com/kourai55k/booking-service/internal/transport/handlers/http/middleware"
+	"github.com/kourai55k/booking-service/internal/transport/handlers/http/reservationHandler"
 	"github.com/kourai55k/booking-service/internal/transport/handlers/http/router"
 	"github.com/kourai55k/booking-service/internal/transport/handlers/http/userHandler"
+	jwthelper "github.com/kourai55k/booking-service/pkg/jwtHelper"
+	"github.com/kourai55k/booking-service/pkg/logctx"
 	prettyslog "github.com/kourai55k/booking-service/pkg/prettySlog"
+	"github.com/redis/go-redis/v9"
 )
 
-const (
-	envLocal = "local"
-	envDev   = "dev"
-	envProd  = "prod"
-)
+const envLocal = "local"
 
 func main() {
 	// load environment variables and initialize config
 	cfg := config.MustLoad()
 
 	// setup logger
-	log := setupLogger(cfg.Env)
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(cfg.Level())
+	log := setupLogger(cfg.Env, logLevel)
 	log.Debug("config loaded", "config", cfg)
 	log.Debug("logger initialized")
 
+	jwthelper.Configure([]byte(cfg.Auth.JWTSecret.Value()), cfg.Auth.TokenTTL)
+
+	// setup tracing, spans are still created for logs and propagation if exporting is disabled
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:     cfg.Tracing.Exporter,
+		ServiceName:  cfg.Tracing.ServiceName,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure: cfg.Tracing.OTLPInsecure,
+		SampleRatio:  cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		log.Error("failed to setup tracing", "err", err.Error())
+		os.Exit(1)
+	}
+
 	// DI
-	userRepo := data.NewInMemoryUserRepo()
-	userService := service.NewUserService(userRepo)
-	authService := service.NewAuthService(userRepo)
+	appMetrics := metrics.New()
+	restaurantRepo := data.NewInMemoryRestaurantRepo()
+	userRepo := data.NewInMemoryUserRepo(restaurantRepo)
+	tableRepo := data.NewInMemoryTableRepo()
+	reservationRepo := data.NewInMemoryReservationRepo(tableRepo)
+	outboxRepo := data.NewInMemoryOutboxRepo()
+	waitlistRepo := data.NewInMemoryWaitlistRepo()
+	walkInRepo := data.NewInMemoryWalkInRepo()
+	appHealth := health.New(cfg.Health.CheckTimeout, log)
+
+	// read-through cache of restaurants and tables, nil if disabled
+	repoCache := setupCache(cfg.Cache, appMetrics, log)
+	txRepos := service.Repositories{
+		Users:        userRepo,
+		Restaurants:  restaurantRepo,
+		Tables:       tableRepo,
+		Reservations: reservationRepo,
+		Holds:        reservationRepo,
+		Outbox:       outboxRepo,
+		Waitlist:     waitlistRepo,
+		WalkIns:      walkInRepo,
+	}
+	var restaurants service.RestaurantRepository = restaurantRepo
+	var tables service.TableRepository = tableRepo
+	if repoCache != nil {
+		restaurants = cached.NewRestaurantRepo(restaurantRepo, repoCache)
+		tables = cached.NewTableRepo(tableRepo, repoCache)
+		txRepos.Restaurants = cached.NewTxRestaurantRepo(restaurantRepo, repoCache)
+		txRepos.Tables = cached.NewTxTableRepo(tableRepo, repoCache)
+	}
+	txManager := data.NewInMemoryTxManager(txRepos, userRepo, restaurantRepo, tableRepo, reservationRepo, outboxRepo, waitlistRepo, walkInRepo)
+
+	// in-process fake of the notification service
+	fakeNotificationServer := fake.NewServer(log)
+	notificationAddr, err := fakeNotificationServer.Start("127.0.0.1:0")
+	if err != nil {
+		log.Error("failed to start fake notification service", "err", err.Error())
+		os.Exit(1)
+	}
+	notificationClient, err := app.NewNotificationClient(notificationAddr, cfg.Notification, log)
+	if err != nil {
+		log.Error("failed to create notification client", "err", err.Error())
+		os.Exit(1)
+	}
+	appHealth.AddCheck("notification", notificationClient.Check, false)
+	stopDispatcher := app.RunInBackground(app.NewDispatcher(cfg.Outbox, outboxRepo, notificationClient, appMetrics, log).Run)
+
+	featureFlags := features.New(features.Set{Registration: !cfg.Features.DisableRegistration})
+	cors := middleware.NewCORS(cfg.HTTP.CORS.AllowedOrigins)
+
+	authService := service.NewAuthService(userRepo, appMetrics, featureFlags)
+	// the changes committed through this instance are streamed to the host stands
+	liveHub := live.New(live.Options{History: cfg.Live.History, Buffer: cfg.Live.Buffer})
+	userService := service.NewUserService(userRepo, txManager, appMetrics, liveHub, cfg.Waitlist.OfferTTL)
+	restaurantService := service.NewRestaurantService(tables, restaurants, txManager, appMetrics, liveHub, cfg.Waitlist.OfferTTL)
+	reservationService := service.NewReservationService(
+		reservationRepo, reservationRepo, tables, restaurants, txManager, appMetrics, liveHub, cfg.Holds.TTL, cfg.Waitlist.OfferTTL,
+	)
+	waitlistService := service.NewWaitlistService(waitlistRepo, restaurants, txManager, cfg.Waitlist.OfferTTL)
+	walkInService := service.NewWalkInService(walkInRepo, tables, restaurants, reservationRepo, txManager, liveHub)
+	stopHoldSweeper := app.RunInBackground(sweeper.New("holds", cfg.Holds.SweepInterval, reservationService.ExpireHolds, log).Run)
+	stopOfferSweeper := app.RunInBackground(sweeper.New("waitlist offers", cfg.Waitlist.SweepInterval, waitlistService.ExpireOffers, log).Run)
 	httpUserHandler := userHandler.NewUserHandler(userService, log)
 	httpAuthHandler := authHandler.NewAuthHandler(authService, log)
-	r := router.NewRouter(httpUserHandler, httpAuthHandler)
-	// TODO: use config file to configure server
-	server := http.Server{
-		Addr:    ":8080",
-		Handler: r,
+	httpReservationHandler := reservationHandler.NewReservationHandler(
+		reservationService, waitlistService, walkInService, liveHub, cfg.Live.HeartbeatInterval, cors, log,
+	)
+	limiter, err := setupRateLimiter(cfg.RateLimit, appHealth, log)
+	if err != nil {
+		log.Error("failed to setup rate limiter", "err", err.Error())
+		os.Exit(1)
+	}
+	r := router.NewRouter(httpUserHandler, httpAuthHandler, httpReservationHandler, limiter, cors, appMetrics, appHealth, log)
+	// the reloadable parts of the config are swapped in on SIGHUP
+	reloader := config.NewReloader(cfg, func(next *config.Config) {
+		logLevel.Set(next.Level())
+		limiter.SetPolicies(app.RateLimitPolicies(next.RateLimit))
+		cors.SetAllowedOrigins(next.HTTP.CORS.AllowedOrigins)
+		featureFlags.Set(features.Set{Registration: !next.Features.DisableRegistration})
+	}, log)
+	reloadCtx, stopReloading := context.WithCancel(context.Background())
+	defer stopReloading()
+	go reloader.Run(reloadCtx)
+
+	server, err := app.SetupHTTPServer(cfg.HTTP, r, log)
+	if err != nil {
+		log.Error("failed to setup http server", "err", err.Error())
+		os.Exit(1)
 	}
+	adminServer := app.SetupAdminServer(cfg.HTTP, appMetrics.Handler())
+	grpcServer := grpcHandler.NewServer(userService, authService, restaurantService, limiter, log)
 	log.Debug("dependencies injected")
 
 	// Channel to listen for OS signals
@@ -60,62 +172,163 @@ func main() {
 
 	// Start the server in a goroutine
 	go func() {
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := app.ListenAndServe(server); err != nil && err != http.ErrServerClosed {
 			log.Error("ListenAndServe error:", "err", err.Error())
 			stop <- os.Interrupt
 			return
 		}
 	}()
 	log.Debug("server started", "addr", server.Addr)
+
+	// Start the admin server in a goroutine
+	go func() {
+		if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error("admin ListenAndServe error:", "err", err.Error())
+			stop <- os.Interrupt
+			return
+		}
+	}()
+	log.Debug("admin server started", "addr", adminServer.Addr)
+
+	// Start the gRPC server in a goroutine
+	grpcListener, err := net.Listen("tcp", cfg.GRPC.Addr)
+	if err != nil {
+		log.Error("failed to listen for grpc", "err", err.Error())
+		stop <- os.Interrupt
+	} else {
+		go func() {
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				log.Error("grpc Serve error:", "err", err.Error())
+				stop <- os.Interrupt
+			}
+		}()
+		log.Debug("grpc server started", "addr", cfg.GRPC.Addr)
+	}
 	log.Info("app started")
 
 	// Block until we receive a termination signal
 	<-stop
+	stopReloading()
+
+	// Fail the readiness probe first, so no new traffic is routed here while the servers stop
+	appHealth.SetShuttingDown()
+	time.Sleep(cfg.HTTP.ShutdownDelay)
 
 	// Create a context with a timeout for graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 	defer cancel()
 
+	// End the live streams first: the server doesn't wait for hijacked WebSockets, and waits for SSE until the timeout.
+	// Their clients reconnect on their own, new streams are refused from now on.
+	if err := liveHub.Shutdown(ctx); err != nil {
+		log.Error("live streams shutdown error:", "err", err.Error())
+	}
+
 	// Attempt to gracefully shutdown the server
 	if err := server.Shutdown(ctx); err != nil {
 		log.Error("server shutdown error:", "err", err.Error())
 	}
+	if err := adminServer.Shutdown(ctx); err != nil {
+		log.Error("admin server shutdown error:", "err", err.Error())
+	}
+
+	// Gracefully stop the gRPC server, forcing it if the shutdown timeout is exceeded
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+		log.Error("grpc server shutdown error:", "err", ctx.Err().Error())
+	}
+
+	// Stop delivering outbox events, the in-memory outbox is lost with the undelivered ones
+	if err := stopDispatcher(ctx); err != nil {
+		log.Error("outbox dispatcher shutdown error:", "err", err.Error())
+	}
+	if err := stopHoldSweeper(ctx); err != nil {
+		log.Error("hold sweeper shutdown error:", "err", err.Error())
+	}
+	if err := stopOfferSweeper(ctx); err != nil {
+		log.Error("waitlist offer sweeper shutdown error:", "err", err.Error())
+	}
+	if err := notificationClient.Close(); err != nil {
+		log.Error("notification client close error:", "err", err.Error())
+	}
+	fakeNotificationServer.Stop()
+
+	// Export the remaining spans
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error("tracing shutdown error:", "err", err.Error())
+	}
 
 	log.Debug("server stopped gracefully")
 	log.Info("app stopped")
 }
 
-func setupLogger(env string) *slog.Logger {
-	var log *slog.Logger
-
-	switch env {
-	case envLocal:
-		log = setupPrettySlog()
-	case envDev:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
-		)
-	case envProd:
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
-	default: // If env config is invalid, set prod settings by default due to security
-		log = slog.New(
-			slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
-		)
-	}
-
-	return log
+// setupRateLimiter creates the HTTP rate limiter on top of the configured Redis, or an in-process fake one
+// so the Redis store is exercised locally. It returns nil if rate limiting is disabled.
+func setupRateLimiter(cfg config.RateLimitConfig, appHealth *health.Health, log *slog.Logger) (*middleware.RateLimiter, error) {
+	if cfg.Disabled {
+		return nil, nil
+	}
+
+	trustedProxies, err := cfg.TrustedProxyPrefixes()
+	if err != nil {
+		return nil, err
+	}
+
+	addr := cfg.RedisAddr
+	if addr == "" {
+		fakeRedis, err := miniredis.Run()
+		if err != nil {
+			return nil, err
+		}
+		addr = fakeRedis.Addr()
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	store := ratelimit.NewRedisStore(client, "ratelimit:")
+	appHealth.AddCheck("redis", func(ctx context.Context) error { return client.Ping(ctx).Err() }, false)
+
+	return middleware.NewRateLimiter(store, app.RateLimitPolicies(cfg), trustedProxies, log), nil
 }
 
-func setupPrettySlog() *slog.Logger {
-	opts := prettyslog.PrettyHandlerOptions{
-		SlogOpts: &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		},
+// setupCache creates the read-through cache of restaurants and tables in memory, Redis isn't used locally.
+// It returns nil if caching is disabled.
+func setupCache(cfg config.CacheConfig, appMetrics *metrics.Metrics, log *slog.Logger) *cache.Cache {
+	if cfg.Disabled {
+		return nil
+	}
+
+	return cache.New(cache.NewMemoryStore(cfg.Size), cfg.TTL, appMetrics, log)
+}
+
+// setupLogger creates the logger of the environment, its level can be changed through level.
+// Secrets are redacted, and records logged with a context get its request ID, user ID and trace ID.
+func setupLogger(env string, level *slog.LevelVar) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: prettyslog.DefaultRedactor().ReplaceAttr,
 	}
 
-	handler := opts.NewPrettyHandler(os.Stdout)
+	var handler slog.Handler
+	if env == envLocal {
+		handler = setupPrettySlog(opts)
+	} else {
+		// dev and prod log JSON, as does an invalid env
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	}
+
+	return slog.New(prettyslog.NewContextHandler(handler, logctx.Attrs))
+}
+
+func setupPrettySlog(slogOpts *slog.HandlerOptions) slog.Handler {
+	opts := prettyslog.PrettyHandlerOptions{
+		SlogOpts: slogOpts,
+	}
 
-	return slog.New(handler)
+	return opts.NewPrettyHandler(os.Stdout)
 }
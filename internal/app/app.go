@@ -0,0 +1,124 @@
+// Package app wires the parts shared by the commands of the service:
+// the outbox dispatcher and its publisher, background workers, rate limit policies and the HTTP servers.
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+
+	"github.com/kourai55k/booking-service/internal/certreload"
+	"github.com/kourai55k/booking-service/internal/clients/notification"
+	"github.com/kourai55k/booking-service/internal/config"
+	"github.com/kourai55k/booking-service/internal/outbox"
+	"github.com/kourai55k/booking-service/internal/ratelimit"
+	"github.com/kourai55k/booking-service/internal/transport/handlers/http/middleware"
+)
+
+// NewDispatcher creates the dispatcher of the outbox events
+func NewDispatcher(
+	cfg config.OutboxConfig,
+	store outbox.Store,
+	publisher outbox.Publisher,
+	metrics outbox.Metrics,
+	log *slog.Logger,
+) *outbox.Dispatcher {
+	return outbox.New(store, publisher, outbox.Options{
+		PollInterval:    cfg.PollInterval,
+		BatchSize:       cfg.BatchSize,
+		LeaseTimeout:    cfg.LeaseTimeout,
+		MaxAttempts:     cfg.MaxAttempts,
+		RetryBackoff:    cfg.RetryBackoff,
+		MaxRetryBackoff: cfg.MaxRetryBackoff,
+	}, metrics, log)
+}
+
+// NewNotificationClient creates the client of the notification service at addr as the outbox publisher.
+// It doesn't retry on its own: the dispatcher retries failed deliveries, and retries of both would multiply.
+func NewNotificationClient(addr string, cfg config.NotificationConfig, log *slog.Logger) (*notification.Client, error) {
+	return notification.New(addr, notification.Options{Timeout: cfg.Timeout}, log)
+}
+
+// RunInBackground calls run in a goroutine until stop is called.
+// stop cancels the context of run and waits for it to return, or until ctx is done.
+func RunInBackground(run func(ctx context.Context)) (stop func(ctx context.Context) error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		run(ctx)
+		close(done)
+	}()
+
+	return func(stopCtx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-stopCtx.Done():
+			return stopCtx.Err()
+		}
+	}
+}
+
+// RateLimitPolicies maps the policies of the config to the ones of the rate limit middleware
+func RateLimitPolicies(cfg config.RateLimitConfig) map[string]ratelimit.Policy {
+	return map[string]ratelimit.Policy{
+		middleware.PolicyDefault: cfg.Default,
+		middleware.PolicyAuth:    cfg.Auth,
+		middleware.PolicyUser:    cfg.User,
+	}
+}
+
+// SetupHTTPServer creates the HTTP server, serving HTTPS with a reloadable certificate if TLS is configured
+func SetupHTTPServer(cfg config.HTTPConfig, handler http.Handler, log *slog.Logger) (*http.Server, error) {
+	server := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           middleware.MaxBodyMiddleware(cfg.MaxBodyBytes, handler),
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+
+	if !cfg.TLS.Enabled() {
+		return server, nil
+	}
+
+	reloader, err := certreload.New(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.ReloadInterval, log)
+	if err != nil {
+		return nil, err
+	}
+	server.TLSConfig = &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	return server, nil
+}
+
+// SetupAdminServer creates the HTTP server of the admin listener, which serves the metrics.
+// It's plain HTTP: the listener is meant for the internal network, not to be exposed like the API.
+func SetupAdminServer(cfg config.HTTPConfig, metrics http.Handler) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics)
+
+	return &http.Server{
+		Addr:              cfg.AdminAddr,
+		Handler:           mux,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+}
+
+// ListenAndServe serves HTTPS if the server has a TLS config, HTTP otherwise
+func ListenAndServe(server *http.Server) error {
+	if server.TLSConfig != nil {
+		return server.ListenAndServeTLS("", "")
+	}
+	return server.ListenAndServe()
+}
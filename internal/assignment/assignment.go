@@ -0,0 +1,157 @@
+// Package assignment picks the table for a party among the free tables of a restaurant.
+package assignment
+
+import (
+	"slices"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+// Request is what the party needs from a table
+type Request struct {
+	PartySize uint
+	// Attributes the table must all have, normalized with models.NormalizeAttributes
+	Attributes []string
+}
+
+// Eligible reports whether the table seats the party and has the requested attributes
+func Eligible(table *models.Table, req Request) bool {
+	return table.Seats(req.PartySize) && table.HasAttributes(req.Attributes)
+}
+
+// Choose returns the best of the free tables for the request by the strategy, or nil if none is eligible.
+// load is the number of reservations per table ID, only spread load uses it.
+//
+//   - Tightest fit takes the smallest capacity, then the fewest attributes the party didn't ask for,
+//     keeping big and special tables for the parties that need them.
+//   - Spread load takes the table with the fewest reservations, then the tightest fit.
+//
+// The remaining ties go to the lowest table number, then ID, so the choice doesn't depend on the order of tables.
+func Choose(tables []*models.Table, req Request, strategy models.TableAssignment, load map[uint]int) *models.Table {
+	var best *models.Table
+	for _, table := range tables {
+		if !Eligible(table, req) {
+			continue
+		}
+		if best == nil || better(table, best, req, strategy, load) {
+			best = table
+		}
+	}
+	return best
+}
+
+// better reports whether a is a better choice than b, both eligible
+func better(a, b *models.Table, req Request, strategy models.TableAssignment, load map[uint]int) bool {
+	if strategy == models.TableAssignmentSpreadLoad && load[a.ID] != load[b.ID] {
+		return load[a.ID] < load[b.ID]
+	}
+	if a.Capacity != b.Capacity {
+		return a.Capacity < b.Capacity
+	}
+	if extraA, extraB := extraAttributes(a, req), extraAttributes(b, req); extraA != extraB {
+		return extraA < extraB
+	}
+	if a.Number != b.Number {
+		return a.Number < b.Number
+	}
+	return a.ID < b.ID
+}
+
+// extraAttributes counts the attributes of the table the party didn't ask for
+func extraAttributes(table *models.Table, req Request) int {
+	extra := 0
+	for _, attribute := range table.Attributes {
+		if !slices.Contains(req.Attributes, attribute) {
+			extra++
+		}
+	}
+	return extra
+}
+
+// CombinationEligible reports whether the joined tables seat the party:
+// the combination has the capacity, and its tables are all free and have the requested attributes
+func CombinationEligible(combination *models.TableCombination, free map[uint]*models.Table, req Request) bool {
+	if combination.Capacity < req.PartySize {
+		return false
+	}
+	for _, id := range combination.TableIDs {
+		table, ok := free[id]
+		if !ok || !table.HasAttributes(req.Attributes) {
+			return false
+		}
+	}
+	return true
+}
+
+// ChooseCombination returns the best combination of the free tables for the request by the strategy,
+// or nil if none is eligible. It's meant for parties no single table seats.
+//
+//   - Tightest fit takes the smallest capacity, then the fewest tables.
+//   - Spread load takes the combination whose tables have the fewest reservations together, then the tightest fit.
+//
+// The remaining ties go to the lowest combination ID.
+func ChooseCombination(
+	combinations []*models.TableCombination,
+	free []*models.Table,
+	req Request,
+	strategy models.TableAssignment,
+	load map[uint]int,
+) *models.TableCombination {
+	freeByID := make(map[uint]*models.Table, len(free))
+	for _, table := range free {
+		freeByID[table.ID] = table
+	}
+
+	var best *models.TableCombination
+	for _, combination := range combinations {
+		if !CombinationEligible(combination, freeByID, req) {
+			continue
+		}
+		if best == nil || betterCombination(combination, best, strategy, load) {
+			best = combination
+		}
+	}
+	return best
+}
+
+// betterCombination reports whether a is a better choice than b, both eligible
+func betterCombination(a, b *models.TableCombination, strategy models.TableAssignment, load map[uint]int) bool {
+	if strategy == models.TableAssignmentSpreadLoad {
+		if loadA, loadB := combinationLoad(a, load), combinationLoad(b, load); loadA != loadB {
+			return loadA < loadB
+		}
+	}
+	if a.Capacity != b.Capacity {
+		return a.Capacity < b.Capacity
+	}
+	if len(a.TableIDs) != len(b.TableIDs) {
+		return len(a.TableIDs) < len(b.TableIDs)
+	}
+	return a.ID < b.ID
+}
+
+func combinationLoad(combination *models.TableCombination, load map[uint]int) int {
+	total := 0
+	for _, id := range combination.TableIDs {
+		total += load[id]
+	}
+	return total
+}
+
+// DayLoad counts the active reservations of every table on the day of at, in the location of at
+func DayLoad(reservations []*models.Reservation, at time.Time) map[uint]int {
+	year, month, day := at.Date()
+	dayStart := time.Date(year, month, day, 0, 0, 0, 0, at.Location())
+	dayEnd := dayStart.AddDate(0, 0, 1)
+
+	load := make(map[uint]int)
+	for _, reservation := range reservations {
+		if reservation.Status.Active() && reservation.StartTime.Before(dayEnd) && reservation.EndTime.After(dayStart) {
+			for _, id := range reservation.TableIDs() {
+				load[id]++
+			}
+		}
+	}
+	return load
+}
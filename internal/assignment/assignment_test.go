@@ -0,0 +1,219 @@
+package assignment
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+var strategies = []models.TableAssignment{models.TableAssignmentTightestFit, models.TableAssignmentSpreadLoad}
+
+var attributePool = []string{"accessible", "booth", "outdoor", "quiet", "window"}
+
+// TestProperties checks Choose and ChooseCombination against their contract on random restaurants:
+//   - a table (combination) is chosen exactly if one is eligible, and the chosen one is eligible;
+//   - no eligible one is tighter (tightest fit) or less loaded (spread load) than the chosen one;
+//   - the choice doesn't depend on the order of the tables and combinations.
+//
+// The input is generated from a new seed every run, set ASSIGNMENT_SEED to the one reported to repeat a failure.
+func TestProperties(t *testing.T) {
+	const runs = 2000
+
+	seed := uint64(time.Now().UnixNano())
+	if s := os.Getenv("ASSIGNMENT_SEED"); s != "" {
+		parsed, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			t.Fatalf("invalid ASSIGNMENT_SEED %q: %v", s, err)
+		}
+		seed = parsed
+	}
+	rnd := rand.New(rand.NewPCG(seed, 0))
+
+	for run := range runs {
+		tables, combinations, req, load := randomInput(rnd)
+		for _, strategy := range strategies {
+			err := checkChoice(rnd, tables, req, strategy, load)
+			if err == nil {
+				err = checkCombinationChoice(rnd, combinations, tables, req, strategy, load)
+			}
+			if err != nil {
+				t.Fatalf("seed %d, run %d, %s for a party of %d with %v among %s and combinations %s: %v",
+					seed, run, strategy, req.PartySize, req.Attributes, describe(tables, load), describeCombinations(combinations), err)
+			}
+		}
+	}
+}
+
+func checkChoice(rnd *rand.Rand, tables []*models.Table, req Request, strategy models.TableAssignment, load map[uint]int) error {
+	chosen := Choose(tables, req, strategy, load)
+
+	eligible := make([]*models.Table, 0, len(tables))
+	for _, table := range tables {
+		if Eligible(table, req) {
+			eligible = append(eligible, table)
+		}
+	}
+
+	if chosen == nil {
+		if len(eligible) > 0 {
+			return fmt.Errorf("no table chosen, but table %d is eligible", eligible[0].Number)
+		}
+		return nil
+	}
+	if !slices.Contains(eligible, chosen) {
+		return fmt.Errorf("chose table %d, which isn't eligible", chosen.Number)
+	}
+
+	for _, table := range eligible {
+		if strategy == models.TableAssignmentSpreadLoad && load[table.ID] < load[chosen.ID] {
+			return fmt.Errorf("chose table %d, but table %d has fewer reservations", chosen.Number, table.Number)
+		}
+		if strategy == models.TableAssignmentTightestFit && table.Capacity < chosen.Capacity {
+			return fmt.Errorf("chose table %d, but table %d is a tighter fit", chosen.Number, table.Number)
+		}
+	}
+
+	shuffled := slices.Clone(tables)
+	rnd.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	if again := Choose(shuffled, req, strategy, load); again != chosen {
+		return fmt.Errorf("chose table %d, but table %d when the tables were shuffled", chosen.Number, again.Number)
+	}
+
+	return nil
+}
+
+func checkCombinationChoice(
+	rnd *rand.Rand,
+	combinations []*models.TableCombination,
+	free []*models.Table,
+	req Request,
+	strategy models.TableAssignment,
+	load map[uint]int,
+) error {
+	chosen := ChooseCombination(combinations, free, req, strategy, load)
+
+	freeByID := make(map[uint]*models.Table, len(free))
+	for _, table := range free {
+		freeByID[table.ID] = table
+	}
+	eligible := make([]*models.TableCombination, 0, len(combinations))
+	for _, combination := range combinations {
+		if CombinationEligible(combination, freeByID, req) {
+			eligible = append(eligible, combination)
+		}
+	}
+
+	if chosen == nil {
+		if len(eligible) > 0 {
+			return fmt.Errorf("no combination chosen, but combination %d is eligible", eligible[0].ID)
+		}
+		return nil
+	}
+	if !slices.Contains(eligible, chosen) {
+		return fmt.Errorf("chose combination %d, which isn't eligible", chosen.ID)
+	}
+	for _, id := range chosen.TableIDs {
+		if _, ok := freeByID[id]; !ok {
+			return fmt.Errorf("chose combination %d, but its table %d isn't free", chosen.ID, id)
+		}
+	}
+
+	for _, combination := range eligible {
+		if strategy == models.TableAssignmentSpreadLoad && combinationLoad(combination, load) < combinationLoad(chosen, load) {
+			return fmt.Errorf("chose combination %d, but combination %d has fewer reservations", chosen.ID, combination.ID)
+		}
+		if strategy == models.TableAssignmentTightestFit && combination.Capacity < chosen.Capacity {
+			return fmt.Errorf("chose combination %d, but combination %d is a tighter fit", chosen.ID, combination.ID)
+		}
+	}
+
+	shuffled := slices.Clone(combinations)
+	rnd.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	shuffledFree := slices.Clone(free)
+	rnd.Shuffle(len(shuffledFree), func(i, j int) { shuffledFree[i], shuffledFree[j] = shuffledFree[j], shuffledFree[i] })
+	if again := ChooseCombination(shuffled, shuffledFree, req, strategy, load); again != chosen {
+		return fmt.Errorf("chose combination %d, but combination %d when the input was shuffled", chosen.ID, again.ID)
+	}
+
+	return nil
+}
+
+// randomInput makes up to a dozen free tables with unique numbers, combinations of them and of occupied tables,
+// a party and the reservations per table
+func randomInput(rnd *rand.Rand) ([]*models.Table, []*models.TableCombination, Request, map[uint]int) {
+	numbers := rnd.Perm(20)
+	tables := make([]*models.Table, rnd.IntN(13))
+	load := make(map[uint]int, len(tables))
+
+	for i := range tables {
+		table := &models.Table{
+			ID:          uint(i + 1),
+			Number:      uint(numbers[i] + 1),
+			Capacity:    uint(1 + rnd.IntN(10)),
+			IsAvailable: true,
+			Attributes:  randomAttributes(rnd, len(attributePool)),
+		}
+		if rnd.IntN(2) == 0 {
+			table.MinPartySize = uint(1 + rnd.IntN(int(table.Capacity)))
+		}
+		tables[i] = table
+		load[table.ID] = rnd.IntN(4)
+	}
+
+	// the tables after the free ones are occupied
+	combinations := make([]*models.TableCombination, rnd.IntN(6))
+	for i := range combinations {
+		ids := rnd.Perm(len(tables) + 3)[:2+rnd.IntN(2)]
+		combination := &models.TableCombination{ID: uint(i + 1), Capacity: uint(4 + rnd.IntN(17))}
+		for _, id := range ids {
+			combination.TableIDs = append(combination.TableIDs, uint(id+1))
+		}
+		slices.Sort(combination.TableIDs)
+		combinations[i] = combination
+	}
+
+	req := Request{
+		PartySize:  uint(1 + rnd.IntN(20)),
+		Attributes: randomAttributes(rnd, 2),
+	}
+
+	return tables, combinations, req, load
+}
+
+// randomAttributes picks up to max attributes of the pool, normalized
+func randomAttributes(rnd *rand.Rand, max int) []string {
+	attributes := make([]string, rnd.IntN(max+1))
+	for i := range attributes {
+		attributes[i] = attributePool[rnd.IntN(len(attributePool))]
+	}
+	return models.NormalizeAttributes(attributes)
+}
+
+func describe(tables []*models.Table, load map[uint]int) string {
+	s := "["
+	for i, table := range tables {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("table %d (seats %d-%d, %v, %d booked)",
+			table.Number, table.MinPartySize, table.Capacity, table.Attributes, load[table.ID])
+	}
+	return s + "]"
+}
+
+func describeCombinations(combinations []*models.TableCombination) string {
+	s := "["
+	for i, combination := range combinations {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("combination %d (tables %v, seats %d)", combination.ID, combination.TableIDs, combination.Capacity)
+	}
+	return s + "]"
+}
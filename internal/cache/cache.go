@@ -0,0 +1,121 @@
+// Package cache implements a read-through cache with pluggable stores.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Store keeps the cached values. A missing or expired key is reported with ok == false.
+type Store interface {
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Metrics counts the lookups by the kind of the key and the result: hit, miss, or error when the store failed
+type Metrics interface {
+	ObserveCache(kind, result string)
+}
+
+// Logger reports store failures, which never fail a read or a write
+type Logger interface {
+	Warn(msg string, args ...any)
+}
+
+// Cache reads through a store: values are JSON encoded, so every store gets its own copy,
+// and concurrent misses of a key run the loader once.
+// Keys look like "kind:id", the kind labels the metrics so their cardinality stays bounded.
+type Cache struct {
+	store   Store
+	ttl     time.Duration
+	metrics Metrics
+	log     Logger
+	group   singleflight.Group
+	// generation is bumped by every invalidation, loads that started before one don't store their value
+	generation atomic.Uint64
+}
+
+// New creates a cache on top of store, values expire after ttl
+func New(store Store, ttl time.Duration, metrics Metrics, log Logger) *Cache {
+	return &Cache{store: store, ttl: ttl, metrics: metrics, log: log}
+}
+
+// Fetch returns the value of key, loading and storing it on a miss.
+// Errors of the loader aren't cached.
+func Fetch[T any](ctx context.Context, c *Cache, key string, load func(ctx context.Context) (T, error)) (T, error) {
+	const op = "cache.Fetch"
+
+	var value T
+	b, ok, err := c.store.Get(ctx, key)
+	switch {
+	case err != nil:
+		c.observe(key, "error")
+		c.log.Warn("cache get failed", "key", key, "err", err.Error())
+	case ok:
+		if err := json.Unmarshal(b, &value); err == nil {
+			c.observe(key, "hit")
+			return value, nil
+		}
+		c.observe(key, "error")
+	default:
+		c.observe(key, "miss")
+	}
+
+	// the first caller loads for everyone waiting on the key, without its cancellation
+	loadCtx := context.WithoutCancel(ctx)
+	v, err, _ := c.group.Do(key, func() (any, error) {
+		generation := c.generation.Load()
+		value, err := load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if c.generation.Load() != generation {
+			return b, nil
+		}
+		if err := c.store.Set(loadCtx, key, b, c.ttl); err != nil {
+			c.log.Warn("cache set failed", "key", key, "err", err.Error())
+		}
+		return b, nil
+	})
+	if err != nil {
+		return value, err
+	}
+
+	// every caller decodes its own copy
+	if err := json.Unmarshal(v.([]byte), &value); err != nil {
+		return value, fmt.Errorf("%s: %w", op, err)
+	}
+	return value, nil
+}
+
+// Invalidate drops the keys, it is called after every write that changes them
+func (c *Cache) Invalidate(ctx context.Context, keys ...string) {
+	// loads started before the write may have read the old value: they must not store it,
+	// and callers arriving from now on must not wait for them
+	c.generation.Add(1)
+	for _, key := range keys {
+		c.group.Forget(key)
+	}
+	if err := c.store.Delete(context.WithoutCancel(ctx), keys...); err != nil {
+		c.log.Warn("cache invalidation failed", "keys", keys, "err", err.Error())
+	}
+}
+
+func (c *Cache) observe(key, result string) {
+	if c.metrics == nil {
+		return
+	}
+	kind, _, _ := strings.Cut(key, ":")
+	c.metrics.ObserveCache(kind, result)
+}
@@ -0,0 +1,178 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetch(t *testing.T) {
+	ctx := context.Background()
+	errLoad := errors.New("load failed")
+
+	tests := []struct {
+		name     string
+		stored   string // the value in the store before the fetch, none if empty
+		storeErr error
+		loadErr  error
+		want     string
+		wantErr  error
+		loads    int
+		// the value in the store after the fetch, none if empty
+		wantStored string
+		result     string
+	}{
+		{name: "hit", stored: `"cached"`, want: "cached", loads: 0, wantStored: `"cached"`, result: "hit"},
+		{name: "miss", want: "loaded", loads: 1, wantStored: `"loaded"`, result: "miss"},
+		{name: "undecodable value", stored: `{`, want: "loaded", loads: 1, wantStored: `"loaded"`, result: "error"},
+		{name: "failed read", storeErr: errors.New("store down"), want: "loaded", loads: 1, wantStored: `"loaded"`, result: "error"},
+		{name: "load failure", loadErr: errLoad, wantErr: errLoad, loads: 1, result: "miss"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &testStore{MemoryStore: NewMemoryStore(0), err: tt.storeErr}
+			if tt.stored != "" {
+				store.MemoryStore.Set(ctx, "value:1", []byte(tt.stored), time.Hour)
+			}
+			metrics := &testMetrics{}
+			c := New(store, time.Hour, metrics, testLogger{})
+
+			loads := 0
+			got, err := Fetch(ctx, c, "value:1", func(context.Context) (string, error) {
+				loads++
+				return "loaded", tt.loadErr
+			})
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Fatalf("Fetch: got %q, %v, want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+			if loads != tt.loads {
+				t.Errorf("loads: got %d, want %d", loads, tt.loads)
+			}
+			stored, ok, _ := store.MemoryStore.Get(ctx, "value:1")
+			if tt.wantStored == "" && ok || tt.wantStored != "" && string(stored) != tt.wantStored {
+				t.Errorf("stored: got %q, want %q", stored, tt.wantStored)
+			}
+			if want := []string{"value:" + tt.result}; !slices.Equal(metrics.observed, want) {
+				t.Errorf("metrics: got %v, want %v", metrics.observed, want)
+			}
+		})
+	}
+}
+
+func TestFetchSingleflight(t *testing.T) {
+	ctx := context.Background()
+	const callers = 10
+
+	// every caller misses before the first one loads
+	var missed sync.WaitGroup
+	missed.Add(callers)
+	store := &testStore{MemoryStore: NewMemoryStore(0), afterGet: missed.Done}
+	c := New(store, time.Hour, nil, testLogger{})
+
+	var loads atomic.Int32
+	load := func(context.Context) ([]int, error) {
+		loads.Add(1)
+		missed.Wait()
+		// leaves the last callers time to join the load
+		time.Sleep(50 * time.Millisecond)
+		return []int{1, 2}, nil
+	}
+
+	results := make([][]int, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := Fetch(ctx, c, "values:1", load)
+			if err != nil {
+				t.Errorf("Fetch: %v", err)
+			}
+			results[i] = got
+		}()
+	}
+	wg.Wait()
+
+	if n := loads.Load(); n != 1 {
+		t.Fatalf("loads: got %d, want 1", n)
+	}
+	// the callers get copies of their own
+	results[0][0] = 100
+	for i, got := range results[1:] {
+		if len(got) != 2 || got[0] != 1 || got[1] != 2 {
+			t.Fatalf("caller %d: got %v, want [1 2]", i+1, got)
+		}
+	}
+}
+
+func TestFetchInvalidatedDuringLoad(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(0)
+	c := New(store, time.Hour, nil, testLogger{})
+
+	// the stale load has read the old value when a write invalidates the key
+	read := make(chan struct{})
+	release := make(chan struct{})
+	stale := make(chan string)
+	go func() {
+		got, err := Fetch(ctx, c, "value:1", func(context.Context) (string, error) {
+			close(read)
+			<-release
+			return "old", nil
+		})
+		if err != nil {
+			t.Errorf("stale Fetch: %v", err)
+		}
+		stale <- got
+	}()
+	<-read
+	c.Invalidate(ctx, "value:1")
+
+	// callers arriving after the invalidation don't wait for the stale load
+	got, err := Fetch(ctx, c, "value:1", func(context.Context) (string, error) { return "new", nil })
+	if err != nil || got != "new" {
+		t.Fatalf("Fetch after Invalidate: got %q, %v, want %q", got, err, "new")
+	}
+	store.Delete(ctx, "value:1")
+
+	close(release)
+	if got := <-stale; got != "old" {
+		t.Fatalf("stale Fetch: got %q, want %q", got, "old")
+	}
+	if stored, ok, _ := store.Get(ctx, "value:1"); ok {
+		t.Fatalf("the stale load stored %q", stored)
+	}
+}
+
+// testStore is a MemoryStore whose reads fail with err if set, afterGet is called after every read
+type testStore struct {
+	*MemoryStore
+	err      error
+	afterGet func()
+}
+
+func (s *testStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	if s.afterGet != nil {
+		defer s.afterGet()
+	}
+	if s.err != nil {
+		return nil, false, s.err
+	}
+	return s.MemoryStore.Get(ctx, key)
+}
+
+type testMetrics struct {
+	observed []string
+}
+
+func (m *testMetrics) ObserveCache(kind, result string) {
+	m.observed = append(m.observed, kind+":"+result)
+}
+
+type testLogger struct{}
+
+func (testLogger) Warn(string, ...any) {}
@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+
+	// an op sets its key, or reads it with get
+	type op struct {
+		key string
+		get bool
+		ttl time.Duration
+	}
+	tests := []struct {
+		name string
+		size int
+		ops  []op
+		kept []string
+		gone []string
+	}{
+		{
+			name: "evicts the least recently set",
+			size: 2,
+			ops:  []op{{key: "a"}, {key: "b"}, {key: "c"}},
+			kept: []string{"b", "c"},
+			gone: []string{"a"},
+		},
+		{
+			name: "a get makes a key recently used",
+			size: 2,
+			ops:  []op{{key: "a"}, {key: "b"}, {key: "a", get: true}, {key: "c"}},
+			kept: []string{"a", "c"},
+			gone: []string{"b"},
+		},
+		{
+			name: "a set of a present key makes it recently used",
+			size: 2,
+			ops:  []op{{key: "a"}, {key: "b"}, {key: "a"}, {key: "c"}},
+			kept: []string{"a", "c"},
+			gone: []string{"b"},
+		},
+		{
+			name: "no size keeps everything",
+			size: 0,
+			ops:  []op{{key: "a"}, {key: "b"}, {key: "c"}},
+			kept: []string{"a", "b", "c"},
+		},
+		{
+			name: "expired values are gone",
+			size: 2,
+			ops:  []op{{key: "a", ttl: -time.Second}, {key: "b"}},
+			kept: []string{"b"},
+			gone: []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore(tt.size)
+			for _, op := range tt.ops {
+				if op.get {
+					if _, _, err := store.Get(ctx, op.key); err != nil {
+						t.Fatalf("Get %q: %v", op.key, err)
+					}
+					continue
+				}
+				ttl := op.ttl
+				if ttl == 0 {
+					ttl = time.Hour
+				}
+				if err := store.Set(ctx, op.key, []byte(op.key), ttl); err != nil {
+					t.Fatalf("Set %q: %v", op.key, err)
+				}
+			}
+
+			for _, key := range tt.kept {
+				value, ok, err := store.Get(ctx, key)
+				if err != nil || !ok || string(value) != key {
+					t.Errorf("Get %q: got %q, %v, %v, want it kept", key, value, ok, err)
+				}
+			}
+			for _, key := range tt.gone {
+				if value, ok, err := store.Get(ctx, key); err != nil || ok {
+					t.Errorf("Get %q: got %q, %v, %v, want it gone", key, value, ok, err)
+				}
+			}
+			if len(store.entries) != store.lru.Len() {
+				t.Errorf("%d entries but %d in the LRU list", len(store.entries), store.lru.Len())
+			}
+		})
+	}
+}
+
+func TestMemoryStoreDelete(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(0)
+	for _, key := range []string{"a", "b", "c"} {
+		if err := store.Set(ctx, key, []byte(key), time.Hour); err != nil {
+			t.Fatalf("Set %q: %v", key, err)
+		}
+	}
+
+	if err := store.Delete(ctx, "a", "c", "missing"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	for key, want := range map[string]bool{"a": false, "b": true, "c": false} {
+		if _, ok, _ := store.Get(ctx, key); ok != want {
+			t.Errorf("Get %q after Delete: got ok = %v, want %v", key, ok, want)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Package certreload serves a TLS certificate that is re-read from disk when its files change.
+package certreload
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+type Logger interface {
+	Info(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Reloader keeps the certificate loaded from certFile and keyFile.
+// The files are checked for changes at most once per interval, on the next TLS handshake.
+// A broken new pair is logged and the previous certificate is kept.
+type Reloader struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+	log      Logger
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	certMod   time.Time
+	keyMod    time.Time
+	lastCheck time.Time
+}
+
+// New loads the certificate, failing if it can't be loaded
+func New(certFile, keyFile string, interval time.Duration, log Logger) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile, interval: interval, log: log}
+
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		return nil, fmt.Errorf("certreload.New: %w", err)
+	}
+	if err := r.load(certMod, keyMod); err != nil {
+		return nil, fmt.Errorf("certreload.New: %w", err)
+	}
+
+	return r, nil
+}
+
+// GetCertificate is meant for tls.Config.GetCertificate
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.lastCheck) >= r.interval {
+		r.reloadIfChanged()
+	}
+
+	return r.cert, nil
+}
+
+func (r *Reloader) reloadIfChanged() {
+	r.lastCheck = time.Now()
+
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		r.log.Error("failed to check tls certificate", "err", err.Error())
+		return
+	}
+	if certMod.Equal(r.certMod) && keyMod.Equal(r.keyMod) {
+		return
+	}
+
+	if err := r.load(certMod, keyMod); err != nil {
+		// the files may be mid-rotation, the next check will try again
+		r.log.Error("failed to reload tls certificate, keeping the previous one", "err", err.Error())
+		return
+	}
+	r.log.Info("tls certificate reloaded", "cert_file", r.certFile)
+}
+
+func (r *Reloader) load(certMod, keyMod time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	r.cert = &cert
+	r.certMod = certMod
+	r.keyMod = keyMod
+	r.lastCheck = time.Now()
+
+	return nil
+}
+
+func (r *Reloader) modTimes() (certMod, keyMod time.Time, err error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
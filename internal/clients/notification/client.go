@@ -0,0 +1,211 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	notificationv1 "github.com/kourai55k/booking-service/pkg/api/notification/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Logger interface {
+	Debug(msg string, args ...interface{})
+}
+
+type Options struct {
+	// Timeout of a single NotifyOwner or NotifyGuest call
+	Timeout time.Duration
+	// RetriesCount is the number of retries after the first failed attempt
+	RetriesCount int
+	// RetryBackoff is the delay before the first retry, it doubles with every next one
+	RetryBackoff time.Duration
+}
+
+// Client sends reservation events to the notification service.
+// It is the publisher of the outbox dispatcher, which retries failed deliveries itself, so it's created without retries.
+type Client struct {
+	api  notificationv1.NotificationServiceClient
+	conn *grpc.ClientConn
+	log  Logger
+	opts Options
+}
+
+// New creates a client for the notification service at addr
+func New(addr string, opts Options, log Logger) (*Client, error) {
+	const op = "notification.New"
+
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	c := &Client{
+		api:  notificationv1.NewNotificationServiceClient(conn),
+		conn: conn,
+		log:  log,
+		opts: opts,
+	}
+
+	return c, nil
+}
+
+// Publish sends the event to the notification service, retrying transient failures with exponential backoff and jitter.
+// Waitlist offers go to the guest, tables ready to the walk-in, the other events to the restaurant owner.
+func (c *Client) Publish(ctx context.Context, event models.ReservationEvent) error {
+	const op = "notification.Publish"
+
+	notify := func(ctx context.Context) error {
+		_, err := c.api.NotifyOwner(ctx, toProto(event))
+		return err
+	}
+	switch event.Type {
+	case models.WaitlistOffered:
+		notify = func(ctx context.Context) error {
+			_, err := c.api.NotifyGuest(ctx, toProtoOffer(event))
+			return err
+		}
+	case models.WalkInNotified:
+		notify = func(ctx context.Context) error {
+			_, err := c.api.NotifyWalkIn(ctx, toProtoWalkIn(event))
+			return err
+		}
+	}
+	backoff := c.opts.RetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		callCtx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+		err := notify(callCtx)
+		cancel()
+
+		if err == nil {
+			c.log.Debug("notification sent", "reservation_id", event.Reservation.ID, "type", event.Type, "attempt", attempt+1)
+			return nil
+		}
+		if !retryable(err) || attempt >= c.opts.RetriesCount {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		select {
+		case <-time.After(backoff + rand.N(backoff/2+1)):
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", op, errors.Join(err, ctx.Err()))
+		}
+		backoff *= 2
+	}
+}
+
+// Close closes the connection to the notification service
+func (c *Client) Close() error {
+	if err := c.conn.Close(); err != nil {
+		return fmt.Errorf("notification.Close: %w", err)
+	}
+	return nil
+}
+
+// Check reports whether the notification service is reachable, connecting to it if the connection is idle
+func (c *Client) Check(ctx context.Context) error {
+	c.conn.Connect()
+	for {
+		state := c.conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Shutdown:
+			return errors.New("notification.Check: connection is closed")
+		}
+		if !c.conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("notification.Check: connection is %s: %w", state, ctx.Err())
+		}
+	}
+}
+
+func retryable(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+func toProto(event models.ReservationEvent) *notificationv1.NotifyOwnerRequest {
+	return &notificationv1.NotifyOwnerRequest{
+		OwnerId:     uint64(event.OwnerID),
+		Type:        toProtoEventType(event.Type),
+		Reservation: toProtoReservation(event.Reservation),
+		OccurredAt:  timestamppb.New(event.OccurredAt),
+	}
+}
+
+func toProtoOffer(event models.ReservationEvent) *notificationv1.NotifyGuestRequest {
+	req := &notificationv1.NotifyGuestRequest{
+		UserId:     uint64(event.Reservation.UserID),
+		Offered:    toProtoReservation(event.Reservation),
+		OccurredAt: timestamppb.New(event.OccurredAt),
+	}
+	if entry := event.Waitlist; entry != nil {
+		req.WaitlistEntryId = uint64(entry.ID)
+		req.Contact = entry.Contact
+		if entry.Offer != nil {
+			req.ClaimToken = entry.Offer.Token
+			req.ExpiresAt = timestamppb.New(entry.Offer.ExpiresAt)
+		}
+	}
+	return req
+}
+
+func toProtoWalkIn(event models.ReservationEvent) *notificationv1.NotifyWalkInRequest {
+	req := &notificationv1.NotifyWalkInRequest{
+		RestaurantId: uint64(event.Reservation.RestaurantID),
+		PartySize:    uint64(event.Reservation.PartySize),
+		TableId:      uint64(event.Reservation.TableID),
+		OccurredAt:   timestamppb.New(event.OccurredAt),
+	}
+	if walkIn := event.WalkIn; walkIn != nil {
+		req.WalkInId = uint64(walkIn.ID)
+		req.Name = walkIn.Name
+		req.Contact = walkIn.Contact
+	}
+	return req
+}
+
+func toProtoReservation(r models.Reservation) *notificationv1.Reservation {
+	return &notificationv1.Reservation{
+		Id:           uint64(r.ID),
+		RestaurantId: uint64(r.RestaurantID),
+		TableId:      uint64(r.TableID),
+		UserId:       uint64(r.UserID),
+		PartySize:    uint64(r.PartySize),
+		StartTime:    timestamppb.New(r.StartTime),
+		EndTime:      timestamppb.New(r.EndTime),
+	}
+}
+
+func toProtoEventType(t models.ReservationEventType) notificationv1.ReservationEventType {
+	switch t {
+	case models.ReservationCreated:
+		return notificationv1.ReservationEventType_RESERVATION_EVENT_TYPE_CREATED
+	case models.ReservationChanged:
+		return notificationv1.ReservationEventType_RESERVATION_EVENT_TYPE_CHANGED
+	case models.ReservationCancelled:
+		return notificationv1.ReservationEventType_RESERVATION_EVENT_TYPE_CANCELLED
+	default:
+		return notificationv1.ReservationEventType_RESERVATION_EVENT_TYPE_UNSPECIFIED
+	}
+}
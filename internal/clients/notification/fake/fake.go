@@ -0,0 +1,172 @@
+// Package fake provides an in-process notification service for tests and local development.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	notificationv1 "github.com/kourai55k/booking-service/pkg/api/notification/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type Logger interface {
+	Info(msg string, args ...interface{})
+}
+
+// Server records the notifications it receives instead of sending them to owners and guests
+type Server struct {
+	notificationv1.UnimplementedNotificationServiceServer
+
+	log  Logger
+	grpc *grpc.Server
+
+	mu       sync.Mutex
+	received []*notificationv1.NotifyOwnerRequest
+	offers   []*notificationv1.NotifyGuestRequest
+	walkIns  []*notificationv1.NotifyWalkInRequest
+	failNext int
+}
+
+func NewServer(log Logger) *Server {
+	return &Server{log: log}
+}
+
+// Start listens on addr (use "127.0.0.1:0" for a random port) and serves in the background.
+// It returns the address the server actually listens on.
+func (s *Server) Start(addr string) (string, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("fake.Server.Start: %w", err)
+	}
+
+	s.grpc = grpc.NewServer()
+	notificationv1.RegisterNotificationServiceServer(s.grpc, s)
+
+	go s.grpc.Serve(lis)
+
+	return lis.Addr().String(), nil
+}
+
+// Stop stops the server, waiting for the in-flight calls
+func (s *Server) Stop() {
+	if s.grpc != nil {
+		s.grpc.GracefulStop()
+	}
+}
+
+// FailNext makes the next n calls fail with codes.Unavailable, to simulate an outage
+func (s *Server) FailNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failNext = n
+}
+
+// Received returns copies of all the notifications received so far
+func (s *Server) Received() []*notificationv1.NotifyOwnerRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]*notificationv1.NotifyOwnerRequest, 0, len(s.received))
+	for _, req := range s.received {
+		res = append(res, proto.Clone(req).(*notificationv1.NotifyOwnerRequest))
+	}
+	return res
+}
+
+// Offers returns copies of all the waitlist offers received so far
+func (s *Server) Offers() []*notificationv1.NotifyGuestRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]*notificationv1.NotifyGuestRequest, 0, len(s.offers))
+	for _, req := range s.offers {
+		res = append(res, proto.Clone(req).(*notificationv1.NotifyGuestRequest))
+	}
+	return res
+}
+
+// WalkIns returns copies of all the tables ready sent to walk-ins so far
+func (s *Server) WalkIns() []*notificationv1.NotifyWalkInRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]*notificationv1.NotifyWalkInRequest, 0, len(s.walkIns))
+	for _, req := range s.walkIns {
+		res = append(res, proto.Clone(req).(*notificationv1.NotifyWalkInRequest))
+	}
+	return res
+}
+
+func (s *Server) NotifyOwner(_ context.Context, req *notificationv1.NotifyOwnerRequest) (*notificationv1.NotifyOwnerResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failNext > 0 {
+		s.failNext--
+		return nil, status.Error(codes.Unavailable, "fake outage")
+	}
+
+	s.received = append(s.received, proto.Clone(req).(*notificationv1.NotifyOwnerRequest))
+
+	if s.log != nil {
+		s.log.Info("owner notified",
+			"owner_id", req.GetOwnerId(),
+			"type", req.GetType().String(),
+			"reservation_id", req.GetReservation().GetId(),
+		)
+	}
+
+	return &notificationv1.NotifyOwnerResponse{}, nil
+}
+
+func (s *Server) NotifyGuest(_ context.Context, req *notificationv1.NotifyGuestRequest) (*notificationv1.NotifyGuestResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failNext > 0 {
+		s.failNext--
+		return nil, status.Error(codes.Unavailable, "fake outage")
+	}
+
+	s.offers = append(s.offers, proto.Clone(req).(*notificationv1.NotifyGuestRequest))
+
+	if s.log != nil {
+		s.log.Info("guest offered a table",
+			"waitlist_entry_id", req.GetWaitlistEntryId(),
+			"contact", req.GetContact(),
+			"table_id", req.GetOffered().GetTableId(),
+			"claim_token", req.GetClaimToken(),
+			"expires_at", req.GetExpiresAt().AsTime(),
+		)
+	}
+
+	return &notificationv1.NotifyGuestResponse{}, nil
+}
+
+func (s *Server) NotifyWalkIn(_ context.Context, req *notificationv1.NotifyWalkInRequest) (*notificationv1.NotifyWalkInResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failNext > 0 {
+		s.failNext--
+		return nil, status.Error(codes.Unavailable, "fake outage")
+	}
+
+	s.walkIns = append(s.walkIns, proto.Clone(req).(*notificationv1.NotifyWalkInRequest))
+
+	if s.log != nil {
+		s.log.Info("walk-in told their table is ready",
+			"walk_in_id", req.GetWalkInId(),
+			"contact", req.GetContact(),
+			"table_id", req.GetTableId(),
+		)
+	}
+
+	return &notificationv1.NotifyWalkInResponse{}, nil
+}
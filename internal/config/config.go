@@ -1,20 +1,298 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
+	"net/netip"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
+	"github.com/kourai55k/booking-service/internal/ratelimit"
 )
 
 type Config struct {
-	Env                string `yaml:"env" env-default:"local"`
-	PostgresConnString string `yaml:"PostgresConnString" env-required:"true"`
+	Env string `yaml:"env" env-default:"local"`
+	// LogLevel is debug, info, warn or error, by default debug for local and dev and info for prod
+	LogLevel string `yaml:"logLevel" env:"LOG_LEVEL"`
+	// ReloadWatchInterval makes the service check the config file for changes,
+	// it's only reloaded on SIGHUP if it's zero
+	ReloadWatchInterval time.Duration `yaml:"reloadWatchInterval" env:"CONFIG_RELOAD_WATCH_INTERVAL"`
+
+	PostgresConnString Secret             `yaml:"PostgresConnString" env:"POSTGRES_CONN_STRING"`
+	Auth               AuthConfig         `yaml:"auth"`
+	Postgres           PostgresConfig     `yaml:"postgres"`
+	HTTP               HTTPConfig         `yaml:"http"`
+	Health             HealthConfig       `yaml:"health"`
+	GRPC               GRPCConfig         `yaml:"grpc"`
+	Notification       NotificationConfig `yaml:"notification"`
+	Outbox             OutboxConfig       `yaml:"outbox"`
+	Holds              HoldsConfig        `yaml:"holds"`
+	Waitlist           WaitlistConfig     `yaml:"waitlist"`
+	Live               LiveConfig         `yaml:"live"`
+	RateLimit          RateLimitConfig    `yaml:"rateLimit"`
+	Cache              CacheConfig        `yaml:"cache"`
+	Tracing            TracingConfig      `yaml:"tracing"`
+	Features           FeaturesConfig     `yaml:"features"`
+}
+
+// Level is the configured log level, or the default of the environment
+func (c *Config) Level() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err == nil {
+		return level
+	}
+	if c.Env == "prod" {
+		return slog.LevelInfo
+	}
+	return slog.LevelDebug
+}
+
+// FeaturesConfig switches features on and off while the service runs.
+// Flags are named so they're off by default: cleanenv can't tell an explicit false from a missing value.
+type FeaturesConfig struct {
+	DisableRegistration bool `yaml:"disableRegistration" env:"FEATURE_DISABLE_REGISTRATION"`
+}
+
+// CacheConfig configures the read-through cache of restaurants and tables
+type CacheConfig struct {
+	Disabled bool          `yaml:"disabled" env:"CACHE_DISABLED"`
+	TTL      time.Duration `yaml:"ttl" env:"CACHE_TTL" env-default:"1m"`
+	// Size is the number of entries kept in memory
+	Size int `yaml:"size" env:"CACHE_SIZE" env-default:"10000"`
+	// RedisAddr shares the cache between instances through Redis, entries are kept in memory if it's empty
+	RedisAddr string `yaml:"redisAddr" env:"CACHE_REDIS_ADDR"`
+}
+
+// LogValue logs the cache settings with the backend they select: none, memory or redis
+func (c CacheConfig) LogValue() slog.Value {
+	backend := "memory"
+	switch {
+	case c.Disabled:
+		backend = "none"
+	case c.RedisAddr != "":
+		backend = "redis"
+	}
+	return slog.GroupValue(
+		slog.String("backend", backend),
+		slog.Duration("ttl", c.TTL),
+		slog.Int("size", c.Size),
+		slog.String("redisAddr", c.RedisAddr),
+	)
+}
+
+// AuthConfig configures the JWTs issued on login
+type AuthConfig struct {
+	JWTSecret Secret        `yaml:"jwtSecret" env:"JWT_SECRET"`
+	TokenTTL  time.Duration `yaml:"tokenTTL" env:"JWT_TOKEN_TTL" env-default:"1h"`
+}
+
+// PostgresConfig configures the connection to the database.
+// The service doesn't start until it connects, retrying ConnectAttempts times with doubling backoff.
+type PostgresConfig struct {
+	ConnectAttempts int           `yaml:"connectAttempts" env:"POSTGRES_CONNECT_ATTEMPTS" env-default:"5"`
+	ConnectBackoff  time.Duration `yaml:"connectBackoff" env:"POSTGRES_CONNECT_BACKOFF" env-default:"1s"`
+	Pool            PoolConfig    `yaml:"pool"`
+	Tx              TxConfig      `yaml:"tx"`
+}
+
+// TxConfig configures the transactions of units of work spanning several repositories
+type TxConfig struct {
+	// Isolation is read committed, repeatable read or serializable
+	Isolation    string        `yaml:"isolation" env:"POSTGRES_TX_ISOLATION" env-default:"serializable"`
+	MaxRetries   int           `yaml:"maxRetries" env:"POSTGRES_TX_MAX_RETRIES" env-default:"3"`
+	RetryBackoff time.Duration `yaml:"retryBackoff" env:"POSTGRES_TX_RETRY_BACKOFF" env-default:"10ms"`
+}
+
+// PoolConfig configures the connection pool, zero values keep the pgx defaults
+type PoolConfig struct {
+	MaxConns          int32         `yaml:"maxConns" env:"POSTGRES_POOL_MAX_CONNS"`
+	MinConns          int32         `yaml:"minConns" env:"POSTGRES_POOL_MIN_CONNS"`
+	MaxConnLifetime   time.Duration `yaml:"maxConnLifetime" env:"POSTGRES_POOL_MAX_CONN_LIFETIME"`
+	MaxConnIdleTime   time.Duration `yaml:"maxConnIdleTime" env:"POSTGRES_POOL_MAX_CONN_IDLE_TIME"`
+	HealthCheckPeriod time.Duration `yaml:"healthCheckPeriod" env:"POSTGRES_POOL_HEALTH_CHECK_PERIOD"`
 }
 
-// MustLoad loads the configuration
+// HTTPConfig configures the HTTP server
+type HTTPConfig struct {
+	Addr              string        `yaml:"addr" env:"HTTP_ADDR" env-default:":8080"`
+	ReadTimeout       time.Duration `yaml:"readTimeout" env:"HTTP_READ_TIMEOUT" env-default:"10s"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout" env:"HTTP_READ_HEADER_TIMEOUT" env-default:"5s"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout" env:"HTTP_WRITE_TIMEOUT" env-default:"15s"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout" env:"HTTP_IDLE_TIMEOUT" env-default:"60s"`
+	MaxHeaderBytes    int           `yaml:"maxHeaderBytes" env:"HTTP_MAX_HEADER_BYTES" env-default:"1048576"`
+	MaxBodyBytes      int64         `yaml:"maxBodyBytes" env:"HTTP_MAX_BODY_BYTES" env-default:"1048576"`
+	// AdminAddr serves the metrics, apart from the API so they aren't exposed with it
+	AdminAddr string `yaml:"adminAddr" env:"HTTP_ADMIN_ADDR" env-default:":8081"`
+	// ShutdownDelay is how long the service keeps serving after failing the readiness probe,
+	// so load balancers stop routing to it before connections are closed
+	ShutdownDelay time.Duration `yaml:"shutdownDelay" env:"HTTP_SHUTDOWN_DELAY" env-default:"5s"`
+	// ShutdownTimeout is the grace period for in-flight requests, after it they're cut off
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env:"HTTP_SHUTDOWN_TIMEOUT" env-default:"15s"`
+	TLS             TLSConfig     `yaml:"tls"`
+	CORS            CORSConfig    `yaml:"cors"`
+}
+
+// CORSConfig lists the origins browsers may call the API from, "*" allows any
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowedOrigins" env:"HTTP_CORS_ALLOWED_ORIGINS"`
+}
+
+// TLSConfig enables HTTPS when both files are set.
+// The files are re-read when they change, so certificates can be rotated without a restart.
+type TLSConfig struct {
+	CertFile string `yaml:"certFile" env:"HTTP_TLS_CERT_FILE"`
+	KeyFile  string `yaml:"keyFile" env:"HTTP_TLS_KEY_FILE"`
+	// ReloadInterval is how often the files are checked for changes
+	ReloadInterval time.Duration `yaml:"reloadInterval" env:"HTTP_TLS_RELOAD_INTERVAL" env-default:"1m"`
+}
+
+// Enabled reports whether HTTPS is configured
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// HealthConfig configures the readiness probe
+type HealthConfig struct {
+	// CheckTimeout bounds all dependency checks of one probe
+	CheckTimeout time.Duration `yaml:"checkTimeout" env:"HEALTH_CHECK_TIMEOUT" env-default:"2s"`
+}
+
+type GRPCConfig struct {
+	Addr string `yaml:"addr" env:"GRPC_ADDR" env-default:":9090"`
+}
+
+// NotificationConfig configures the client of the external notification service.
+// Notifications are disabled when Addr is empty.
+// Failed deliveries are retried by the outbox dispatcher, see OutboxConfig.
+type NotificationConfig struct {
+	Addr    string        `yaml:"addr" env:"NOTIFICATION_ADDR"`
+	Timeout time.Duration `yaml:"timeout" env-default:"2s"`
+}
+
+// HoldsConfig configures the holds that keep a table free while a guest completes their booking
+type HoldsConfig struct {
+	TTL time.Duration `yaml:"ttl" env:"HOLDS_TTL" env-default:"5m"`
+	// SweepInterval is how often expired holds are deleted, they stop taking their table when they expire anyway
+	SweepInterval time.Duration `yaml:"sweepInterval" env:"HOLDS_SWEEP_INTERVAL" env-default:"1m"`
+}
+
+// WaitlistConfig configures the offers of freed tables to waitlisted guests
+type WaitlistConfig struct {
+	// OfferTTL is how long a guest has to claim an offered table before it's offered to the next one
+	OfferTTL time.Duration `yaml:"offerTTL" env:"WAITLIST_OFFER_TTL" env-default:"15m"`
+	// SweepInterval is how often expired offers are passed on to the next guest
+	SweepInterval time.Duration `yaml:"sweepInterval" env:"WAITLIST_SWEEP_INTERVAL" env-default:"1m"`
+}
+
+// LiveConfig configures the live streams of table and reservation events to host stands and owner dashboards
+type LiveConfig struct {
+	// HeartbeatInterval is how often an idle stream gets a heartbeat, so proxies don't close it
+	HeartbeatInterval time.Duration `yaml:"heartbeatInterval" env:"LIVE_HEARTBEAT_INTERVAL" env-default:"15s"`
+	// History is the number of recent events kept per restaurant for clients resuming after a reconnect
+	History int `yaml:"history" env:"LIVE_HISTORY" env-default:"256"`
+	// Buffer is the number of events a stream may lag behind before it's closed for the client to resume
+	Buffer int `yaml:"buffer" env:"LIVE_BUFFER" env-default:"64"`
+}
+
+// OutboxConfig configures the dispatcher that delivers reservation events from the outbox
+type OutboxConfig struct {
+	// PollInterval is how often the outbox is checked for due events
+	PollInterval time.Duration `yaml:"pollInterval" env:"OUTBOX_POLL_INTERVAL" env-default:"1s"`
+	// BatchSize is the number of events leased at once
+	BatchSize int `yaml:"batchSize" env:"OUTBOX_BATCH_SIZE" env-default:"10"`
+	// LeaseTimeout is how long leased events are hidden from other dispatchers
+	LeaseTimeout time.Duration `yaml:"leaseTimeout" env:"OUTBOX_LEASE_TIMEOUT" env-default:"30s"`
+	// MaxAttempts is the number of deliveries after which an event is dead-lettered
+	MaxAttempts int `yaml:"maxAttempts" env:"OUTBOX_MAX_ATTEMPTS" env-default:"10"`
+	// RetryBackoff is the delay after the first failed delivery, it doubles with every next one up to MaxRetryBackoff
+	RetryBackoff    time.Duration `yaml:"retryBackoff" env:"OUTBOX_RETRY_BACKOFF" env-default:"5s"`
+	MaxRetryBackoff time.Duration `yaml:"maxRetryBackoff" env:"OUTBOX_MAX_RETRY_BACKOFF" env-default:"10m"`
+}
+
+// TracingConfig configures OpenTelemetry tracing.
+// Exporter is one of "otlp", "stdout" or "none", spans are still created but dropped with "none".
+type TracingConfig struct {
+	Exporter    string `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"none"`
+	ServiceName string `yaml:"serviceName" env:"TRACING_SERVICE_NAME" env-default:"booking-service"`
+	// OTLPEndpoint is the host:port of the OTLP gRPC collector
+	OTLPEndpoint string `yaml:"otlpEndpoint" env:"TRACING_OTLP_ENDPOINT" env-default:"localhost:4317"`
+	// OTLPInsecure disables TLS to the collector, e.g. for a local agent. It's off by default:
+	// with a true default cleanenv couldn't tell "otlpInsecure: false" from a missing key, so TLS couldn't be turned on.
+	OTLPInsecure bool `yaml:"otlpInsecure" env:"TRACING_OTLP_INSECURE"`
+	// SampleRatio is the share of new traces that are recorded, incoming sampled traces are always recorded
+	SampleRatio float64 `yaml:"sampleRatio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+}
+
+// RateLimitConfig configures the HTTP rate limiter.
+// Policies that aren't set get the defaults from defaultRateLimitPolicies.
+type RateLimitConfig struct {
+	Disabled bool `yaml:"disabled" env:"RATE_LIMIT_DISABLED"`
+	// TrustedProxies are the IPs or CIDRs whose X-Forwarded-For header is trusted
+	TrustedProxies []string `yaml:"trustedProxies" env:"RATE_LIMIT_TRUSTED_PROXIES"`
+	// RedisAddr makes instances share limits through Redis, buckets are kept in memory if it's empty
+	RedisAddr string `yaml:"redisAddr" env:"RATE_LIMIT_REDIS_ADDR"`
+
+	Default ratelimit.Policy `yaml:"default"`
+	Auth    ratelimit.Policy `yaml:"auth"`
+	User    ratelimit.Policy `yaml:"user"`
+}
+
+var defaultRateLimitPolicies = RateLimitConfig{
+	Default: ratelimit.Policy{Limit: 100, Period: time.Minute, Burst: 50},
+	Auth:    ratelimit.Policy{Limit: 10, Period: time.Minute, Burst: 5},
+	User:    ratelimit.Policy{Limit: 60, Period: time.Minute, Burst: 30},
+}
+
+// TrustedProxyPrefixes parses TrustedProxies, single IPs become one-address prefixes
+func (c RateLimitConfig) TrustedProxyPrefixes() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for _, s := range c.TrustedProxies {
+		s = strings.TrimSpace(s)
+		if !strings.Contains(s, "/") {
+			addr, err := netip.ParseAddr(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", s, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", s, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+func (c *RateLimitConfig) applyDefaults() {
+	if c.Default == (ratelimit.Policy{}) {
+		c.Default = defaultRateLimitPolicies.Default
+	}
+	if c.Auth == (ratelimit.Policy{}) {
+		c.Auth = defaultRateLimitPolicies.Auth
+	}
+	if c.User == (ratelimit.Policy{}) {
+		c.User = defaultRateLimitPolicies.User
+	}
+}
+
+// MustLoad loads the configuration, exiting if it can't be loaded or is invalid
 func MustLoad() *Config {
+	cfg, err := Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	return cfg
+}
+
+// Load reads and validates the configuration
+func Load() (*Config, error) {
 	// Only load .env file if CONFIG_PATH is not set (assumes running locally)
 	if os.Getenv("CONFIG_PATH") == "" {
 		if err := godotenv.Load(); err != nil {
@@ -23,22 +301,66 @@ func MustLoad() *Config {
 	}
 
 	// Get config path from environment
-	configPath := os.Getenv("CONFIG_PATH")
+	configPath := Path()
 	if configPath == "" {
-		log.Fatal("CONFIG_PATH is not set")
+		return nil, errors.New("CONFIG_PATH is not set")
 	}
 
 	// Check if config file exists
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		log.Fatalf("Config file does not exist: %s", configPath)
+		return nil, fmt.Errorf("config file does not exist: %s", configPath)
 	}
 
 	var cfg Config
 
 	// Read config
 	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
-		log.Fatalf("Cannot read config: %s", err)
+		return nil, fmt.Errorf("cannot read config: %w", err)
+	}
+
+	if err := cfg.readSecretFiles(); err != nil {
+		return nil, fmt.Errorf("cannot read secrets: %w", err)
+	}
+
+	cfg.RateLimit.applyDefaults()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config:\n%w", err)
+	}
+
+	return &cfg, nil
+}
+
+// Path is the path of the config file
+func Path() string {
+	return os.Getenv("CONFIG_PATH")
+}
+
+// readSecretFiles reads the secrets whose env variable has a _FILE variant set, like JWT_SECRET_FILE,
+// from that file. This is how Docker and Kubernetes secrets are mounted.
+func (c *Config) readSecretFiles() error {
+	secrets := []struct {
+		env    string
+		secret *Secret
+	}{
+		{"POSTGRES_CONN_STRING", &c.PostgresConnString},
+		{"JWT_SECRET", &c.Auth.JWTSecret},
+	}
+
+	var errs []error
+	for _, s := range secrets {
+		env, secret := s.env, s.secret
+		path := os.Getenv(env + "_FILE")
+		if path == "" {
+			continue
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s_FILE: %w", env, err))
+			continue
+		}
+		*secret = Secret(strings.TrimRight(string(b), "\r\n"))
 	}
 
-	return &cfg
+	return errors.Join(errs...)
 }
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// reloadable are the yaml paths of the fields that are applied without a restart
+var reloadable = map[string]bool{
+	"logLevel":          true,
+	"rateLimit.default": true,
+	"rateLimit.auth":    true,
+	"rateLimit.user":    true,
+	"http.cors":         true,
+	"features":          true,
+}
+
+type Logger interface {
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Reloader re-reads the config file and applies the parts that can change while the service runs
+type Reloader struct {
+	running *Config
+	apply   func(*Config)
+	log     Logger
+
+	mu sync.Mutex
+}
+
+// NewReloader creates a reloader for the service started with running.
+// apply gets every new valid config and must swap the reloadable parts in.
+func NewReloader(running *Config, apply func(*Config), log Logger) *Reloader {
+	return &Reloader{running: running, apply: apply, log: log}
+}
+
+// Reload loads the config and applies it. An invalid config is rejected as a whole.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next, err := Load()
+	if err != nil {
+		return fmt.Errorf("config.Reload: %w", err)
+	}
+
+	r.apply(next)
+
+	if fields := RestartRequired(r.running, next); len(fields) > 0 {
+		r.log.Warn("config reloaded, some changes need a restart", "fields", fields)
+		return nil
+	}
+	r.log.Info("config reloaded")
+
+	return nil
+}
+
+// Run reloads the config on SIGHUP, and when the file changes if ReloadWatchInterval is set, until ctx is done
+func (r *Reloader) Run(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var changed <-chan time.Time
+	var modTime time.Time
+	if r.running.ReloadWatchInterval > 0 {
+		ticker := time.NewTicker(r.running.ReloadWatchInterval)
+		defer ticker.Stop()
+		changed = ticker.C
+		modTime = fileModTime(Path())
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		case <-changed:
+			mt := fileModTime(Path())
+			if mt.Equal(modTime) {
+				continue
+			}
+			modTime = mt
+		}
+
+		if err := r.Reload(); err != nil {
+			r.log.Error("failed to reload config, keeping the current one", "err", err.Error())
+		}
+	}
+}
+
+// RestartRequired lists the fields that differ between the running config and next
+// but only take effect after a restart
+func RestartRequired(running, next *Config) []string {
+	var fields []string
+	diffFields(reflect.ValueOf(*running), reflect.ValueOf(*next), "", &fields)
+	return fields
+}
+
+func diffFields(a, b reflect.Value, prefix string, fields *[]string) {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "" {
+			name = f.Name
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		if reloadable[path] {
+			continue
+		}
+
+		if f.Type.Kind() == reflect.Struct {
+			diffFields(a.Field(i), b.Field(i), path, fields)
+			continue
+		}
+		if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			*fields = append(*fields, path)
+		}
+	}
+}
+
+func fileModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/kourai55k/booking-service/internal/ratelimit"
+	"github.com/kourai55k/booking-service/internal/tracing"
+)
+
+// minJWTSecretLength is the HS256 key size, shorter secrets are only accepted locally
+const minJWTSecretLength = 32
+
+// Validate reports every problem of the config at once, so they can be fixed in one go
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Env == "local" || c.Env == "dev" || c.Env == "prod", "env: must be local, dev or prod, got %q", c.Env)
+	if c.LogLevel != "" {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+			errs = append(errs, fmt.Errorf("logLevel: must be debug, info, warn or error, got %q", c.LogLevel))
+		}
+	}
+	check(c.ReloadWatchInterval >= 0, "reloadWatchInterval: must not be negative")
+	check(c.PostgresConnString != "", "PostgresConnString: is required (POSTGRES_CONN_STRING or POSTGRES_CONN_STRING_FILE)")
+
+	check(c.Auth.JWTSecret != "", "auth.jwtSecret: is required (JWT_SECRET or JWT_SECRET_FILE)")
+	check(c.Env == "local" || c.Auth.JWTSecret == "" || len(c.Auth.JWTSecret) >= minJWTSecretLength,
+		"auth.jwtSecret: must be at least %d bytes", minJWTSecretLength)
+	check(c.Auth.TokenTTL > 0, "auth.tokenTTL: must be positive")
+
+	check(c.Postgres.ConnectAttempts >= 1, "postgres.connectAttempts: must be at least 1")
+	check(c.Postgres.ConnectBackoff >= 0, "postgres.connectBackoff: must not be negative")
+	pool := c.Postgres.Pool
+	check(pool.MaxConns >= 0 && pool.MinConns >= 0, "postgres.pool: connection counts must not be negative")
+	check(pool.MaxConns == 0 || pool.MinConns <= pool.MaxConns, "postgres.pool.minConns: must not exceed maxConns")
+	switch c.Postgres.Tx.Isolation {
+	case "read committed", "repeatable read", "serializable":
+	default:
+		errs = append(errs, fmt.Errorf("postgres.tx.isolation: must be read committed, repeatable read or serializable, got %q", c.Postgres.Tx.Isolation))
+	}
+	check(c.Postgres.Tx.MaxRetries >= 0, "postgres.tx.maxRetries: must not be negative")
+	check(c.Postgres.Tx.RetryBackoff >= 0, "postgres.tx.retryBackoff: must not be negative")
+
+	check(c.HTTP.Addr != "", "http.addr: is required")
+	check(c.HTTP.AdminAddr != "", "http.adminAddr: is required")
+	check(c.HTTP.AdminAddr != c.HTTP.Addr, "http.adminAddr: must differ from http.addr")
+	check(c.HTTP.ReadTimeout >= 0 && c.HTTP.ReadHeaderTimeout >= 0 && c.HTTP.WriteTimeout >= 0 && c.HTTP.IdleTimeout >= 0,
+		"http: timeouts must not be negative")
+	check(c.HTTP.MaxHeaderBytes > 0, "http.maxHeaderBytes: must be positive")
+	check(c.HTTP.MaxBodyBytes > 0, "http.maxBodyBytes: must be positive")
+	check(c.HTTP.ShutdownDelay >= 0, "http.shutdownDelay: must not be negative")
+	check(c.HTTP.ShutdownTimeout > 0, "http.shutdownTimeout: must be positive")
+	check((c.HTTP.TLS.CertFile == "") == (c.HTTP.TLS.KeyFile == ""), "http.tls: certFile and keyFile must be set together")
+	check(!c.HTTP.TLS.Enabled() || c.HTTP.TLS.ReloadInterval > 0, "http.tls.reloadInterval: must be positive")
+
+	check(c.Health.CheckTimeout > 0, "health.checkTimeout: must be positive")
+	check(c.GRPC.Addr != "", "grpc.addr: is required")
+
+	if c.Notification.Addr != "" {
+		check(c.Notification.Timeout > 0, "notification.timeout: must be positive")
+	}
+
+	check(c.Holds.TTL > 0, "holds.ttl: must be positive")
+	check(c.Holds.SweepInterval > 0, "holds.sweepInterval: must be positive")
+	check(c.Waitlist.OfferTTL > 0, "waitlist.offerTTL: must be positive")
+	check(c.Waitlist.SweepInterval > 0, "waitlist.sweepInterval: must be positive")
+	check(c.Live.HeartbeatInterval > 0, "live.heartbeatInterval: must be positive")
+	check(c.Live.History > 0, "live.history: must be positive")
+	check(c.Live.Buffer > 0, "live.buffer: must be positive")
+
+	check(c.Outbox.PollInterval > 0, "outbox.pollInterval: must be positive")
+	check(c.Outbox.BatchSize > 0, "outbox.batchSize: must be positive")
+	check(c.Outbox.LeaseTimeout > 0, "outbox.leaseTimeout: must be positive")
+	check(c.Outbox.MaxAttempts > 0, "outbox.maxAttempts: must be positive")
+	check(c.Outbox.RetryBackoff > 0, "outbox.retryBackoff: must be positive")
+	check(c.Outbox.MaxRetryBackoff >= c.Outbox.RetryBackoff, "outbox.maxRetryBackoff: must not be less than retryBackoff")
+
+	if _, err := c.RateLimit.TrustedProxyPrefixes(); err != nil {
+		errs = append(errs, fmt.Errorf("rateLimit.trustedProxies: %w", err))
+	}
+	for _, p := range []struct {
+		name   string
+		policy ratelimit.Policy
+	}{
+		{"default", c.RateLimit.Default},
+		{"auth", c.RateLimit.Auth},
+		{"user", c.RateLimit.User},
+	} {
+		name, policy := p.name, p.policy
+		check(policy.Limit >= 0 && policy.Period >= 0 && policy.Burst >= 0,
+			"rateLimit.%s: limit, period and burst must not be negative", name)
+		check(policy.Limit == 0 || policy.Period > 0, "rateLimit.%s.period: must be positive when limit is set", name)
+	}
+
+	if !c.Cache.Disabled {
+		check(c.Cache.TTL > 0, "cache.ttl: must be positive")
+		check(c.Cache.Size > 0, "cache.size: must be positive")
+	}
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterOTLP, tracing.ExporterStdout, tracing.ExporterNone:
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter: must be otlp, stdout or none, got %q", c.Tracing.Exporter))
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sampleRatio: must be between 0 and 1")
+
+	return errors.Join(errs...)
+}
+
+// LogValue logs the config with its secrets redacted
+func (c Config) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("env", c.Env),
+		slog.String("logLevel", c.Level().String()),
+		slog.Duration("reloadWatchInterval", c.ReloadWatchInterval),
+		slog.Any("postgresConnString", c.PostgresConnString),
+		slog.Any("auth", c.Auth),
+		slog.Any("postgres", c.Postgres),
+		slog.Any("http", c.HTTP),
+		slog.Any("health", c.Health),
+		slog.Any("grpc", c.GRPC),
+		slog.Any("notification", c.Notification),
+		slog.Any("outbox", c.Outbox),
+		slog.Any("holds", c.Holds),
+		slog.Any("waitlist", c.Waitlist),
+		slog.Any("live", c.Live),
+		slog.Any("rateLimit", c.RateLimit),
+		slog.Any("cache", c.Cache),
+		slog.Any("tracing", c.Tracing),
+		slog.Any("features", c.Features),
+	)
+}
@@ -0,0 +1,216 @@
+package cached
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/cache"
+	"github.com/kourai55k/booking-service/internal/data"
+	"github.com/kourai55k/booking-service/internal/data/txhooks"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+func TestRestaurantRepo(t *testing.T) {
+	tests := []struct {
+		name string
+		// write changes the restaurant through repo, with the context of the unit of work if there is one
+		write func(ctx context.Context, repo *RestaurantRepo, id uint) error
+		inTx  bool
+		// commit is false for a unit of work rolled back
+		commit bool
+		// reloaded is whether the next read goes to the repository again
+		reloaded bool
+	}{
+		{
+			name:     "reads are cached",
+			write:    func(context.Context, *RestaurantRepo, uint) error { return nil },
+			reloaded: false,
+		},
+		{
+			name: "a write invalidates right away",
+			write: func(ctx context.Context, repo *RestaurantRepo, id uint) error {
+				return repo.UpdateRestraunt(ctx, &models.Restaurant{ID: id, Name: "Renamed"})
+			},
+			reloaded: true,
+		},
+		{
+			name: "a write in a unit of work invalidates once it commits",
+			write: func(ctx context.Context, repo *RestaurantRepo, id uint) error {
+				return repo.UpdateBookingRules(ctx, id, models.BookingRules{Buffer: 15 * time.Minute})
+			},
+			inTx:     true,
+			commit:   true,
+			reloaded: true,
+		},
+		{
+			name: "a write in a unit of work rolled back doesn't invalidate",
+			write: func(ctx context.Context, repo *RestaurantRepo, id uint) error {
+				return repo.UpdateRestraunt(ctx, &models.Restaurant{ID: id, Name: "Renamed"})
+			},
+			inTx:     true,
+			reloaded: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			next := &countingRestaurantRepo{InMemoryRestaurantRepo: data.NewInMemoryRestaurantRepo()}
+			c := newCache()
+			repo := NewRestaurantRepo(next, c)
+
+			id, err := repo.CreateRestaurant(ctx, &models.Restaurant{Name: "First", OwnerID: 1})
+			if err != nil {
+				t.Fatalf("CreateRestaurant: %v", err)
+			}
+			if _, err := repo.GetRestaurantByID(ctx, id); err != nil {
+				t.Fatalf("GetRestaurantByID: %v", err)
+			}
+
+			writeCtx, commit := ctx, func() {}
+			writer := repo
+			if tt.inTx {
+				writeCtx, commit = txhooks.Begin(ctx)
+				writer = NewTxRestaurantRepo(next, c)
+			}
+			if err := tt.write(writeCtx, writer, id); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+
+			if tt.inTx {
+				// readers outside the unit of work keep the cached value until it commits
+				reads := next.reads
+				if _, err := repo.GetRestaurantByID(ctx, id); err != nil {
+					t.Fatalf("GetRestaurantByID before the commit: %v", err)
+				}
+				if next.reads != reads {
+					t.Fatalf("the cache was invalidated before the unit of work committed")
+				}
+			}
+			if tt.commit {
+				commit()
+			}
+
+			reads := next.reads
+			if _, err := repo.GetRestaurantByID(ctx, id); err != nil {
+				t.Fatalf("GetRestaurantByID: %v", err)
+			}
+			if reloaded := next.reads != reads; reloaded != tt.reloaded {
+				t.Fatalf("reloaded = %v, want %v", reloaded, tt.reloaded)
+			}
+		})
+	}
+}
+
+func TestTableRepo(t *testing.T) {
+	ctx := context.Background()
+	c := newCache()
+	restaurants := NewRestaurantRepo(data.NewInMemoryRestaurantRepo(), c)
+	next := &countingTableRepo{InMemoryTableRepo: data.NewInMemoryTableRepo()}
+	repo := NewTableRepo(next, c)
+
+	restaurantID, err := restaurants.CreateRestaurant(ctx, &models.Restaurant{Name: "First", OwnerID: 1})
+	if err != nil {
+		t.Fatalf("CreateRestaurant: %v", err)
+	}
+	tableID, err := repo.CreateTable(ctx, &models.Table{RestaurantID: restaurantID, Number: 1, Capacity: 2, IsAvailable: true})
+	if err != nil {
+		t.Fatalf("CreateTable: %v", err)
+	}
+
+	// the writes of a table drop it and the lists of its restaurant, deleting the restaurant drops the lists
+	tests := []struct {
+		name  string
+		write func() error
+		// reloaded is how many of the three reads go to the repository after the write
+		reloaded int
+	}{
+		{name: "UpdateTable", write: func() error {
+			return repo.UpdateTable(ctx, &models.Table{ID: tableID, Capacity: 4, IsAvailable: true})
+		}, reloaded: 3},
+		{name: "SetTableOccupancy", write: func() error {
+			return repo.SetTableOccupancy(ctx, tableID, time.Now(), time.Now().Add(time.Hour), 2)
+		}, reloaded: 3},
+		{name: "DeleteRestraunt", write: func() error { return restaurants.DeleteRestraunt(ctx, restaurantID) }, reloaded: 2},
+		{name: "DeleteTable", write: func() error { return repo.DeleteTable(ctx, tableID) }, reloaded: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readAll := func() {
+				t.Helper()
+				if _, err := repo.GetTableByID(ctx, tableID); err != nil {
+					t.Fatalf("GetTableByID: %v", err)
+				}
+				if _, err := repo.GetTablesByRestaurantID(ctx, restaurantID); err != nil {
+					t.Fatalf("GetTablesByRestaurantID: %v", err)
+				}
+				if _, err := repo.GetAvailableTablesByRestaurantID(ctx, restaurantID); err != nil {
+					t.Fatalf("GetAvailableTablesByRestaurantID: %v", err)
+				}
+			}
+			readAll()
+			reads := next.reads
+			readAll()
+			if next.reads != reads {
+				t.Fatalf("reads before the write weren't cached")
+			}
+
+			if err := tt.write(); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			reads = next.reads
+			// the lists are read, the table may be gone
+			repo.GetTablesByRestaurantID(ctx, restaurantID)
+			repo.GetAvailableTablesByRestaurantID(ctx, restaurantID)
+			repo.GetTableByID(ctx, tableID)
+			if got := next.reads - reads; got != tt.reloaded {
+				t.Fatalf("reads after %s: got %d from the repository, want %d", tt.name, got, tt.reloaded)
+			}
+		})
+	}
+}
+
+func newCache() *cache.Cache {
+	return cache.New(cache.NewMemoryStore(0), time.Hour, nil, testLogger{})
+}
+
+type testLogger struct{}
+
+func (testLogger) Warn(string, ...any) {}
+
+// countingRestaurantRepo counts the reads that reach the repository
+type countingRestaurantRepo struct {
+	*data.InMemoryRestaurantRepo
+	reads int
+}
+
+func (r *countingRestaurantRepo) GetRestaurantByID(ctx context.Context, id uint) (*models.Restaurant, error) {
+	r.reads++
+	return r.InMemoryRestaurantRepo.GetRestaurantByID(ctx, id)
+}
+
+func (r *countingRestaurantRepo) GetRestaurants(ctx context.Context) ([]*models.Restaurant, error) {
+	r.reads++
+	return r.InMemoryRestaurantRepo.GetRestaurants(ctx)
+}
+
+// countingTableRepo counts the table reads that reach the repository
+type countingTableRepo struct {
+	*data.InMemoryTableRepo
+	reads int
+}
+
+func (r *countingTableRepo) GetTableByID(ctx context.Context, id uint) (*models.Table, error) {
+	r.reads++
+	return r.InMemoryTableRepo.GetTableByID(ctx, id)
+}
+
+func (r *countingTableRepo) GetTablesByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.Table, error) {
+	r.reads++
+	return r.InMemoryTableRepo.GetTablesByRestaurantID(ctx, restaurantID)
+}
+
+func (r *countingTableRepo) GetAvailableTablesByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.Table, error) {
+	r.reads++
+	return r.InMemoryTableRepo.GetAvailableTablesByRestaurantID(ctx, restaurantID)
+}
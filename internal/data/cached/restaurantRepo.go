@@ -0,0 +1,95 @@
+// Package cached decorates repositories with a read-through cache.
+// Reads are served from the cache, writes go to the repository and invalidate what they change.
+package cached
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/kourai55k/booking-service/internal/cache"
+	"github.com/kourai55k/booking-service/internal/data/txhooks"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+type RestaurantRepository interface {
+	CreateRestaurant(ctx context.Context, restaurant *models.Restaurant) (uint, error)
+	GetRestaurants(ctx context.Context) ([]*models.Restaurant, error)
+	GetRestaurantByID(ctx context.Context, id uint) (*models.Restaurant, error)
+	UpdateRestraunt(ctx context.Context, restaurant *models.Restaurant) error
+	UpdateBookingRules(ctx context.Context, restaurantID uint, rules models.BookingRules) error
+	DeleteRestraunt(ctx context.Context, id uint) error
+}
+
+const restaurantsKey = "restaurants"
+
+func restaurantKey(id uint) string {
+	return "restaurant:" + strconv.FormatUint(uint64(id), 10)
+}
+
+type RestaurantRepo struct {
+	next  RestaurantRepository
+	cache *cache.Cache
+	// uncachedReads is set inside units of work
+	uncachedReads bool
+}
+
+func NewRestaurantRepo(next RestaurantRepository, cache *cache.Cache) *RestaurantRepo {
+	return &RestaurantRepo{next: next, cache: cache}
+}
+
+// NewTxRestaurantRepo decorates a repository bound to a transaction: reads go to the transaction,
+// which must see its own writes, and writes invalidate once the transaction commits, so concurrent
+// readers can't cache the rows it's about to replace.
+func NewTxRestaurantRepo(next RestaurantRepository, cache *cache.Cache) *RestaurantRepo {
+	return &RestaurantRepo{next: next, cache: cache, uncachedReads: true}
+}
+
+func (r *RestaurantRepo) CreateRestaurant(ctx context.Context, restaurant *models.Restaurant) (uint, error) {
+	id, err := r.next.CreateRestaurant(ctx, restaurant)
+	if err != nil {
+		return 0, err
+	}
+	r.invalidate(ctx, restaurantsKey)
+	return id, nil
+}
+
+func (r *RestaurantRepo) GetRestaurants(ctx context.Context) ([]*models.Restaurant, error) {
+	if r.uncachedReads {
+		return r.next.GetRestaurants(ctx)
+	}
+	return cache.Fetch(ctx, r.cache, restaurantsKey, r.next.GetRestaurants)
+}
+
+func (r *RestaurantRepo) GetRestaurantByID(ctx context.Context, id uint) (*models.Restaurant, error) {
+	if r.uncachedReads {
+		return r.next.GetRestaurantByID(ctx, id)
+	}
+	return cache.Fetch(ctx, r.cache, restaurantKey(id), func(ctx context.Context) (*models.Restaurant, error) {
+		return r.next.GetRestaurantByID(ctx, id)
+	})
+}
+
+func (r *RestaurantRepo) UpdateRestraunt(ctx context.Context, restaurant *models.Restaurant) error {
+	err := r.next.UpdateRestraunt(ctx, restaurant)
+	// invalidated on errors too, the write may have happened before the error
+	r.invalidate(ctx, restaurantsKey, restaurantKey(restaurant.ID))
+	return err
+}
+
+func (r *RestaurantRepo) UpdateBookingRules(ctx context.Context, restaurantID uint, rules models.BookingRules) error {
+	err := r.next.UpdateBookingRules(ctx, restaurantID, rules)
+	r.invalidate(ctx, restaurantsKey, restaurantKey(restaurantID))
+	return err
+}
+
+// DeleteRestraunt also drops the table lists of the restaurant, its tables go with it
+func (r *RestaurantRepo) DeleteRestraunt(ctx context.Context, id uint) error {
+	err := r.next.DeleteRestraunt(ctx, id)
+	r.invalidate(ctx, restaurantsKey, restaurantKey(id), tablesKey(id), availableTablesKey(id))
+	return err
+}
+
+// invalidate drops the keys once the unit of work of ctx commits, right away outside units of work
+func (r *RestaurantRepo) invalidate(ctx context.Context, keys ...string) {
+	txhooks.AfterCommit(ctx, func() { r.cache.Invalidate(ctx, keys...) })
+}
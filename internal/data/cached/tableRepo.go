@@ -0,0 +1,135 @@
+package cached
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/cache"
+	"github.com/kourai55k/booking-service/internal/data/txhooks"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+type TableRepository interface {
+	CreateTable(ctx context.Context, table *models.Table) (uint, error)
+	GetTablesByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.Table, error)
+	GetAvailableTablesByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.Table, error)
+	GetTableByID(ctx context.Context, id uint) (*models.Table, error)
+	UpdateTable(ctx context.Context, table *models.Table) error
+	SetTableOccupancy(ctx context.Context, id uint, since, until time.Time, partySize uint) error
+	DeleteTable(ctx context.Context, id uint) error
+
+	CreateTableCombination(ctx context.Context, combination *models.TableCombination) (uint, error)
+	GetTableCombinationsByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.TableCombination, error)
+	DeleteTableCombination(ctx context.Context, id uint) error
+}
+
+func tableKey(id uint) string {
+	return "table:" + strconv.FormatUint(uint64(id), 10)
+}
+
+func tablesKey(restaurantID uint) string {
+	return "tables:" + strconv.FormatUint(uint64(restaurantID), 10)
+}
+
+func availableTablesKey(restaurantID uint) string {
+	return "available-tables:" + strconv.FormatUint(uint64(restaurantID), 10)
+}
+
+type TableRepo struct {
+	next  TableRepository
+	cache *cache.Cache
+	// uncachedReads is set inside units of work
+	uncachedReads bool
+}
+
+func NewTableRepo(next TableRepository, cache *cache.Cache) *TableRepo {
+	return &TableRepo{next: next, cache: cache}
+}
+
+// NewTxTableRepo decorates a repository bound to a transaction: reads go to the transaction,
+// which must see its own writes, and writes invalidate once the transaction commits, so concurrent
+// readers can't cache the rows it's about to replace.
+func NewTxTableRepo(next TableRepository, cache *cache.Cache) *TableRepo {
+	return &TableRepo{next: next, cache: cache, uncachedReads: true}
+}
+
+func (r *TableRepo) CreateTable(ctx context.Context, table *models.Table) (uint, error) {
+	id, err := r.next.CreateTable(ctx, table)
+	if err != nil {
+		return 0, err
+	}
+	r.invalidate(ctx, tablesKey(table.RestaurantID), availableTablesKey(table.RestaurantID))
+	return id, nil
+}
+
+func (r *TableRepo) GetTablesByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.Table, error) {
+	if r.uncachedReads {
+		return r.next.GetTablesByRestaurantID(ctx, restaurantID)
+	}
+	return cache.Fetch(ctx, r.cache, tablesKey(restaurantID), func(ctx context.Context) ([]*models.Table, error) {
+		return r.next.GetTablesByRestaurantID(ctx, restaurantID)
+	})
+}
+
+func (r *TableRepo) GetAvailableTablesByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.Table, error) {
+	if r.uncachedReads {
+		return r.next.GetAvailableTablesByRestaurantID(ctx, restaurantID)
+	}
+	return cache.Fetch(ctx, r.cache, availableTablesKey(restaurantID), func(ctx context.Context) ([]*models.Table, error) {
+		return r.next.GetAvailableTablesByRestaurantID(ctx, restaurantID)
+	})
+}
+
+func (r *TableRepo) GetTableByID(ctx context.Context, id uint) (*models.Table, error) {
+	if r.uncachedReads {
+		return r.next.GetTableByID(ctx, id)
+	}
+	return cache.Fetch(ctx, r.cache, tableKey(id), func(ctx context.Context) (*models.Table, error) {
+		return r.next.GetTableByID(ctx, id)
+	})
+}
+
+func (r *TableRepo) UpdateTable(ctx context.Context, table *models.Table) error {
+	return r.write(ctx, table.ID, func() error { return r.next.UpdateTable(ctx, table) })
+}
+
+func (r *TableRepo) SetTableOccupancy(ctx context.Context, id uint, since, until time.Time, partySize uint) error {
+	return r.write(ctx, id, func() error { return r.next.SetTableOccupancy(ctx, id, since, until, partySize) })
+}
+
+func (r *TableRepo) DeleteTable(ctx context.Context, id uint) error {
+	return r.write(ctx, id, func() error { return r.next.DeleteTable(ctx, id) })
+}
+
+// Table combinations aren't cached: they're read with the occupancy of the tables, which always goes to the database
+
+func (r *TableRepo) CreateTableCombination(ctx context.Context, combination *models.TableCombination) (uint, error) {
+	return r.next.CreateTableCombination(ctx, combination)
+}
+
+func (r *TableRepo) GetTableCombinationsByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.TableCombination, error) {
+	return r.next.GetTableCombinationsByRestaurantID(ctx, restaurantID)
+}
+
+func (r *TableRepo) DeleteTableCombination(ctx context.Context, id uint) error {
+	return r.next.DeleteTableCombination(ctx, id)
+}
+
+// write runs a write of the table and drops it and the lists of its restaurant,
+// which is looked up in the repository first since writes don't always carry it
+func (r *TableRepo) write(ctx context.Context, id uint, write func() error) error {
+	table, err := r.next.GetTableByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = write()
+	r.invalidate(ctx, tableKey(id), tablesKey(table.RestaurantID), availableTablesKey(table.RestaurantID))
+	return err
+}
+
+// invalidate drops the keys once the unit of work of ctx commits, right away outside units of work
+func (r *TableRepo) invalidate(ctx context.Context, keys ...string) {
+	txhooks.AfterCommit(ctx, func() { r.cache.Invalidate(ctx, keys...) })
+}
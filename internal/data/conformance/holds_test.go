@@ -0,0 +1,169 @@
+package conformance
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/internal/service"
+)
+
+// testHoldRepository checks service.HoldRepository and how holds and reservations take tables from each other
+func testHoldRepository(ctx context.Context, t *testing.T, repos service.Repositories) {
+	r := repos.Holds
+
+	restaurantID, ok := createRestaurant(ctx, t, repos, "holds")
+	if !ok {
+		return
+	}
+	tableIDs := make([]uint, 0, 4)
+	for number := uint(1); number <= 4; number++ {
+		id, err := repos.Tables.CreateTable(ctx, &models.Table{Number: number, Capacity: 4, IsAvailable: true, RestaurantID: restaurantID})
+		if !noError(t, "creating a table", err) {
+			return
+		}
+		tableIDs = append(tableIDs, id)
+	}
+	guestID, err := repos.Users.CreateUser(ctx, &models.User{Name: "Guest", Login: "hold-guest", HashPass: "hash", Role: "user"})
+	if !noError(t, "creating a guest", err) {
+		return
+	}
+
+	// whole seconds in UTC, which every backend stores exactly
+	day := time.Now().UTC().Truncate(24 * time.Hour).Add(48 * time.Hour)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+	live := time.Now().UTC().Truncate(time.Second).Add(time.Hour)
+	expired := time.Now().UTC().Truncate(time.Second).Add(-time.Minute)
+	hold := func(token string, tableID uint, start, end, expiresAt time.Time) models.Hold {
+		return models.Hold{
+			Token: token, PartySize: 2, StartTime: start, EndTime: end, ExpiresAt: expiresAt,
+			RestaurantID: restaurantID, TableID: tableID, UserID: guestID,
+		}
+	}
+
+	evening := hold("evening", tableIDs[0], at(19), at(21), live)
+	id, err := r.CreateHold(ctx, &evening)
+	if !noError(t, "CreateHold", err) {
+		return
+	}
+	evening.ID = id
+
+	got, err := r.GetHoldByToken(ctx, "evening")
+	if noError(t, "GetHoldByToken", err) {
+		equal(t, "GetHoldByToken", normalizeHold(*got), evening)
+	}
+	_, err = r.GetHoldByToken(ctx, "missing")
+	errorIs(t, "GetHoldByToken of a missing hold", err, domain.ErrHoldNotFound)
+
+	clash := hold("clash", tableIDs[0], at(20), at(22), live)
+	_, err = r.CreateHold(ctx, &clash)
+	errorIs(t, "CreateHold of a held table", err, domain.ErrTableAlreadyReserved)
+	_, err = repos.Reservations.CreateReservation(ctx, &models.Reservation{
+		PartySize: 2, StartTime: at(20), EndTime: at(22), Status: models.ReservationStatusPending,
+		RestaurantID: restaurantID, TableID: tableIDs[0], UserID: guestID,
+	})
+	errorIs(t, "CreateReservation of a held table", err, domain.ErrTableAlreadyReserved)
+
+	// an expired hold doesn't take its table, even before it's deleted
+	stale := hold("stale", tableIDs[1], at(19), at(21), expired)
+	staleID, err := r.CreateHold(ctx, &stale)
+	if !noError(t, "CreateHold of an expired hold", err) {
+		return
+	}
+	_, err = repos.Reservations.CreateReservation(ctx, &models.Reservation{
+		PartySize: 2, StartTime: at(19), EndTime: at(21), Status: models.ReservationStatusConfirmed,
+		RestaurantID: restaurantID, TableID: tableIDs[1], UserID: guestID,
+	})
+	noError(t, "CreateReservation over an expired hold", err)
+	occupiedByReservation := hold("reserved", tableIDs[1], at(20), at(21), live)
+	_, err = r.CreateHold(ctx, &occupiedByReservation)
+	errorIs(t, "CreateHold of a reserved table", err, domain.ErrTableAlreadyReserved)
+
+	occupied, err := repos.Reservations.GetOccupiedTableIDs(ctx, restaurantID, at(20), at(21))
+	if noError(t, "GetOccupiedTableIDs", err) {
+		equal(t, "GetOccupiedTableIDs", occupied, []uint{tableIDs[0], tableIDs[1]})
+	}
+	occupied, err = repos.Reservations.GetOccupiedTableIDs(ctx, restaurantID, at(21), at(22))
+	if noError(t, "GetOccupiedTableIDs after the holds and reservations", err) && len(occupied) != 0 {
+		t.Errorf("GetOccupiedTableIDs after the holds and reservations: got %v, want none", occupied)
+	}
+
+	// a party seated at a table takes it until it's expected to leave
+	err = repos.Tables.SetTableOccupancy(ctx, tableIDs[2], time.Now().UTC().Truncate(time.Second), at(19), 2)
+	if noError(t, "SetTableOccupancy", err) {
+		seated := hold("seated", tableIDs[2], at(18), at(20), live)
+		_, err = r.CreateHold(ctx, &seated)
+		errorIs(t, "CreateHold of a table a party stays at", err, domain.ErrTableAlreadyReserved)
+		_, err = repos.Reservations.CreateReservation(ctx, &models.Reservation{
+			PartySize: 2, StartTime: at(18), EndTime: at(20), Status: models.ReservationStatusConfirmed,
+			RestaurantID: restaurantID, TableID: tableIDs[2], UserID: guestID,
+		})
+		errorIs(t, "CreateReservation of a table a party stays at", err, domain.ErrTableAlreadyReserved)
+		after := hold("after", tableIDs[2], at(19), at(20), live)
+		_, err = r.CreateHold(ctx, &after)
+		noError(t, "CreateHold once the party is expected to leave", err)
+	}
+
+	deleted, err := r.DeleteExpiredHolds(ctx)
+	if noError(t, "DeleteExpiredHolds", err) && deleted != 1 {
+		t.Errorf("DeleteExpiredHolds: deleted %d holds, want 1", deleted)
+	}
+	_, err = r.GetHoldByToken(ctx, "stale")
+	errorIs(t, "GetHoldByToken after DeleteExpiredHolds", err, domain.ErrHoldNotFound)
+	err = r.DeleteHold(ctx, staleID)
+	errorIs(t, "DeleteHold of a deleted hold", err, domain.ErrHoldNotFound)
+
+	err = r.DeleteHold(ctx, evening.ID)
+	if noError(t, "DeleteHold", err) {
+		_, err = repos.Reservations.CreateReservation(ctx, &models.Reservation{
+			PartySize: 2, StartTime: at(20), EndTime: at(22), Status: models.ReservationStatusConfirmed,
+			RestaurantID: restaurantID, TableID: tableIDs[0], UserID: guestID,
+		})
+		noError(t, "CreateReservation of a released table", err)
+	}
+
+	// a hold takes its table for its buffer too, like the reservation it's converted into
+	_, err = repos.Reservations.CreateReservation(ctx, &models.Reservation{
+		PartySize: 2, StartTime: at(21), EndTime: at(22), Status: models.ReservationStatusConfirmed,
+		RestaurantID: restaurantID, TableID: tableIDs[3], UserID: guestID,
+	})
+	if !noError(t, "CreateReservation after a buffered hold", err) {
+		return
+	}
+	intoReservation := hold("into-reservation", tableIDs[3], at(20), at(21), live)
+	intoReservation.Buffer = 30 * time.Minute
+	_, err = r.CreateHold(ctx, &intoReservation)
+	errorIs(t, "CreateHold whose buffer runs into a reservation", err, domain.ErrTableAlreadyReserved)
+
+	buffered := hold("buffered", tableIDs[3], at(19), at(20), live)
+	buffered.Buffer = 30 * time.Minute
+	id, err = r.CreateHold(ctx, &buffered)
+	if !noError(t, "CreateHold with a buffer", err) {
+		return
+	}
+	buffered.ID = id
+	got, err = r.GetHoldByToken(ctx, "buffered")
+	if noError(t, "GetHoldByToken of a hold with a buffer", err) {
+		equal(t, "GetHoldByToken of a hold with a buffer", normalizeHold(*got), buffered)
+	}
+	_, err = repos.Reservations.CreateReservation(ctx, &models.Reservation{
+		PartySize: 2, StartTime: at(20), EndTime: at(21), Status: models.ReservationStatusConfirmed,
+		RestaurantID: restaurantID, TableID: tableIDs[3], UserID: guestID,
+	})
+	errorIs(t, "CreateReservation in the buffer of a hold", err, domain.ErrTableAlreadyReserved)
+	occupied, err = repos.Reservations.GetOccupiedTableIDs(ctx, restaurantID, at(20), at(20).Add(15*time.Minute))
+	if noError(t, "GetOccupiedTableIDs in the buffer of a hold", err) && !slices.Contains(occupied, tableIDs[3]) {
+		t.Errorf("GetOccupiedTableIDs in the buffer of a hold: got %v, want table %d in it", occupied, tableIDs[3])
+	}
+}
+
+// normalizeHold puts the times in UTC, backends may return them in another location
+func normalizeHold(hold models.Hold) models.Hold {
+	hold.StartTime = hold.StartTime.UTC()
+	hold.EndTime = hold.EndTime.UTC()
+	hold.ExpiresAt = hold.ExpiresAt.UTC()
+	return hold
+}
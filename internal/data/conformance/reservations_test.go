@@ -0,0 +1,317 @@
+package conformance
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/internal/service"
+)
+
+// testReservationRepository checks service.ReservationRepository
+func testReservationRepository(ctx context.Context, t *testing.T, repos service.Repositories) {
+	r := repos.Reservations
+
+	restaurantID, ok := createRestaurant(ctx, t, repos, "reservations")
+	if !ok {
+		return
+	}
+	tableID, err := repos.Tables.CreateTable(ctx, &models.Table{Number: 1, Capacity: 4, IsAvailable: true, RestaurantID: restaurantID})
+	if !noError(t, "creating a table", err) {
+		return
+	}
+	otherTableID, err := repos.Tables.CreateTable(ctx, &models.Table{Number: 2, Capacity: 4, IsAvailable: true, RestaurantID: restaurantID})
+	if !noError(t, "creating a table", err) {
+		return
+	}
+	thirdTableID, err := repos.Tables.CreateTable(ctx, &models.Table{Number: 3, Capacity: 4, IsAvailable: true, RestaurantID: restaurantID})
+	if !noError(t, "creating a table", err) {
+		return
+	}
+	guestID, err := repos.Users.CreateUser(ctx, &models.User{Name: "Guest", Login: "guest", HashPass: "hash", Role: "user"})
+	if !noError(t, "creating a guest", err) {
+		return
+	}
+
+	reservations, err := r.GetReservationsByUserID(ctx, guestID)
+	if noError(t, "GetReservationsByUserID without reservations", err) && len(reservations) != 0 {
+		t.Errorf("GetReservationsByUserID without reservations: got %d reservations, want none", len(reservations))
+	}
+
+	// whole seconds in UTC, which every backend stores exactly
+	day := time.Now().UTC().Truncate(24 * time.Hour).Add(48 * time.Hour)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+	create := func(what string, reservation models.Reservation) (models.Reservation, bool) {
+		stored := reservation
+		id, err := r.CreateReservation(ctx, &stored)
+		reservation.ID = id
+		return reservation, noError(t, what, err)
+	}
+
+	// created out of order, reservations are listed by start time
+	evening, ok := create("CreateReservation", models.Reservation{
+		PartySize: 2, StartTime: at(19), EndTime: at(21), Status: models.ReservationStatusPending,
+		RestaurantID: restaurantID, TableID: tableID, UserID: guestID,
+	})
+	if !ok {
+		return
+	}
+	lunch, ok := create("CreateReservation", models.Reservation{
+		PartySize: 3, StartTime: at(12), EndTime: at(14), Status: models.ReservationStatusConfirmed,
+		RestaurantID: restaurantID, TableID: tableID, UserID: guestID,
+	})
+	if !ok {
+		return
+	}
+	_, ok = create("CreateReservation right after another", models.Reservation{
+		PartySize: 2, StartTime: at(14), EndTime: at(15), Status: models.ReservationStatusPending,
+		RestaurantID: restaurantID, TableID: tableID, UserID: guestID,
+	})
+	if !ok {
+		return
+	}
+	_, ok = create("CreateReservation of another table at the same time", models.Reservation{
+		PartySize: 2, StartTime: at(19), EndTime: at(21), Status: models.ReservationStatusPending,
+		RestaurantID: restaurantID, TableID: otherTableID, UserID: guestID,
+	})
+	if !ok {
+		return
+	}
+
+	_, err = r.CreateReservation(ctx, &models.Reservation{
+		PartySize: 2, StartTime: at(20), EndTime: at(22), Status: models.ReservationStatusPending,
+		RestaurantID: restaurantID, TableID: tableID, UserID: guestID,
+	})
+	errorIs(t, "CreateReservation of a taken table", err, domain.ErrTableAlreadyReserved)
+
+	got, err := r.GetReservationByID(ctx, evening.ID)
+	if noError(t, "GetReservationByID", err) {
+		equal(t, "GetReservationByID", normalizeReservation(*got), evening)
+	}
+	_, err = r.GetReservationByID(ctx, evening.ID+100)
+	errorIs(t, "GetReservationByID of a missing reservation", err, domain.ErrReservationNotFound)
+
+	for _, list := range []struct {
+		what string
+		get  func() ([]*models.Reservation, error)
+	}{
+		{"GetReservationsByUserID", func() ([]*models.Reservation, error) { return r.GetReservationsByUserID(ctx, guestID) }},
+		{"GetReservationsByRestaurantID", func() ([]*models.Reservation, error) {
+			return r.GetReservationsByRestaurantID(ctx, restaurantID)
+		}},
+	} {
+		reservations, err := list.get()
+		if !noError(t, list.what, err) {
+			continue
+		}
+		if len(reservations) != 4 {
+			t.Errorf("%s: got %d reservations, want 4", list.what, len(reservations))
+			continue
+		}
+		equal(t, list.what+" first", normalizeReservation(*reservations[0]), lunch)
+		for i := 1; i < len(reservations); i++ {
+			if reservations[i].StartTime.Before(reservations[i-1].StartTime) {
+				t.Errorf("%s: reservations are not ordered by start time", list.what)
+			}
+		}
+	}
+
+	// moving a reservation may overlap with its old self, not with others, and leaves its status
+	moved := lunch
+	moved.StartTime, moved.EndTime, moved.PartySize = at(13), at(14), 4
+	moved.Status = models.ReservationStatusCancelled
+	err = r.UpdateReservation(ctx, &moved)
+	if noError(t, "UpdateReservation", err) {
+		got, err := r.GetReservationByID(ctx, lunch.ID)
+		if noError(t, "GetReservationByID after UpdateReservation", err) {
+			moved.Status = lunch.Status
+			equal(t, "GetReservationByID after UpdateReservation", normalizeReservation(*got), moved)
+		}
+	}
+	clash := evening
+	clash.StartTime = at(13)
+	err = r.UpdateReservation(ctx, &clash)
+	errorIs(t, "UpdateReservation to a taken time", err, domain.ErrTableAlreadyReserved)
+	missing := evening
+	missing.ID = evening.ID + 100
+	missing.StartTime, missing.EndTime = at(1), at(2)
+	err = r.UpdateReservation(ctx, &missing)
+	errorIs(t, "UpdateReservation of a missing reservation", err, domain.ErrReservationNotFound)
+
+	// policy outcomes are added one by one, updating the reservation keeps them
+	outcomes := []models.PolicyOutcome{
+		{Action: models.PolicyModification, ActorRole: models.ActorGuest, Reason: "changes are free of charge", At: at(1)},
+		{Action: models.PolicyModification, ActorRole: models.ActorGuest, Fee: 1500, Reason: "late change", At: at(2)},
+	}
+	for _, outcome := range outcomes {
+		if !noError(t, "AddPolicyOutcome", r.AddPolicyOutcome(ctx, lunch.ID, outcome)) {
+			return
+		}
+	}
+	moved.PolicyOutcomes = outcomes
+	err = r.UpdateReservation(ctx, &models.Reservation{
+		ID: moved.ID, PartySize: moved.PartySize, StartTime: moved.StartTime, EndTime: moved.EndTime,
+		RestaurantID: restaurantID, TableID: tableID, UserID: guestID,
+	})
+	if noError(t, "UpdateReservation with policy outcomes", err) {
+		got, err := r.GetReservationByID(ctx, lunch.ID)
+		if noError(t, "GetReservationByID after AddPolicyOutcome", err) {
+			equal(t, "GetReservationByID after AddPolicyOutcome", normalizeReservation(*got), moved)
+		}
+	}
+	err = r.AddPolicyOutcome(ctx, evening.ID+100, outcomes[0])
+	errorIs(t, "AddPolicyOutcome of a missing reservation", err, domain.ErrReservationNotFound)
+
+	// a reservation of joined tables takes all of them
+	joined, ok := create("CreateReservation of joined tables", models.Reservation{
+		PartySize: 8, StartTime: at(9), EndTime: at(11), Status: models.ReservationStatusPending,
+		RestaurantID: restaurantID, TableID: otherTableID, JoinedTableIDs: []uint{thirdTableID}, UserID: guestID,
+	})
+	if !ok {
+		return
+	}
+	got, err = r.GetReservationByID(ctx, joined.ID)
+	if noError(t, "GetReservationByID of joined tables", err) {
+		equal(t, "GetReservationByID of joined tables", normalizeReservation(*got), joined)
+	}
+	_, err = r.CreateReservation(ctx, &models.Reservation{
+		PartySize: 2, StartTime: at(10), EndTime: at(12), Status: models.ReservationStatusPending,
+		RestaurantID: restaurantID, TableID: thirdTableID, UserID: guestID,
+	})
+	errorIs(t, "CreateReservation of a joined table", err, domain.ErrTableAlreadyReserved)
+	occupied, err := r.GetOccupiedTableIDs(ctx, restaurantID, at(10), at(11))
+	if noError(t, "GetOccupiedTableIDs", err) {
+		slices.Sort(occupied)
+		equal(t, "GetOccupiedTableIDs", occupied, []uint{otherTableID, thirdTableID})
+	}
+
+	// the buffer after a reservation keeps its tables taken, a new reservation's own buffer too
+	buffered, ok := create("CreateReservation with a buffer", models.Reservation{
+		PartySize: 2, StartTime: at(16), EndTime: at(17), Status: models.ReservationStatusPending,
+		RestaurantID: restaurantID, TableID: thirdTableID, UserID: guestID, Buffer: 30 * time.Minute,
+	})
+	if !ok {
+		return
+	}
+	got, err = r.GetReservationByID(ctx, buffered.ID)
+	if noError(t, "GetReservationByID with a buffer", err) {
+		equal(t, "GetReservationByID with a buffer", normalizeReservation(*got), buffered)
+	}
+	_, err = r.CreateReservation(ctx, &models.Reservation{
+		PartySize: 2, StartTime: at(17), EndTime: at(18), Status: models.ReservationStatusPending,
+		RestaurantID: restaurantID, TableID: thirdTableID, UserID: guestID,
+	})
+	errorIs(t, "CreateReservation in the buffer of another", err, domain.ErrTableAlreadyReserved)
+	_, err = r.CreateReservation(ctx, &models.Reservation{
+		PartySize: 2, StartTime: at(15), EndTime: at(16), Status: models.ReservationStatusPending,
+		RestaurantID: restaurantID, TableID: thirdTableID, UserID: guestID, Buffer: time.Minute,
+	})
+	errorIs(t, "CreateReservation with a buffer into another", err, domain.ErrTableAlreadyReserved)
+	occupied, err = r.GetOccupiedTableIDs(ctx, restaurantID, at(17), at(18))
+	if noError(t, "GetOccupiedTableIDs in a buffer", err) {
+		equal(t, "GetOccupiedTableIDs in a buffer", occupied, []uint{thirdTableID})
+	}
+
+	// arrivals are counted from the start, up to the end, optionally without one reservation
+	parties, covers, err := r.CountArrivals(ctx, restaurantID, at(13), at(15), 0)
+	if noError(t, "CountArrivals", err) {
+		equal(t, "CountArrivals", []uint{parties, covers}, []uint{2, 6})
+	}
+	parties, covers, err = r.CountArrivals(ctx, restaurantID, at(13), at(14), 0)
+	if noError(t, "CountArrivals up to a start", err) {
+		equal(t, "CountArrivals up to a start", []uint{parties, covers}, []uint{1, 4})
+	}
+	parties, covers, err = r.CountArrivals(ctx, restaurantID, at(13), at(15), lunch.ID)
+	if noError(t, "CountArrivals without a reservation", err) {
+		equal(t, "CountArrivals without a reservation", []uint{parties, covers}, []uint{1, 2})
+	}
+
+	// reservations that are over don't take their table
+	err = r.UpdateReservationStatus(ctx, evening.ID, models.ReservationStatusNoShow)
+	if noError(t, "UpdateReservationStatus", err) {
+		got, err := r.GetReservationByID(ctx, evening.ID)
+		if noError(t, "GetReservationByID after UpdateReservationStatus", err) && got.Status != models.ReservationStatusNoShow {
+			t.Errorf("GetReservationByID after UpdateReservationStatus: got status %q, want %q", got.Status, models.ReservationStatusNoShow)
+		}
+		_, ok := create("CreateReservation over an inactive reservation", models.Reservation{
+			PartySize: 2, StartTime: at(19), EndTime: at(20), Status: models.ReservationStatusPending,
+			RestaurantID: restaurantID, TableID: tableID, UserID: guestID,
+		})
+		if !ok {
+			return
+		}
+		// nor count as arrivals, the reservation of the other table does
+		parties, covers, err := r.CountArrivals(ctx, restaurantID, at(19), at(20), 0)
+		if noError(t, "CountArrivals with an inactive reservation", err) {
+			equal(t, "CountArrivals with an inactive reservation", []uint{parties, covers}, []uint{2, 4})
+		}
+	}
+	err = r.UpdateReservationStatus(ctx, evening.ID+100, models.ReservationStatusCancelled)
+	errorIs(t, "UpdateReservationStatus of a missing reservation", err, domain.ErrReservationNotFound)
+
+	transitions, err := r.GetReservationTransitions(ctx, evening.ID)
+	if noError(t, "GetReservationTransitions without transitions", err) && len(transitions) != 0 {
+		t.Errorf("GetReservationTransitions without transitions: got %d transitions, want none", len(transitions))
+	}
+	created := models.ReservationTransition{
+		ReservationID: evening.ID, To: models.ReservationStatusPending,
+		ActorID: guestID, ActorRole: models.ActorGuest, At: at(1),
+	}
+	noShow := models.ReservationTransition{
+		ReservationID: evening.ID, From: models.ReservationStatusPending, To: models.ReservationStatusNoShow,
+		ActorID: guestID, ActorRole: models.ActorStaff, Reason: "didn't come", At: at(20),
+	}
+	for _, transition := range []*models.ReservationTransition{&created, &noShow} {
+		id, err := r.AddReservationTransition(ctx, transition)
+		if !noError(t, "AddReservationTransition", err) {
+			return
+		}
+		transition.ID = id
+	}
+	_, err = r.AddReservationTransition(ctx, &models.ReservationTransition{
+		ReservationID: evening.ID + 100, To: models.ReservationStatusPending, ActorRole: models.ActorGuest, At: at(1),
+	})
+	errorIs(t, "AddReservationTransition of a missing reservation", err, domain.ErrReservationNotFound)
+	transitions, err = r.GetReservationTransitions(ctx, evening.ID)
+	if noError(t, "GetReservationTransitions", err) {
+		got := make([]models.ReservationTransition, 0, len(transitions))
+		for _, transition := range transitions {
+			transition.At = transition.At.UTC()
+			got = append(got, *transition)
+		}
+		equal(t, "GetReservationTransitions", got, []models.ReservationTransition{created, noShow})
+	}
+
+	err = r.DeleteReservation(ctx, evening.ID)
+	if noError(t, "DeleteReservation", err) {
+		_, err = r.GetReservationByID(ctx, evening.ID)
+		errorIs(t, "GetReservationByID after DeleteReservation", err, domain.ErrReservationNotFound)
+		transitions, err := r.GetReservationTransitions(ctx, evening.ID)
+		if noError(t, "GetReservationTransitions after DeleteReservation", err) && len(transitions) != 0 {
+			t.Errorf("GetReservationTransitions after DeleteReservation: got %d transitions, want none", len(transitions))
+		}
+	}
+	err = r.DeleteReservation(ctx, evening.ID)
+	errorIs(t, "DeleteReservation of a missing reservation", err, domain.ErrReservationNotFound)
+}
+
+// normalizeReservation puts the times in UTC, backends may return them in another location,
+// and makes no joined tables or policy outcomes nil, Postgres returns empty arrays
+func normalizeReservation(reservation models.Reservation) models.Reservation {
+	reservation.StartTime = reservation.StartTime.UTC()
+	reservation.EndTime = reservation.EndTime.UTC()
+	if len(reservation.JoinedTableIDs) == 0 {
+		reservation.JoinedTableIDs = nil
+	}
+	if len(reservation.PolicyOutcomes) == 0 {
+		reservation.PolicyOutcomes = nil
+	}
+	reservation.PolicyOutcomes = slices.Clone(reservation.PolicyOutcomes)
+	for i := range reservation.PolicyOutcomes {
+		reservation.PolicyOutcomes[i].At = reservation.PolicyOutcomes[i].At.UTC()
+	}
+	return reservation
+}
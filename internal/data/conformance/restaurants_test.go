@@ -0,0 +1,186 @@
+package conformance
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/internal/service"
+)
+
+// testRestaurantRepository checks service.RestaurantRepository
+func testRestaurantRepository(ctx context.Context, t *testing.T, repos service.Repositories) {
+	r := repos.Restaurants
+
+	ownerID, ok := createOwner(ctx, t, repos)
+	if !ok {
+		return
+	}
+
+	restaurants, err := r.GetRestaurants(ctx)
+	if noError(t, "GetRestaurants without restaurants", err) && len(restaurants) != 0 {
+		t.Errorf("GetRestaurants without restaurants: got %d restaurants, want none", len(restaurants))
+	}
+
+	first := models.Restaurant{
+		Name:        "First",
+		Description: "Pasta",
+		Address:     "1 Main St",
+		OwnerID:     ownerID,
+		// stored as given, the service sets the defaults
+		TimeZone:        "Europe/Berlin",
+		TableAssignment: models.TableAssignmentSpreadLoad,
+		OpeningHours: []models.OpeningHours{
+			{DayOfWeek: "monday", OpenTime: "10:00", CloseTime: "22:00"},
+			{DayOfWeek: "friday", OpenTime: "12:00", CloseTime: "23:00"},
+		},
+		BookingRules: models.BookingRules{
+			Durations: []models.SeatingDuration{
+				{MaxPartySize: 2, Duration: 90 * time.Minute},
+				{MaxPartySize: 8, Duration: 2 * time.Hour},
+			},
+			Buffer:       15 * time.Minute,
+			MinLeadTime:  time.Hour,
+			MaxDaysAhead: 60,
+			LastSeating:  45 * time.Minute,
+			MaxPartySize: 8,
+			ChangePolicy: models.ChangePolicy{
+				CancellationCutoff: 24 * time.Hour,
+				CancellationFee:    2500,
+				ModificationCutoff: 2 * time.Hour,
+				MaxModifications:   3,
+			},
+		},
+	}
+	firstID, err := r.CreateRestaurant(ctx, copyRestaurant(first))
+	if !noError(t, "CreateRestaurant", err) {
+		return
+	}
+	first.ID = firstID
+	for i := range first.OpeningHours {
+		first.OpeningHours[i].RestaurantID = firstID
+	}
+
+	second := models.Restaurant{Name: "Second", OwnerID: ownerID}
+	secondID, err := r.CreateRestaurant(ctx, copyRestaurant(second))
+	if !noError(t, "CreateRestaurant without opening hours", err) {
+		return
+	}
+	second.ID = secondID
+
+	got, err := r.GetRestaurantByID(ctx, firstID)
+	if noError(t, "GetRestaurantByID", err) {
+		equal(t, "GetRestaurantByID", normalizeRestaurant(*got), normalizeRestaurant(first))
+	}
+	_, err = r.GetRestaurantByID(ctx, secondID+100)
+	errorIs(t, "GetRestaurantByID of a missing restaurant", err, domain.ErrRestaurantNotFound)
+
+	restaurants, err = r.GetRestaurants(ctx)
+	if noError(t, "GetRestaurants", err) {
+		got := make([]models.Restaurant, 0, len(restaurants))
+		for _, restaurant := range restaurants {
+			got = append(got, normalizeRestaurant(*restaurant))
+		}
+		equal(t, "GetRestaurants", got, []models.Restaurant{normalizeRestaurant(first), normalizeRestaurant(second)})
+	}
+
+	// empty fields are left as they are, opening hours are replaced when given
+	err = r.UpdateRestraunt(ctx, &models.Restaurant{ID: firstID, Address: "2 Main St"})
+	if noError(t, "UpdateRestraunt", err) {
+		first.Address = "2 Main St"
+		got, err := r.GetRestaurantByID(ctx, firstID)
+		if noError(t, "GetRestaurantByID after UpdateRestraunt", err) {
+			equal(t, "GetRestaurantByID after UpdateRestraunt", normalizeRestaurant(*got), normalizeRestaurant(first))
+		}
+	}
+	hours := []models.OpeningHours{{DayOfWeek: "sunday", OpenTime: "09:00", CloseTime: "15:00"}}
+	err = r.UpdateRestraunt(ctx, &models.Restaurant{ID: firstID, OpeningHours: hours})
+	if noError(t, "UpdateRestraunt of the opening hours", err) {
+		first.OpeningHours = []models.OpeningHours{{DayOfWeek: "sunday", OpenTime: "09:00", CloseTime: "15:00", RestaurantID: firstID}}
+		got, err := r.GetRestaurantByID(ctx, firstID)
+		if noError(t, "GetRestaurantByID after UpdateRestraunt", err) {
+			equal(t, "GetRestaurantByID after UpdateRestraunt of the opening hours", normalizeRestaurant(*got), normalizeRestaurant(first))
+		}
+	}
+	err = r.UpdateRestraunt(ctx, &models.Restaurant{ID: firstID, TableAssignment: models.TableAssignmentTightestFit})
+	if noError(t, "UpdateRestraunt of the table assignment", err) {
+		first.TableAssignment = models.TableAssignmentTightestFit
+		got, err := r.GetRestaurantByID(ctx, firstID)
+		if noError(t, "GetRestaurantByID after UpdateRestraunt", err) {
+			equal(t, "GetRestaurantByID after UpdateRestraunt of the table assignment", normalizeRestaurant(*got), normalizeRestaurant(first))
+		}
+	}
+	err = r.UpdateRestraunt(ctx, &models.Restaurant{ID: firstID, TimeZone: "America/New_York"})
+	if noError(t, "UpdateRestraunt of the time zone", err) {
+		first.TimeZone = "America/New_York"
+		got, err := r.GetRestaurantByID(ctx, firstID)
+		if noError(t, "GetRestaurantByID after UpdateRestraunt", err) {
+			equal(t, "GetRestaurantByID after UpdateRestraunt of the time zone", normalizeRestaurant(*got), normalizeRestaurant(first))
+		}
+	}
+	err = r.UpdateRestraunt(ctx, &models.Restaurant{ID: secondID + 100, Name: "Nowhere"})
+	errorIs(t, "UpdateRestraunt of a missing restaurant", err, domain.ErrRestaurantNotFound)
+
+	// the booking rules are replaced as a whole
+	rules := models.BookingRules{MinLeadTime: 30 * time.Minute, MinPartySize: 2}
+	err = r.UpdateBookingRules(ctx, firstID, rules)
+	if noError(t, "UpdateBookingRules", err) {
+		first.BookingRules = rules
+		got, err := r.GetRestaurantByID(ctx, firstID)
+		if noError(t, "GetRestaurantByID after UpdateBookingRules", err) {
+			equal(t, "GetRestaurantByID after UpdateBookingRules", normalizeRestaurant(*got), normalizeRestaurant(first))
+		}
+	}
+	err = r.UpdateBookingRules(ctx, secondID+100, rules)
+	errorIs(t, "UpdateBookingRules of a missing restaurant", err, domain.ErrRestaurantNotFound)
+
+	err = r.DeleteRestraunt(ctx, firstID)
+	if noError(t, "DeleteRestraunt", err) {
+		_, err = r.GetRestaurantByID(ctx, firstID)
+		errorIs(t, "GetRestaurantByID after DeleteRestraunt", err, domain.ErrRestaurantNotFound)
+	}
+	err = r.DeleteRestraunt(ctx, firstID)
+	errorIs(t, "DeleteRestraunt of a missing restaurant", err, domain.ErrRestaurantNotFound)
+}
+
+// createOwner creates the user that owns the restaurants of a suite
+func createOwner(ctx context.Context, t *testing.T, repos service.Repositories) (uint, bool) {
+	id, err := repos.Users.CreateUser(ctx, &models.User{Name: "Owner", Login: "owner", HashPass: "hash", Role: "owner"})
+	return id, noError(t, "creating the owner", err)
+}
+
+// createRestaurant creates a restaurant of a new owner for the suites that need one
+func createRestaurant(ctx context.Context, t *testing.T, repos service.Repositories, name string) (uint, bool) {
+	ownerID, err := repos.Users.CreateUser(ctx, &models.User{Name: name, Login: name, HashPass: "hash", Role: "owner"})
+	if !noError(t, "creating the owner of "+name, err) {
+		return 0, false
+	}
+	id, err := repos.Restaurants.CreateRestaurant(ctx, &models.Restaurant{Name: name, OwnerID: ownerID})
+	return id, noError(t, "creating restaurant "+name, err)
+}
+
+// copyRestaurant copies the restaurant with its opening hours and seating durations,
+// so the repository can't change the expectations
+func copyRestaurant(restaurant models.Restaurant) *models.Restaurant {
+	restaurant.OpeningHours = append([]models.OpeningHours(nil), restaurant.OpeningHours...)
+	restaurant.BookingRules.Durations = append([]models.SeatingDuration(nil), restaurant.BookingRules.Durations...)
+	return &restaurant
+}
+
+// normalizeRestaurant sorts the opening hours, whose order isn't specified, and makes no hours and no seating durations nil
+func normalizeRestaurant(restaurant models.Restaurant) models.Restaurant {
+	if len(restaurant.BookingRules.Durations) == 0 {
+		restaurant.BookingRules.Durations = nil
+	}
+	if len(restaurant.OpeningHours) == 0 {
+		restaurant.OpeningHours = nil
+		return restaurant
+	}
+	hours := append([]models.OpeningHours(nil), restaurant.OpeningHours...)
+	sort.Slice(hours, func(i, j int) bool { return hours[i].DayOfWeek < hours[j].DayOfWeek })
+	restaurant.OpeningHours = hours
+	return restaurant
+}
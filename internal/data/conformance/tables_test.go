@@ -0,0 +1,201 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/internal/service"
+)
+
+// testTableRepository checks service.TableRepository
+func testTableRepository(ctx context.Context, t *testing.T, repos service.Repositories) {
+	r := repos.Tables
+
+	restaurantID, ok := createRestaurant(ctx, t, repos, "tables")
+	if !ok {
+		return
+	}
+	otherRestaurantID, ok := createRestaurant(ctx, t, repos, "other-tables")
+	if !ok {
+		return
+	}
+
+	tables, err := r.GetTablesByRestaurantID(ctx, restaurantID)
+	if noError(t, "GetTablesByRestaurantID without tables", err) && len(tables) != 0 {
+		t.Errorf("GetTablesByRestaurantID without tables: got %d tables, want none", len(tables))
+	}
+
+	// created out of order, the tables of a restaurant are listed by number
+	create := func(table models.Table) (models.Table, bool) {
+		stored := table
+		stored.Attributes = append([]string(nil), table.Attributes...)
+		id, err := r.CreateTable(ctx, &stored)
+		table.ID = id
+		return table, noError(t, "CreateTable", err)
+	}
+	two, ok := create(models.Table{
+		Number: 2, Capacity: 4, IsAvailable: true, MinPartySize: 2, Attributes: []string{"quiet", "window"}, RestaurantID: restaurantID,
+	})
+	if !ok {
+		return
+	}
+	one, ok := create(models.Table{Number: 1, Capacity: 2, IsAvailable: false, RestaurantID: restaurantID})
+	if !ok {
+		return
+	}
+	otherOne, ok := create(models.Table{Number: 1, Capacity: 6, IsAvailable: true, RestaurantID: otherRestaurantID})
+	if !ok {
+		return
+	}
+
+	_, err = r.CreateTable(ctx, &models.Table{Number: 2, Capacity: 8, RestaurantID: restaurantID})
+	errorIs(t, "CreateTable with a taken number", err, domain.ErrTableAlreadyExists)
+
+	got, err := r.GetTableByID(ctx, two.ID)
+	if noError(t, "GetTableByID", err) {
+		equal(t, "GetTableByID", normalizeTable(*got), normalizeTable(two))
+	}
+	_, err = r.GetTableByID(ctx, otherOne.ID+100)
+	errorIs(t, "GetTableByID of a missing table", err, domain.ErrTableNotFound)
+
+	tables, err = r.GetTablesByRestaurantID(ctx, restaurantID)
+	if noError(t, "GetTablesByRestaurantID", err) {
+		equal(t, "GetTablesByRestaurantID", normalizeTables(tables), []models.Table{normalizeTable(one), normalizeTable(two)})
+	}
+	tables, err = r.GetAvailableTablesByRestaurantID(ctx, restaurantID)
+	if noError(t, "GetAvailableTablesByRestaurantID", err) {
+		equal(t, "GetAvailableTablesByRestaurantID", normalizeTables(tables), []models.Table{normalizeTable(two)})
+	}
+
+	// zero number and capacity are left as they are, the availability is always set
+	err = r.UpdateTable(ctx, &models.Table{ID: one.ID, Capacity: 3, IsAvailable: true})
+	if noError(t, "UpdateTable", err) {
+		one.Capacity, one.IsAvailable = 3, true
+		got, err := r.GetTableByID(ctx, one.ID)
+		if noError(t, "GetTableByID after UpdateTable", err) {
+			equal(t, "GetTableByID after UpdateTable", normalizeTable(*got), normalizeTable(one))
+		}
+	}
+
+	// nil attributes are left as they are, empty ones replace them like any others
+	err = r.UpdateTable(ctx, &models.Table{ID: one.ID, MinPartySize: 2, Attributes: []string{"booth"}, IsAvailable: true})
+	if noError(t, "UpdateTable of the minimum party size and attributes", err) {
+		one.MinPartySize, one.Attributes = 2, []string{"booth"}
+		got, err := r.GetTableByID(ctx, one.ID)
+		if noError(t, "GetTableByID after UpdateTable", err) {
+			equal(t, "GetTableByID after UpdateTable of the minimum party size and attributes", normalizeTable(*got), normalizeTable(one))
+		}
+	}
+	err = r.UpdateTable(ctx, &models.Table{ID: one.ID, Attributes: []string{}, IsAvailable: true})
+	if noError(t, "UpdateTable to no attributes", err) {
+		one.Attributes = nil
+		got, err := r.GetTableByID(ctx, one.ID)
+		if noError(t, "GetTableByID after UpdateTable", err) {
+			equal(t, "GetTableByID after UpdateTable to no attributes", normalizeTable(*got), normalizeTable(one))
+		}
+	}
+	err = r.UpdateTable(ctx, &models.Table{ID: one.ID, Number: 2, IsAvailable: true})
+	errorIs(t, "UpdateTable to a taken number", err, domain.ErrTableAlreadyExists)
+	err = r.UpdateTable(ctx, &models.Table{ID: otherOne.ID + 100, Capacity: 2})
+	errorIs(t, "UpdateTable of a missing table", err, domain.ErrTableNotFound)
+
+	// occupancy is set on its own, UpdateTable leaves it as it is
+	since := time.Now().UTC().Truncate(time.Second)
+	until := since.Add(2 * time.Hour)
+	err = r.SetTableOccupancy(ctx, two.ID, since, until, 3)
+	if noError(t, "SetTableOccupancy", err) {
+		two.OccupiedSince, two.OccupiedUntil, two.OccupiedPartySize = since, until, 3
+		got, err := r.GetTableByID(ctx, two.ID)
+		if noError(t, "GetTableByID after SetTableOccupancy", err) {
+			equal(t, "GetTableByID after SetTableOccupancy", normalizeTable(*got), normalizeTable(two))
+		}
+		err = r.UpdateTable(ctx, &models.Table{ID: two.ID, Capacity: 5, IsAvailable: true})
+		if noError(t, "UpdateTable of an occupied table", err) {
+			two.Capacity = 5
+			got, err := r.GetTableByID(ctx, two.ID)
+			if noError(t, "GetTableByID after UpdateTable", err) {
+				equal(t, "GetTableByID after UpdateTable of an occupied table", normalizeTable(*got), normalizeTable(two))
+			}
+		}
+	}
+	// a zero time frees the table and drops when it's expected free and the party size
+	err = r.SetTableOccupancy(ctx, two.ID, time.Time{}, until, 3)
+	if noError(t, "SetTableOccupancy to free", err) {
+		two.OccupiedSince, two.OccupiedUntil, two.OccupiedPartySize = time.Time{}, time.Time{}, 0
+		got, err := r.GetTableByID(ctx, two.ID)
+		if noError(t, "GetTableByID after SetTableOccupancy", err) {
+			equal(t, "GetTableByID after SetTableOccupancy to free", normalizeTable(*got), normalizeTable(two))
+		}
+	}
+	err = r.SetTableOccupancy(ctx, otherOne.ID+100, since, until, 2)
+	errorIs(t, "SetTableOccupancy of a missing table", err, domain.ErrTableNotFound)
+
+	combinations, err := r.GetTableCombinationsByRestaurantID(ctx, restaurantID)
+	if noError(t, "GetTableCombinationsByRestaurantID without combinations", err) && len(combinations) != 0 {
+		t.Errorf("GetTableCombinationsByRestaurantID without combinations: got %d combinations, want none", len(combinations))
+	}
+	// the tables of a combination are sorted by ID
+	combination := models.TableCombination{TableIDs: []uint{two.ID, one.ID}, Capacity: 6, RestaurantID: restaurantID}
+	stored := combination
+	stored.TableIDs = append([]uint(nil), combination.TableIDs...)
+	combination.ID, err = r.CreateTableCombination(ctx, &stored)
+	if noError(t, "CreateTableCombination", err) {
+		_, err = r.CreateTableCombination(ctx, &models.TableCombination{
+			TableIDs: []uint{two.ID, one.ID}, Capacity: 5, RestaurantID: restaurantID,
+		})
+		errorIs(t, "CreateTableCombination of the same tables", err, domain.ErrTableCombinationAlreadyExists)
+
+		combinations, err = r.GetTableCombinationsByRestaurantID(ctx, restaurantID)
+		if noError(t, "GetTableCombinationsByRestaurantID", err) {
+			got := make([]models.TableCombination, 0, len(combinations))
+			for _, combination := range combinations {
+				got = append(got, *combination)
+			}
+			equal(t, "GetTableCombinationsByRestaurantID", got, []models.TableCombination{combination})
+		}
+		combinations, err = r.GetTableCombinationsByRestaurantID(ctx, otherRestaurantID)
+		if noError(t, "GetTableCombinationsByRestaurantID of another restaurant", err) && len(combinations) != 0 {
+			t.Errorf("GetTableCombinationsByRestaurantID of another restaurant: got %d combinations, want none", len(combinations))
+		}
+
+		err = r.DeleteTableCombination(ctx, combination.ID)
+		if noError(t, "DeleteTableCombination", err) {
+			combinations, err = r.GetTableCombinationsByRestaurantID(ctx, restaurantID)
+			if noError(t, "GetTableCombinationsByRestaurantID after DeleteTableCombination", err) && len(combinations) != 0 {
+				t.Errorf("GetTableCombinationsByRestaurantID after DeleteTableCombination: got %d combinations, want none", len(combinations))
+			}
+		}
+		err = r.DeleteTableCombination(ctx, combination.ID)
+		errorIs(t, "DeleteTableCombination of a missing combination", err, domain.ErrTableCombinationNotFound)
+	}
+
+	err = r.DeleteTable(ctx, one.ID)
+	if noError(t, "DeleteTable", err) {
+		_, err = r.GetTableByID(ctx, one.ID)
+		errorIs(t, "GetTableByID after DeleteTable", err, domain.ErrTableNotFound)
+	}
+	err = r.DeleteTable(ctx, one.ID)
+	errorIs(t, "DeleteTable of a missing table", err, domain.ErrTableNotFound)
+}
+
+// normalizeTable makes no attributes nil, Postgres returns an empty array,
+// and puts the occupancy time in UTC, backends may return it in another location
+func normalizeTable(table models.Table) models.Table {
+	if len(table.Attributes) == 0 {
+		table.Attributes = nil
+	}
+	table.OccupiedSince = table.OccupiedSince.UTC()
+	table.OccupiedUntil = table.OccupiedUntil.UTC()
+	return table
+}
+
+func normalizeTables(tables []*models.Table) []models.Table {
+	normalized := make([]models.Table, 0, len(tables))
+	for _, table := range tables {
+		normalized = append(normalized, normalizeTable(*table))
+	}
+	return normalized
+}
@@ -0,0 +1,118 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/internal/service"
+)
+
+// testUserRepository checks service.UserRepository
+func testUserRepository(ctx context.Context, t *testing.T, repos service.Repositories) {
+	r := repos.Users
+
+	_, err := r.GetUsers(ctx)
+	errorIs(t, "GetUsers without users", err, domain.ErrUsersNotFound)
+
+	alice := models.User{Name: "Alice", Login: "alice", HashPass: "hash-a", Role: "user"}
+	aliceID, err := r.CreateUser(ctx, &models.User{Name: alice.Name, Login: alice.Login, HashPass: alice.HashPass, Role: alice.Role})
+	if !noError(t, "CreateUser", err) {
+		return
+	}
+	alice.ID = aliceID
+
+	bob := models.User{Name: "Bob", Login: "bob", HashPass: "hash-b", Role: "owner"}
+	bobID, err := r.CreateUser(ctx, &models.User{Name: bob.Name, Login: bob.Login, HashPass: bob.HashPass, Role: bob.Role})
+	if !noError(t, "CreateUser", err) {
+		return
+	}
+	bob.ID = bobID
+	if aliceID == 0 || aliceID == bobID {
+		t.Errorf("CreateUser: got IDs %d and %d, want distinct non-zero IDs", aliceID, bobID)
+	}
+
+	_, err = r.CreateUser(ctx, &models.User{Name: "Other Alice", Login: "alice", HashPass: "hash", Role: "user"})
+	errorIs(t, "CreateUser with a taken login", err, domain.ErrUserAlreadyExists)
+
+	got, err := r.GetUserByID(ctx, aliceID)
+	if noError(t, "GetUserByID", err) {
+		equal(t, "GetUserByID", *got, alice)
+	}
+	got, err = r.GetUserByLogin(ctx, "bob")
+	if noError(t, "GetUserByLogin", err) {
+		equal(t, "GetUserByLogin", *got, bob)
+	}
+	_, err = r.GetUserByID(ctx, bobID+100)
+	errorIs(t, "GetUserByID of a missing user", err, domain.ErrUserNotFound)
+	_, err = r.GetUserByLogin(ctx, "nobody")
+	errorIs(t, "GetUserByLogin of a missing user", err, domain.ErrUserNotFound)
+
+	users, err := r.GetUsers(ctx)
+	if noError(t, "GetUsers", err) {
+		equal(t, "GetUsers", derefAll(users), []models.User{alice, bob})
+	}
+
+	// empty fields are left as they are
+	err = r.UpdateUser(ctx, &models.User{ID: aliceID, Name: "Alice Smith"})
+	if noError(t, "UpdateUser", err) {
+		alice.Name = "Alice Smith"
+		got, err := r.GetUserByID(ctx, aliceID)
+		if noError(t, "GetUserByID after UpdateUser", err) {
+			equal(t, "GetUserByID after UpdateUser", *got, alice)
+		}
+	}
+	err = r.UpdateUser(ctx, &models.User{ID: aliceID, Login: "alice"})
+	noError(t, "UpdateUser keeping the login", err)
+	err = r.UpdateUser(ctx, &models.User{ID: aliceID, Login: "bob"})
+	errorIs(t, "UpdateUser to a taken login", err, domain.ErrUserAlreadyExists)
+	err = r.UpdateUser(ctx, &models.User{ID: bobID + 100, Name: "Nobody"})
+	errorIs(t, "UpdateUser of a missing user", err, domain.ErrUserNotFound)
+
+	err = r.DeleteUser(ctx, aliceID)
+	if noError(t, "DeleteUser", err) {
+		_, err = r.GetUserByID(ctx, aliceID)
+		errorIs(t, "GetUserByID after DeleteUser", err, domain.ErrUserNotFound)
+	}
+	err = r.DeleteUser(ctx, aliceID)
+	errorIs(t, "DeleteUser of a missing user", err, domain.ErrUserNotFound)
+
+	// a user created after a delete gets a new ID, the login of the deleted one is free again
+	carolID, err := r.CreateUser(ctx, &models.User{Name: "Carol", Login: "alice", HashPass: "hash-c", Role: "user"})
+	if noError(t, "CreateUser after DeleteUser", err) {
+		if carolID == aliceID || carolID == bobID {
+			t.Errorf("CreateUser after DeleteUser: got ID %d, taken by %d and %d before", carolID, aliceID, bobID)
+		}
+		got, err := r.GetUserByID(ctx, bobID)
+		if noError(t, "GetUserByID after CreateUser after DeleteUser", err) {
+			equal(t, "GetUserByID after CreateUser after DeleteUser", *got, bob)
+		}
+	}
+
+	// an owner can't be deleted before their restaurants
+	ownerID, err := r.CreateUser(ctx, &models.User{Name: "Dave", Login: "dave", HashPass: "hash-d", Role: "owner"})
+	if !noError(t, "CreateUser of an owner", err) {
+		return
+	}
+	restaurantID, err := repos.Restaurants.CreateRestaurant(ctx, &models.Restaurant{Name: "Dave's", OwnerID: ownerID})
+	if !noError(t, "CreateRestaurant of the owner", err) {
+		return
+	}
+	err = r.DeleteUser(ctx, ownerID)
+	errorIs(t, "DeleteUser of an owner", err, domain.ErrUserOwnsRestaurants)
+	_, err = r.GetUserByID(ctx, ownerID)
+	noError(t, "GetUserByID after DeleteUser of an owner", err)
+	if noError(t, "DeleteRestraunt of the owner", repos.Restaurants.DeleteRestraunt(ctx, restaurantID)) {
+		noError(t, "DeleteUser of an owner without restaurants", r.DeleteUser(ctx, ownerID))
+	}
+}
+
+// derefAll copies the records, so they compare by value
+func derefAll[T any](records []*T) []T {
+	values := make([]T, 0, len(records))
+	for _, record := range records {
+		values = append(values, *record)
+	}
+	return values
+}
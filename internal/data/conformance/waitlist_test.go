@@ -0,0 +1,152 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/internal/service"
+)
+
+// testWaitlistRepository checks service.WaitlistRepository
+func testWaitlistRepository(ctx context.Context, t *testing.T, repos service.Repositories) {
+	r := repos.Waitlist
+
+	restaurantID, ok := createRestaurant(ctx, t, repos, "waitlist")
+	if !ok {
+		return
+	}
+	otherRestaurantID, ok := createRestaurant(ctx, t, repos, "other-waitlist")
+	if !ok {
+		return
+	}
+	guestID, err := repos.Users.CreateUser(ctx, &models.User{Name: "Guest", Login: "waitlist-guest", HashPass: "hash", Role: "user"})
+	if !noError(t, "creating a guest", err) {
+		return
+	}
+
+	// whole seconds in UTC, which every backend stores exactly
+	day := time.Now().UTC().Truncate(24 * time.Hour).Add(48 * time.Hour)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+	create := func(what string, entry models.WaitlistEntry) (models.WaitlistEntry, bool) {
+		stored := entry
+		id, err := r.CreateWaitlistEntry(ctx, &stored)
+		entry.ID = id
+		return entry, noError(t, what, err)
+	}
+	entry := func(restaurantID uint, start, end time.Time) models.WaitlistEntry {
+		return models.WaitlistEntry{
+			PartySize: 2, WindowStart: start, WindowEnd: end, Contact: "+10000000000",
+			Status: models.WaitlistStatusWaiting, CreatedAt: at(0), RestaurantID: restaurantID, UserID: guestID,
+		}
+	}
+
+	evening, ok := create("CreateWaitlistEntry", entry(restaurantID, at(18), at(21)))
+	if !ok {
+		return
+	}
+	later, ok := create("CreateWaitlistEntry of a later guest", entry(restaurantID, at(19), at(20)))
+	if !ok {
+		return
+	}
+	if _, ok := create("CreateWaitlistEntry of another restaurant", entry(otherRestaurantID, at(18), at(21))); !ok {
+		return
+	}
+
+	got, err := r.GetWaitlistEntryByID(ctx, evening.ID)
+	if noError(t, "GetWaitlistEntryByID", err) {
+		equal(t, "GetWaitlistEntryByID", normalizeWaitlistEntry(*got), evening)
+	}
+	_, err = r.GetWaitlistEntryByID(ctx, evening.ID+100)
+	errorIs(t, "GetWaitlistEntryByID of a missing entry", err, domain.ErrWaitlistEntryNotFound)
+
+	// the window takes both of its ends, the entries come in the order they joined
+	for _, window := range []struct {
+		what  string
+		start time.Time
+		want  []models.WaitlistEntry
+	}{
+		{"GetWaitingEntries", at(19), []models.WaitlistEntry{evening, later}},
+		{"GetWaitingEntries at the end of a window", at(21), []models.WaitlistEntry{evening}},
+		{"GetWaitingEntries out of every window", at(22), []models.WaitlistEntry{}},
+	} {
+		entries, err := r.GetWaitingEntries(ctx, restaurantID, window.start)
+		if noError(t, window.what, err) {
+			equal(t, window.what, normalizeWaitlistEntries(entries), window.want)
+		}
+	}
+
+	// an offer takes the entry off the waiting ones
+	expiresAt := time.Now().UTC().Truncate(time.Second).Add(-time.Minute)
+	evening.Status = models.WaitlistStatusOffered
+	evening.Offer = &models.WaitlistOffer{Token: "offer", TableID: 1, StartTime: at(19), EndTime: at(21), ExpiresAt: expiresAt}
+	err = r.UpdateWaitlistEntry(ctx, &models.WaitlistEntry{ID: evening.ID, Status: evening.Status, Offer: copyOffer(evening.Offer)})
+	if noError(t, "UpdateWaitlistEntry", err) {
+		got, err := r.GetWaitlistEntryByOfferToken(ctx, "offer")
+		if noError(t, "GetWaitlistEntryByOfferToken", err) {
+			equal(t, "GetWaitlistEntryByOfferToken", normalizeWaitlistEntry(*got), evening)
+		}
+		entries, err := r.GetWaitingEntries(ctx, restaurantID, at(19))
+		if noError(t, "GetWaitingEntries after an offer", err) {
+			equal(t, "GetWaitingEntries after an offer", normalizeWaitlistEntries(entries), []models.WaitlistEntry{later})
+		}
+	}
+	_, err = r.GetWaitlistEntryByOfferToken(ctx, "missing")
+	errorIs(t, "GetWaitlistEntryByOfferToken of a missing offer", err, domain.ErrWaitlistEntryNotFound)
+
+	entries, err := r.GetExpiredOffers(ctx, expiresAt.Add(-time.Second))
+	if noError(t, "GetExpiredOffers before the expiry", err) && len(entries) != 0 {
+		t.Errorf("GetExpiredOffers before the expiry: got %d entries, want none", len(entries))
+	}
+	entries, err = r.GetExpiredOffers(ctx, expiresAt)
+	if noError(t, "GetExpiredOffers", err) {
+		equal(t, "GetExpiredOffers", normalizeWaitlistEntries(entries), []models.WaitlistEntry{evening})
+	}
+
+	// the offer stays with the entry after it's over
+	evening.Status = models.WaitlistStatusExpired
+	err = r.UpdateWaitlistEntry(ctx, &models.WaitlistEntry{ID: evening.ID, Status: evening.Status, Offer: copyOffer(evening.Offer)})
+	if noError(t, "UpdateWaitlistEntry to expired", err) {
+		entries, err := r.GetExpiredOffers(ctx, time.Now())
+		if noError(t, "GetExpiredOffers after the entry expired", err) && len(entries) != 0 {
+			t.Errorf("GetExpiredOffers after the entry expired: got %d entries, want none", len(entries))
+		}
+		got, err := r.GetWaitlistEntryByID(ctx, evening.ID)
+		if noError(t, "GetWaitlistEntryByID after UpdateWaitlistEntry", err) {
+			equal(t, "GetWaitlistEntryByID after UpdateWaitlistEntry", normalizeWaitlistEntry(*got), evening)
+		}
+	}
+	err = r.UpdateWaitlistEntry(ctx, &models.WaitlistEntry{ID: evening.ID + 100, Status: models.WaitlistStatusLeft})
+	errorIs(t, "UpdateWaitlistEntry of a missing entry", err, domain.ErrWaitlistEntryNotFound)
+}
+
+// copyOffer copies the offer, so the repository can't change the expectations
+func copyOffer(offer *models.WaitlistOffer) *models.WaitlistOffer {
+	copied := *offer
+	return &copied
+}
+
+// normalizeWaitlistEntry puts the times in UTC, backends may return them in another location
+func normalizeWaitlistEntry(entry models.WaitlistEntry) models.WaitlistEntry {
+	entry.WindowStart = entry.WindowStart.UTC()
+	entry.WindowEnd = entry.WindowEnd.UTC()
+	entry.CreatedAt = entry.CreatedAt.UTC()
+	if entry.Offer != nil {
+		offer := *entry.Offer
+		offer.StartTime = offer.StartTime.UTC()
+		offer.EndTime = offer.EndTime.UTC()
+		offer.ExpiresAt = offer.ExpiresAt.UTC()
+		entry.Offer = &offer
+	}
+	return entry
+}
+
+func normalizeWaitlistEntries(entries []*models.WaitlistEntry) []models.WaitlistEntry {
+	normalized := make([]models.WaitlistEntry, 0, len(entries))
+	for _, entry := range entries {
+		normalized = append(normalized, normalizeWaitlistEntry(*entry))
+	}
+	return normalized
+}
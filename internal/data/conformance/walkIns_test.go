@@ -0,0 +1,126 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/internal/service"
+)
+
+// testWalkInRepository checks service.WalkInRepository
+func testWalkInRepository(ctx context.Context, t *testing.T, repos service.Repositories) {
+	r := repos.WalkIns
+
+	restaurantID, ok := createRestaurant(ctx, t, repos, "walk-ins")
+	if !ok {
+		return
+	}
+	otherRestaurantID, ok := createRestaurant(ctx, t, repos, "other-walk-ins")
+	if !ok {
+		return
+	}
+
+	queue, err := r.GetWalkInQueue(ctx, restaurantID)
+	if noError(t, "GetWalkInQueue without walk-ins", err) && len(queue) != 0 {
+		t.Errorf("GetWalkInQueue without walk-ins: got %d walk-ins, want none", len(queue))
+	}
+
+	// whole seconds in UTC, which every backend stores exactly
+	joinedAt := time.Now().UTC().Truncate(time.Second)
+	create := func(what string, walkIn models.WalkIn) (models.WalkIn, bool) {
+		stored := walkIn
+		id, err := r.CreateWalkIn(ctx, &stored)
+		walkIn.ID = id
+		return walkIn, noError(t, what, err)
+	}
+	walkIn := func(restaurantID uint, name string, partySize uint) models.WalkIn {
+		return models.WalkIn{
+			Name: name, PartySize: partySize, Contact: "+10000000000", Status: models.WalkInStatusWaiting,
+			QuotedWait: 25 * time.Minute, JoinedAt: joinedAt, RestaurantID: restaurantID,
+		}
+	}
+
+	first, ok := create("CreateWalkIn", walkIn(restaurantID, "First", 2))
+	if !ok {
+		return
+	}
+	second, ok := create("CreateWalkIn of a later party", walkIn(restaurantID, "Second", 4))
+	if !ok {
+		return
+	}
+	if _, ok := create("CreateWalkIn of another restaurant", walkIn(otherRestaurantID, "Other", 2)); !ok {
+		return
+	}
+
+	got, err := r.GetWalkInByID(ctx, first.ID)
+	if noError(t, "GetWalkInByID", err) {
+		equal(t, "GetWalkInByID", normalizeWalkIn(*got), first)
+	}
+	_, err = r.GetWalkInByID(ctx, first.ID+100)
+	errorIs(t, "GetWalkInByID of a missing walk-in", err, domain.ErrWalkInNotFound)
+
+	queue, err = r.GetWalkInQueue(ctx, restaurantID)
+	if noError(t, "GetWalkInQueue", err) {
+		equal(t, "GetWalkInQueue", normalizeWalkIns(queue), []models.WalkIn{first, second})
+	}
+
+	// notified parties stay in the queue, seated ones leave it with their times and table kept
+	first.Status = models.WalkInStatusNotified
+	first.NotifiedAt = joinedAt.Add(10 * time.Minute)
+	first.TableID = 7
+	err = r.UpdateWalkIn(ctx, &models.WalkIn{
+		ID: first.ID, Status: first.Status, NotifiedAt: first.NotifiedAt, TableID: first.TableID,
+	})
+	if noError(t, "UpdateWalkIn to notified", err) {
+		queue, err = r.GetWalkInQueue(ctx, restaurantID)
+		if noError(t, "GetWalkInQueue after a notification", err) {
+			equal(t, "GetWalkInQueue after a notification", normalizeWalkIns(queue), []models.WalkIn{first, second})
+		}
+	}
+
+	first.Status = models.WalkInStatusSeated
+	first.SeatedAt = joinedAt.Add(15 * time.Minute)
+	err = r.UpdateWalkIn(ctx, &models.WalkIn{
+		ID: first.ID, Status: first.Status, NotifiedAt: first.NotifiedAt, SeatedAt: first.SeatedAt, TableID: first.TableID,
+	})
+	if noError(t, "UpdateWalkIn to seated", err) {
+		got, err := r.GetWalkInByID(ctx, first.ID)
+		if noError(t, "GetWalkInByID after UpdateWalkIn", err) {
+			equal(t, "GetWalkInByID after UpdateWalkIn", normalizeWalkIn(*got), first)
+		}
+		queue, err = r.GetWalkInQueue(ctx, restaurantID)
+		if noError(t, "GetWalkInQueue after seating", err) {
+			equal(t, "GetWalkInQueue after seating", normalizeWalkIns(queue), []models.WalkIn{second})
+		}
+	}
+
+	second.Status = models.WalkInStatusLeft
+	err = r.UpdateWalkIn(ctx, &models.WalkIn{ID: second.ID, Status: second.Status})
+	if noError(t, "UpdateWalkIn to left", err) {
+		queue, err = r.GetWalkInQueue(ctx, restaurantID)
+		if noError(t, "GetWalkInQueue after the last party left", err) && len(queue) != 0 {
+			t.Errorf("GetWalkInQueue after the last party left: got %d walk-ins, want none", len(queue))
+		}
+	}
+	err = r.UpdateWalkIn(ctx, &models.WalkIn{ID: second.ID + 100, Status: models.WalkInStatusLeft})
+	errorIs(t, "UpdateWalkIn of a missing walk-in", err, domain.ErrWalkInNotFound)
+}
+
+// normalizeWalkIn puts the times in UTC, backends may return them in another location
+func normalizeWalkIn(walkIn models.WalkIn) models.WalkIn {
+	walkIn.JoinedAt = walkIn.JoinedAt.UTC()
+	walkIn.NotifiedAt = walkIn.NotifiedAt.UTC()
+	walkIn.SeatedAt = walkIn.SeatedAt.UTC()
+	return walkIn
+}
+
+func normalizeWalkIns(walkIns []*models.WalkIn) []models.WalkIn {
+	normalized := make([]models.WalkIn, 0, len(walkIns))
+	for _, walkIn := range walkIns {
+		normalized = append(normalized, normalizeWalkIn(*walkIn))
+	}
+	return normalized
+}
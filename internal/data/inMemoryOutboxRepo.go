@@ -0,0 +1,139 @@
+package data
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+type outboxRecord struct {
+	event         models.OutboxEvent
+	dead          bool
+	lastError     string
+	nextAttemptAt time.Time
+	lockedUntil   time.Time
+}
+
+type InMemoryOutboxRepo struct {
+	writeGuard
+
+	mu      sync.Mutex
+	records map[uint]*outboxRecord
+	nextID  uint
+}
+
+func NewInMemoryOutboxRepo() *InMemoryOutboxRepo {
+	return &InMemoryOutboxRepo{
+		records: make(map[uint]*outboxRecord),
+		nextID:  1,
+	}
+}
+
+func (r *InMemoryOutboxRepo) AddEvent(ctx context.Context, event models.ReservationEvent) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+
+	id := r.nextID
+	r.nextID++
+	r.records[id] = &outboxRecord{
+		event:         models.OutboxEvent{ID: id, Event: event, TraceParent: carrier.Get("traceparent")},
+		nextAttemptAt: time.Now(),
+	}
+
+	return nil
+}
+
+func (r *InMemoryOutboxRepo) LeaseEvents(ctx context.Context, limit int, leaseFor time.Duration) ([]*models.OutboxEvent, error) {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	ids := make([]uint, 0)
+	for id, record := range r.records {
+		if !record.dead && !record.nextAttemptAt.After(now) && !record.lockedUntil.After(now) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	events := make([]*models.OutboxEvent, 0, len(ids))
+	for _, id := range ids {
+		record := r.records[id]
+		record.lockedUntil = now.Add(leaseFor)
+		event := record.event
+		events = append(events, &event)
+	}
+
+	return events, nil
+}
+
+func (r *InMemoryOutboxRepo) DeleteEvent(ctx context.Context, id uint) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.records, id)
+
+	return nil
+}
+
+func (r *InMemoryOutboxRepo) RetryEvent(ctx context.Context, id uint, attempts int, nextAttemptAt time.Time, lastError string) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if record, ok := r.records[id]; ok {
+		record.event.Attempts = attempts
+		record.nextAttemptAt = nextAttemptAt
+		record.lastError = lastError
+		record.lockedUntil = time.Time{}
+	}
+
+	return nil
+}
+
+func (r *InMemoryOutboxRepo) BuryEvent(ctx context.Context, id uint, attempts int, lastError string) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if record, ok := r.records[id]; ok {
+		record.event.Attempts = attempts
+		record.dead = true
+		record.lastError = lastError
+		record.lockedUntil = time.Time{}
+	}
+
+	return nil
+}
+
+// Snapshot copies the outbox, the returned func puts the copy back
+func (r *InMemoryOutboxRepo) Snapshot() (restore func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records, nextID := copyRecords(r.records), r.nextID
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.records, r.nextID = records, nextID
+	}
+}
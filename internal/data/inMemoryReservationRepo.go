@@ -0,0 +1,371 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+// InMemoryReservationRepo reads the occupancy of the tables from their repository, like the Postgres one
+// does when it locks them
+type InMemoryReservationRepo struct {
+	writeGuard
+
+	tables *InMemoryTableRepo
+
+	mu               sync.RWMutex
+	reservations     map[uint]*models.Reservation
+	nextID           uint
+	transitions      map[uint]*models.ReservationTransition
+	nextTransitionID uint
+	holds            map[uint]*models.Hold
+	nextHoldID       uint
+}
+
+func NewInMemoryReservationRepo(tables *InMemoryTableRepo) *InMemoryReservationRepo {
+	return &InMemoryReservationRepo{
+		tables:           tables,
+		reservations:     make(map[uint]*models.Reservation),
+		nextID:           1,
+		transitions:      make(map[uint]*models.ReservationTransition),
+		nextTransitionID: 1,
+		holds:            make(map[uint]*models.Hold),
+		nextHoldID:       1,
+	}
+}
+
+func (r *InMemoryReservationRepo) CreateReservation(ctx context.Context, reservation *models.Reservation) (uint, error) {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.isReserved(reservation.TableIDs(), reservation.ID, reservation.StartTime, reservation.EndTime.Add(reservation.Buffer)) {
+		return 0, fmt.Errorf("InMemoryReservationRepo.CreateReservation: %w", domain.ErrTableAlreadyReserved)
+	}
+
+	id := r.nextID
+	r.nextID++
+
+	stored := *reservation
+	stored.ID = id
+	stored.JoinedTableIDs = slices.Clone(reservation.JoinedTableIDs)
+	stored.PolicyOutcomes = nil
+	r.reservations[id] = &stored
+
+	return id, nil
+}
+
+func (r *InMemoryReservationRepo) GetReservationByID(_ context.Context, id uint) (*models.Reservation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reservation, ok := r.reservations[id]
+	if !ok {
+		return nil, fmt.Errorf("InMemoryReservationRepo.GetReservationByID: %w", domain.ErrReservationNotFound)
+	}
+
+	res := *reservation
+	return &res, nil
+}
+
+func (r *InMemoryReservationRepo) GetReservationsByRestaurantID(_ context.Context, restaurantID uint) ([]*models.Reservation, error) {
+	return r.filter(func(res *models.Reservation) bool { return res.RestaurantID == restaurantID }), nil
+}
+
+func (r *InMemoryReservationRepo) GetReservationsByUserID(_ context.Context, userID uint) ([]*models.Reservation, error) {
+	return r.filter(func(res *models.Reservation) bool { return res.UserID == userID }), nil
+}
+
+func (r *InMemoryReservationRepo) UpdateReservation(ctx context.Context, reservation *models.Reservation) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.reservations[reservation.ID]
+	if !ok {
+		return fmt.Errorf("InMemoryReservationRepo.UpdateReservation: %w", domain.ErrReservationNotFound)
+	}
+	if r.isReserved(reservation.TableIDs(), reservation.ID, reservation.StartTime, reservation.EndTime.Add(reservation.Buffer)) {
+		return fmt.Errorf("InMemoryReservationRepo.UpdateReservation: %w", domain.ErrTableAlreadyReserved)
+	}
+
+	// the status and the policy outcomes only change through their own methods
+	stored := *reservation
+	stored.Status = existing.Status
+	stored.PolicyOutcomes = existing.PolicyOutcomes
+	stored.JoinedTableIDs = slices.Clone(reservation.JoinedTableIDs)
+	r.reservations[reservation.ID] = &stored
+
+	return nil
+}
+
+func (r *InMemoryReservationRepo) DeleteReservation(ctx context.Context, id uint) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.reservations[id]; !ok {
+		return fmt.Errorf("InMemoryReservationRepo.DeleteReservation: %w", domain.ErrReservationNotFound)
+	}
+	delete(r.reservations, id)
+	for transitionID, transition := range r.transitions {
+		if transition.ReservationID == id {
+			delete(r.transitions, transitionID)
+		}
+	}
+
+	return nil
+}
+
+func (r *InMemoryReservationRepo) UpdateReservationStatus(ctx context.Context, id uint, status models.ReservationStatus) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reservation, ok := r.reservations[id]
+	if !ok {
+		return fmt.Errorf("InMemoryReservationRepo.UpdateReservationStatus: %w", domain.ErrReservationNotFound)
+	}
+	reservation.Status = status
+
+	return nil
+}
+
+func (r *InMemoryReservationRepo) AddPolicyOutcome(ctx context.Context, id uint, outcome models.PolicyOutcome) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reservation, ok := r.reservations[id]
+	if !ok {
+		return fmt.Errorf("InMemoryReservationRepo.AddPolicyOutcome: %w", domain.ErrReservationNotFound)
+	}
+	// a new array, the copies handed out and the snapshots keep theirs
+	reservation.PolicyOutcomes = append(slices.Clip(reservation.PolicyOutcomes), outcome)
+
+	return nil
+}
+
+func (r *InMemoryReservationRepo) AddReservationTransition(ctx context.Context, transition *models.ReservationTransition) (uint, error) {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.reservations[transition.ReservationID]; !ok {
+		return 0, fmt.Errorf("InMemoryReservationRepo.AddReservationTransition: %w", domain.ErrReservationNotFound)
+	}
+
+	id := r.nextTransitionID
+	r.nextTransitionID++
+
+	stored := *transition
+	stored.ID = id
+	r.transitions[id] = &stored
+
+	return id, nil
+}
+
+func (r *InMemoryReservationRepo) GetReservationTransitions(_ context.Context, reservationID uint) ([]*models.ReservationTransition, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	transitions := make([]*models.ReservationTransition, 0)
+	for _, transition := range r.transitions {
+		if transition.ReservationID == reservationID {
+			copied := *transition
+			transitions = append(transitions, &copied)
+		}
+	}
+	sort.Slice(transitions, func(i, j int) bool { return transitions[i].ID < transitions[j].ID })
+
+	return transitions, nil
+}
+
+func (r *InMemoryReservationRepo) GetOccupiedTableIDs(_ context.Context, restaurantID uint, start, end time.Time) ([]uint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	occupied := make(map[uint]bool)
+	for _, reservation := range r.reservations {
+		if reservation.RestaurantID == restaurantID && reservation.Status.Active() && reservation.Overlaps(start, end) {
+			for _, id := range reservation.TableIDs() {
+				occupied[id] = true
+			}
+		}
+	}
+	now := time.Now()
+	for _, hold := range r.holds {
+		if hold.RestaurantID == restaurantID && !hold.Expired(now) && hold.Overlaps(start, end) {
+			occupied[hold.TableID] = true
+		}
+	}
+
+	ids := make([]uint, 0, len(occupied))
+	for id := range occupied {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids, nil
+}
+
+func (r *InMemoryReservationRepo) CountArrivals(
+	_ context.Context,
+	restaurantID uint,
+	start, end time.Time,
+	reservationID uint,
+) (parties, covers uint, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, reservation := range r.reservations {
+		if reservation.RestaurantID == restaurantID && reservation.ID != reservationID && reservation.Status.Active() &&
+			!reservation.StartTime.Before(start) && reservation.StartTime.Before(end) {
+			parties++
+			covers += reservation.PartySize
+		}
+	}
+
+	return parties, covers, nil
+}
+
+func (r *InMemoryReservationRepo) CreateHold(ctx context.Context, hold *models.Hold) (uint, error) {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.holds {
+		if existing.Token == hold.Token {
+			return 0, fmt.Errorf("InMemoryReservationRepo.CreateHold: token is taken")
+		}
+	}
+	if r.isReserved([]uint{hold.TableID}, 0, hold.StartTime, hold.EndTime.Add(hold.Buffer)) {
+		return 0, fmt.Errorf("InMemoryReservationRepo.CreateHold: %w", domain.ErrTableAlreadyReserved)
+	}
+
+	id := r.nextHoldID
+	r.nextHoldID++
+
+	stored := *hold
+	stored.ID = id
+	r.holds[id] = &stored
+
+	return id, nil
+}
+
+func (r *InMemoryReservationRepo) GetHoldByToken(_ context.Context, token string) (*models.Hold, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, hold := range r.holds {
+		if hold.Token == token {
+			copied := *hold
+			return &copied, nil
+		}
+	}
+
+	return nil, fmt.Errorf("InMemoryReservationRepo.GetHoldByToken: %w", domain.ErrHoldNotFound)
+}
+
+func (r *InMemoryReservationRepo) DeleteHold(ctx context.Context, id uint) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.holds[id]; !ok {
+		return fmt.Errorf("InMemoryReservationRepo.DeleteHold: %w", domain.ErrHoldNotFound)
+	}
+	delete(r.holds, id)
+
+	return nil
+}
+
+func (r *InMemoryReservationRepo) DeleteExpiredHolds(ctx context.Context) (int, error) {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	deleted := 0
+	for id, hold := range r.holds {
+		if hold.Expired(now) {
+			delete(r.holds, id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+// isReserved reports whether a party seated at any of the tables is expected to stay past start, or an active
+// reservation other than reservationID, or a live hold, takes any of them at any moment of [start, end),
+// buffers included. The caller must hold the lock.
+func (r *InMemoryReservationRepo) isReserved(tableIDs []uint, reservationID uint, start, end time.Time) bool {
+	if r.tables.occupiedPast(tableIDs, start) {
+		return true
+	}
+	for _, existing := range r.reservations {
+		if existing.ID != reservationID &&
+			existing.Status.Active() &&
+			slices.ContainsFunc(existing.TableIDs(), func(id uint) bool { return slices.Contains(tableIDs, id) }) &&
+			existing.Overlaps(start, end) {
+			return true
+		}
+	}
+	now := time.Now()
+	for _, hold := range r.holds {
+		if slices.Contains(tableIDs, hold.TableID) && !hold.Expired(now) && hold.Overlaps(start, end) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *InMemoryReservationRepo) filter(keep func(*models.Reservation) bool) []*models.Reservation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reservations := make([]*models.Reservation, 0)
+	for _, res := range r.reservations {
+		if keep(res) {
+			copied := *res
+			reservations = append(reservations, &copied)
+		}
+	}
+	sort.Slice(reservations, func(i, j int) bool { return reservations[i].StartTime.Before(reservations[j].StartTime) })
+
+	return reservations
+}
+
+// Snapshot copies the reservations, their transitions and the holds, the returned func puts the copy back
+func (r *InMemoryReservationRepo) Snapshot() (restore func()) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reservations, nextID := copyRecords(r.reservations), r.nextID
+	transitions, nextTransitionID := copyRecords(r.transitions), r.nextTransitionID
+	holds, nextHoldID := copyRecords(r.holds), r.nextHoldID
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.reservations, r.nextID = reservations, nextID
+		r.transitions, r.nextTransitionID = transitions, nextTransitionID
+		r.holds, r.nextHoldID = holds, nextHoldID
+	}
+}
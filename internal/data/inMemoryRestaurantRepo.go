@@ -0,0 +1,164 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+type InMemoryRestaurantRepo struct {
+	writeGuard
+
+	mu          sync.RWMutex
+	restaurants map[uint]*models.Restaurant
+	nextID      uint
+}
+
+func NewInMemoryRestaurantRepo() *InMemoryRestaurantRepo {
+	return &InMemoryRestaurantRepo{
+		restaurants: make(map[uint]*models.Restaurant),
+		nextID:      1,
+	}
+}
+
+func (r *InMemoryRestaurantRepo) CreateRestaurant(ctx context.Context, restaurant *models.Restaurant) (uint, error) {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.nextID
+	r.nextID++
+
+	restaurant.ID = id
+	for i := range restaurant.OpeningHours {
+		restaurant.OpeningHours[i].RestaurantID = id
+	}
+	r.restaurants[id] = restaurant
+
+	return id, nil
+}
+
+func (r *InMemoryRestaurantRepo) GetRestaurants(_ context.Context) ([]*models.Restaurant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	restaurants := make([]*models.Restaurant, 0, len(r.restaurants))
+	for _, restaurant := range r.restaurants {
+		restaurants = append(restaurants, restaurant)
+	}
+	sort.Slice(restaurants, func(i, j int) bool { return restaurants[i].ID < restaurants[j].ID })
+
+	return restaurants, nil
+}
+
+func (r *InMemoryRestaurantRepo) GetRestaurantByID(_ context.Context, id uint) (*models.Restaurant, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	restaurant, ok := r.restaurants[id]
+	if !ok {
+		return nil, fmt.Errorf("InMemoryRestaurantRepo.GetRestaurantByID: %w", domain.ErrRestaurantNotFound)
+	}
+
+	return restaurant, nil
+}
+
+func (r *InMemoryRestaurantRepo) UpdateRestraunt(ctx context.Context, restaurant *models.Restaurant) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.restaurants[restaurant.ID]
+	if !ok {
+		return fmt.Errorf("InMemoryRestaurantRepo.UpdateRestraunt: %w", domain.ErrRestaurantNotFound)
+	}
+
+	// Update non-empty fields (same semantics as the DB repo)
+	if restaurant.Name != "" {
+		existing.Name = restaurant.Name
+	}
+	if restaurant.Description != "" {
+		existing.Description = restaurant.Description
+	}
+	if restaurant.Address != "" {
+		existing.Address = restaurant.Address
+	}
+	if restaurant.TimeZone != "" {
+		existing.TimeZone = restaurant.TimeZone
+	}
+	if restaurant.TableAssignment != "" {
+		existing.TableAssignment = restaurant.TableAssignment
+	}
+	if len(restaurant.OpeningHours) > 0 {
+		existing.OpeningHours = restaurant.OpeningHours
+		for i := range existing.OpeningHours {
+			existing.OpeningHours[i].RestaurantID = existing.ID
+		}
+	}
+
+	return nil
+}
+
+func (r *InMemoryRestaurantRepo) UpdateBookingRules(ctx context.Context, restaurantID uint, rules models.BookingRules) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.restaurants[restaurantID]
+	if !ok {
+		return fmt.Errorf("InMemoryRestaurantRepo.UpdateBookingRules: %w", domain.ErrRestaurantNotFound)
+	}
+	rules.Durations = slices.Clone(rules.Durations)
+	existing.BookingRules = rules
+
+	return nil
+}
+
+func (r *InMemoryRestaurantRepo) DeleteRestraunt(ctx context.Context, id uint) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.restaurants[id]; !ok {
+		return fmt.Errorf("InMemoryRestaurantRepo.DeleteRestraunt: %w", domain.ErrRestaurantNotFound)
+	}
+	delete(r.restaurants, id)
+
+	return nil
+}
+
+// ownsAny reports whether the user owns a restaurant
+func (r *InMemoryRestaurantRepo) ownsAny(ownerID uint) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, restaurant := range r.restaurants {
+		if restaurant.OwnerID == ownerID {
+			return true
+		}
+	}
+	return false
+}
+
+// Snapshot copies the restaurants, the returned func puts the copy back
+func (r *InMemoryRestaurantRepo) Snapshot() (restore func()) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	restaurants, nextID := copyRecords(r.restaurants), r.nextID
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.restaurants, r.nextID = restaurants, nextID
+	}
+}
@@ -0,0 +1,237 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+type InMemoryTableRepo struct {
+	writeGuard
+
+	mu                sync.RWMutex
+	tables            map[uint]*models.Table
+	nextID            uint
+	combinations      map[uint]*models.TableCombination
+	nextCombinationID uint
+}
+
+func NewInMemoryTableRepo() *InMemoryTableRepo {
+	return &InMemoryTableRepo{
+		tables:            make(map[uint]*models.Table),
+		nextID:            1,
+		combinations:      make(map[uint]*models.TableCombination),
+		nextCombinationID: 1,
+	}
+}
+
+func (r *InMemoryTableRepo) CreateTable(ctx context.Context, table *models.Table) (uint, error) {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Table numbers are unique within a restaurant
+	for _, t := range r.tables {
+		if t.RestaurantID == table.RestaurantID && t.Number == table.Number {
+			return 0, fmt.Errorf("InMemoryTableRepo.CreateTable: %w", domain.ErrTableAlreadyExists)
+		}
+	}
+
+	id := r.nextID
+	r.nextID++
+
+	table.ID = id
+	r.tables[id] = table
+
+	return id, nil
+}
+
+func (r *InMemoryTableRepo) GetTablesByRestaurantID(_ context.Context, restaurantID uint) ([]*models.Table, error) {
+	return r.filter(func(t *models.Table) bool { return t.RestaurantID == restaurantID }), nil
+}
+
+func (r *InMemoryTableRepo) GetAvailableTablesByRestaurantID(_ context.Context, restaurantID uint) ([]*models.Table, error) {
+	return r.filter(func(t *models.Table) bool { return t.RestaurantID == restaurantID && t.IsAvailable }), nil
+}
+
+func (r *InMemoryTableRepo) GetTableByID(_ context.Context, id uint) (*models.Table, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	table, ok := r.tables[id]
+	if !ok {
+		return nil, fmt.Errorf("InMemoryTableRepo.GetTableByID: %w", domain.ErrTableNotFound)
+	}
+
+	return table, nil
+}
+
+func (r *InMemoryTableRepo) UpdateTable(ctx context.Context, table *models.Table) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.tables[table.ID]
+	if !ok {
+		return fmt.Errorf("InMemoryTableRepo.UpdateTable: %w", domain.ErrTableNotFound)
+	}
+
+	if table.Number != 0 && table.Number != existing.Number {
+		for _, t := range r.tables {
+			if t.RestaurantID == existing.RestaurantID && t.Number == table.Number {
+				return fmt.Errorf("InMemoryTableRepo.UpdateTable: %w", domain.ErrTableAlreadyExists)
+			}
+		}
+		existing.Number = table.Number
+	}
+	if table.Capacity != 0 {
+		existing.Capacity = table.Capacity
+	}
+	if table.MinPartySize != 0 {
+		existing.MinPartySize = table.MinPartySize
+	}
+	if table.Attributes != nil {
+		existing.Attributes = table.Attributes
+	}
+	existing.IsAvailable = table.IsAvailable
+
+	return nil
+}
+
+func (r *InMemoryTableRepo) SetTableOccupancy(ctx context.Context, id uint, since, until time.Time, partySize uint) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	table, ok := r.tables[id]
+	if !ok {
+		return fmt.Errorf("InMemoryTableRepo.SetTableOccupancy: %w", domain.ErrTableNotFound)
+	}
+	if since.IsZero() {
+		until, partySize = time.Time{}, 0
+	}
+	table.OccupiedSince, table.OccupiedUntil, table.OccupiedPartySize = since, until, partySize
+
+	return nil
+}
+
+// occupiedPast reports whether a party seated at any of the tables is expected to stay past at
+func (r *InMemoryTableRepo) occupiedPast(tableIDs []uint, at time.Time) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, id := range tableIDs {
+		if table, ok := r.tables[id]; ok && table.OccupiedUntil.After(at) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *InMemoryTableRepo) DeleteTable(ctx context.Context, id uint) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tables[id]; !ok {
+		return fmt.Errorf("InMemoryTableRepo.DeleteTable: %w", domain.ErrTableNotFound)
+	}
+	delete(r.tables, id)
+
+	return nil
+}
+
+func (r *InMemoryTableRepo) CreateTableCombination(ctx context.Context, combination *models.TableCombination) (uint, error) {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the tables of a combination are unique within a restaurant
+	for _, c := range r.combinations {
+		if c.RestaurantID == combination.RestaurantID && slices.Equal(c.TableIDs, combination.TableIDs) {
+			return 0, fmt.Errorf("InMemoryTableRepo.CreateTableCombination: %w", domain.ErrTableCombinationAlreadyExists)
+		}
+	}
+
+	id := r.nextCombinationID
+	r.nextCombinationID++
+
+	stored := *combination
+	stored.ID = id
+	stored.TableIDs = slices.Clone(combination.TableIDs)
+	r.combinations[id] = &stored
+
+	return id, nil
+}
+
+func (r *InMemoryTableRepo) GetTableCombinationsByRestaurantID(_ context.Context, restaurantID uint) ([]*models.TableCombination, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	combinations := make([]*models.TableCombination, 0)
+	for _, c := range r.combinations {
+		if c.RestaurantID == restaurantID {
+			copied := *c
+			combinations = append(combinations, &copied)
+		}
+	}
+	sort.Slice(combinations, func(i, j int) bool { return combinations[i].ID < combinations[j].ID })
+
+	return combinations, nil
+}
+
+func (r *InMemoryTableRepo) DeleteTableCombination(ctx context.Context, id uint) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.combinations[id]; !ok {
+		return fmt.Errorf("InMemoryTableRepo.DeleteTableCombination: %w", domain.ErrTableCombinationNotFound)
+	}
+	delete(r.combinations, id)
+
+	return nil
+}
+
+func (r *InMemoryTableRepo) filter(keep func(*models.Table) bool) []*models.Table {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tables := make([]*models.Table, 0)
+	for _, t := range r.tables {
+		if keep(t) {
+			tables = append(tables, t)
+		}
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Number < tables[j].Number })
+
+	return tables
+}
+
+// Snapshot copies the tables and their combinations, the returned func puts the copy back
+func (r *InMemoryTableRepo) Snapshot() (restore func()) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tables, nextID := copyRecords(r.tables), r.nextID
+	combinations, nextCombinationID := copyRecords(r.combinations), r.nextCombinationID
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.tables, r.nextID = tables, nextID
+		r.combinations, r.nextCombinationID = combinations, nextCombinationID
+	}
+}
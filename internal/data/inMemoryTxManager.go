@@ -0,0 +1,98 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kourai55k/booking-service/internal/data/txhooks"
+)
+
+// Snapshotter is an in-memory repository whose state can be put back when a unit of work fails
+type Snapshotter interface {
+	Snapshot() (restore func())
+	// guardWrites makes the writes of the repository made outside units of work take mu
+	guardWrites(mu *sync.Mutex)
+}
+
+// InMemoryTxManager is the in-memory counterpart of postgres.TxManager.
+// Units of work run one at a time, which is serializable isolation, and a failed one
+// puts the stores back to where they were before it. Writes to the stores made outside units of work
+// wait for the running one, so putting the stores back can't undo them.
+type InMemoryTxManager[R any] struct {
+	mu     sync.Mutex
+	repos  R
+	stores []Snapshotter
+}
+
+func NewInMemoryTxManager[R any](repos R, stores ...Snapshotter) *InMemoryTxManager[R] {
+	m := &InMemoryTxManager[R]{repos: repos, stores: stores}
+	for _, s := range stores {
+		s.guardWrites(&m.mu)
+	}
+	return m
+}
+
+// Do runs fn with the repositories, rolling the stores back if it returns an error.
+// The hooks registered in fn run once the stores are released.
+func (m *InMemoryTxManager[R]) Do(ctx context.Context, fn func(ctx context.Context, repos R) error) error {
+	const op = "InMemoryTxManager.Do"
+
+	ctx, commit := txhooks.Begin(ctx)
+	if err := m.run(ctx, fn); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	commit()
+	return nil
+}
+
+func (m *InMemoryTxManager[R]) run(ctx context.Context, fn func(ctx context.Context, repos R) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ctx = context.WithValue(ctx, unitOfWorkKey{}, &m.mu)
+
+	restores := make([]func(), 0, len(m.stores))
+	for _, s := range m.stores {
+		restores = append(restores, s.Snapshot())
+	}
+
+	if err := fn(ctx, m.repos); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		return err
+	}
+	return nil
+}
+
+// unitOfWorkKey marks the context of a unit of work with the lock of its manager, which its writes already hold
+type unitOfWorkKey struct{}
+
+// writeGuard serializes the writes of a store made outside units of work with the units of work of its manager.
+// A store without a manager writes freely.
+type writeGuard struct {
+	mu *sync.Mutex
+}
+
+func (g *writeGuard) guardWrites(mu *sync.Mutex) {
+	g.mu = mu
+}
+
+// enter waits for the running unit of work unless ctx is the one of it, the returned func leaves
+func (g *writeGuard) enter(ctx context.Context) (leave func()) {
+	if g.mu == nil || ctx.Value(unitOfWorkKey{}) == g.mu {
+		return func() {}
+	}
+	g.mu.Lock()
+	return g.mu.Unlock
+}
+
+// copyRecords copies the map and the records, so updates made in place don't leak into the copy
+func copyRecords[T any](records map[uint]*T) map[uint]*T {
+	copied := make(map[uint]*T, len(records))
+	for id, record := range records {
+		record := *record
+		copied[id] = &record
+	}
+	return copied
+}
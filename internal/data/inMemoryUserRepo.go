@@ -1,37 +1,53 @@
 package data
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/kourai55k/booking-service/internal/domain"
 	"github.com/kourai55k/booking-service/internal/domain/models"
 )
 
+// InMemoryUserRepo reads the owners of the restaurants from their repository, so owners can't be deleted
+// before their restaurants, like the foreign key of the Postgres one doesn't let them
 type InMemoryUserRepo struct {
-	mu    sync.RWMutex
-	users map[uint]*models.User
+	writeGuard
+
+	restaurants *InMemoryRestaurantRepo
+
+	mu     sync.RWMutex
+	users  map[uint]*models.User
+	nextID uint
 }
 
-func NewInMemoryUserRepo() *InMemoryUserRepo {
+func NewInMemoryUserRepo(restaurants *InMemoryRestaurantRepo) *InMemoryUserRepo {
 	return &InMemoryUserRepo{
-		users: make(map[uint]*models.User),
+		restaurants: restaurants,
+		users:       make(map[uint]*models.User),
+		nextID:      1,
 	}
 }
 
-func (r *InMemoryUserRepo) GetUsers() ([]*models.User, error) {
+func (r *InMemoryUserRepo) GetUsers(_ context.Context) ([]*models.User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	if len(r.users) == 0 {
+		return nil, fmt.Errorf("InMemoryUserRepo.GetUsers: %w", domain.ErrUsersNotFound)
+	}
+
 	users := make([]*models.User, 0, len(r.users))
 	for _, u := range r.users {
 		users = append(users, u)
 	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
 
 	return users, nil
 }
 
-func (r *InMemoryUserRepo) GetUserByID(id uint) (*models.User, error) {
+func (r *InMemoryUserRepo) GetUserByID(_ context.Context, id uint) (*models.User, error) {
 	const op = "InMemoryUserRepo.GetUserById"
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -44,7 +60,7 @@ func (r *InMemoryUserRepo) GetUserByID(id uint) (*models.User, error) {
 	return user, nil
 }
 
-func (r *InMemoryUserRepo) GetUserByLogin(login string) (*models.User, error) {
+func (r *InMemoryUserRepo) GetUserByLogin(_ context.Context, login string) (*models.User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -57,7 +73,9 @@ func (r *InMemoryUserRepo) GetUserByLogin(login string) (*models.User, error) {
 	return nil, domain.ErrUserNotFound
 }
 
-func (r *InMemoryUserRepo) CreateUser(user *models.User) (uint, error) {
+func (r *InMemoryUserRepo) CreateUser(ctx context.Context, user *models.User) (uint, error) {
+	defer r.writeGuard.enter(ctx)()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -68,10 +86,9 @@ func (r *InMemoryUserRepo) CreateUser(user *models.User) (uint, error) {
 		}
 	}
 
-	// Generate a new ID (ensure we don’t overwrite an existing one)
-	id := uint(len(r.users) + 1)
-	for _, exists := r.users[id]; exists; id++ { // Ensure the ID is unique
-	}
+	// IDs aren't reused, like a database sequence
+	id := r.nextID
+	r.nextID++
 
 	// Set the new user ID and save it
 	user.ID = id
@@ -80,7 +97,9 @@ func (r *InMemoryUserRepo) CreateUser(user *models.User) (uint, error) {
 	return id, nil
 }
 
-func (r *InMemoryUserRepo) UpdateUser(user *models.User) error {
+func (r *InMemoryUserRepo) UpdateUser(ctx context.Context, user *models.User) error {
+	defer r.writeGuard.enter(ctx)()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -120,11 +139,33 @@ func (r *InMemoryUserRepo) UpdateUser(user *models.User) error {
 	return nil
 }
 
-func (r *InMemoryUserRepo) DeleteUser(id uint) error {
+func (r *InMemoryUserRepo) DeleteUser(ctx context.Context, id uint) error {
+	defer r.writeGuard.enter(ctx)()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if _, ok := r.users[id]; !ok {
+		return fmt.Errorf("InMemoryUserRepo.DeleteUser: %w", domain.ErrUserNotFound)
+	}
+	if r.restaurants.ownsAny(id) {
+		return fmt.Errorf("InMemoryUserRepo.DeleteUser: %w", domain.ErrUserOwnsRestaurants)
+	}
 	delete(r.users, id)
 
 	return nil
 }
+
+// Snapshot copies the users, the returned func puts the copy back
+func (r *InMemoryUserRepo) Snapshot() (restore func()) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users, nextID := copyRecords(r.users), r.nextID
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.users, r.nextID = users, nextID
+	}
+}
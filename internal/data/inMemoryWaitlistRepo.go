@@ -0,0 +1,143 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+type InMemoryWaitlistRepo struct {
+	writeGuard
+
+	mu      sync.RWMutex
+	entries map[uint]*models.WaitlistEntry
+	nextID  uint
+}
+
+func NewInMemoryWaitlistRepo() *InMemoryWaitlistRepo {
+	return &InMemoryWaitlistRepo{
+		entries: make(map[uint]*models.WaitlistEntry),
+		nextID:  1,
+	}
+}
+
+func (r *InMemoryWaitlistRepo) CreateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) (uint, error) {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.nextID
+	r.nextID++
+
+	stored := copyEntry(entry)
+	stored.ID = id
+	r.entries[id] = stored
+
+	return id, nil
+}
+
+func (r *InMemoryWaitlistRepo) GetWaitlistEntryByID(_ context.Context, id uint) (*models.WaitlistEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.entries[id]
+	if !ok {
+		return nil, fmt.Errorf("InMemoryWaitlistRepo.GetWaitlistEntryByID: %w", domain.ErrWaitlistEntryNotFound)
+	}
+
+	return copyEntry(entry), nil
+}
+
+func (r *InMemoryWaitlistRepo) GetWaitlistEntryByOfferToken(_ context.Context, token string) (*models.WaitlistEntry, error) {
+	entries := r.filter(func(entry *models.WaitlistEntry) bool {
+		return entry.Offer != nil && entry.Offer.Token == token
+	})
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("InMemoryWaitlistRepo.GetWaitlistEntryByOfferToken: %w", domain.ErrWaitlistEntryNotFound)
+	}
+
+	return entries[0], nil
+}
+
+func (r *InMemoryWaitlistRepo) GetWaitingEntries(_ context.Context, restaurantID uint, start time.Time) ([]*models.WaitlistEntry, error) {
+	return r.filter(func(entry *models.WaitlistEntry) bool {
+		return entry.RestaurantID == restaurantID && entry.Status == models.WaitlistStatusWaiting && entry.Takes(start)
+	}), nil
+}
+
+func (r *InMemoryWaitlistRepo) GetExpiredOffers(_ context.Context, now time.Time) ([]*models.WaitlistEntry, error) {
+	return r.filter(func(entry *models.WaitlistEntry) bool {
+		return entry.Status == models.WaitlistStatusOffered && !now.Before(entry.Offer.ExpiresAt)
+	}), nil
+}
+
+func (r *InMemoryWaitlistRepo) UpdateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.entries[entry.ID]
+	if !ok {
+		return fmt.Errorf("InMemoryWaitlistRepo.UpdateWaitlistEntry: %w", domain.ErrWaitlistEntryNotFound)
+	}
+
+	updated := copyEntry(existing)
+	updated.Status = entry.Status
+	if entry.Offer != nil {
+		offer := *entry.Offer
+		updated.Offer = &offer
+	} else {
+		updated.Offer = nil
+	}
+	r.entries[entry.ID] = updated
+
+	return nil
+}
+
+// filter returns copies of the entries to keep, in the order they joined the waitlist
+func (r *InMemoryWaitlistRepo) filter(keep func(*models.WaitlistEntry) bool) []*models.WaitlistEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]*models.WaitlistEntry, 0)
+	for _, entry := range r.entries {
+		if keep(entry) {
+			entries = append(entries, copyEntry(entry))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+
+	return entries
+}
+
+// Snapshot copies the entries, the returned func puts the copy back.
+// Entries are replaced on update rather than changed in place, so their offers can be shared with the copy.
+func (r *InMemoryWaitlistRepo) Snapshot() (restore func()) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries, nextID := copyRecords(r.entries), r.nextID
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.entries, r.nextID = entries, nextID
+	}
+}
+
+// copyEntry copies the entry with its offer
+func copyEntry(entry *models.WaitlistEntry) *models.WaitlistEntry {
+	copied := *entry
+	if entry.Offer != nil {
+		offer := *entry.Offer
+		copied.Offer = &offer
+	}
+	return &copied
+}
@@ -0,0 +1,107 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+type InMemoryWalkInRepo struct {
+	writeGuard
+
+	mu      sync.RWMutex
+	walkIns map[uint]*models.WalkIn
+	nextID  uint
+}
+
+func NewInMemoryWalkInRepo() *InMemoryWalkInRepo {
+	return &InMemoryWalkInRepo{
+		walkIns: make(map[uint]*models.WalkIn),
+		nextID:  1,
+	}
+}
+
+func (r *InMemoryWalkInRepo) CreateWalkIn(ctx context.Context, walkIn *models.WalkIn) (uint, error) {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.nextID
+	r.nextID++
+
+	stored := *walkIn
+	stored.ID = id
+	stored.EstimatedWait = 0
+	r.walkIns[id] = &stored
+
+	return id, nil
+}
+
+func (r *InMemoryWalkInRepo) GetWalkInByID(_ context.Context, id uint) (*models.WalkIn, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	walkIn, ok := r.walkIns[id]
+	if !ok {
+		return nil, fmt.Errorf("InMemoryWalkInRepo.GetWalkInByID: %w", domain.ErrWalkInNotFound)
+	}
+
+	copied := *walkIn
+	return &copied, nil
+}
+
+func (r *InMemoryWalkInRepo) GetWalkInQueue(_ context.Context, restaurantID uint) ([]*models.WalkIn, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	queue := make([]*models.WalkIn, 0)
+	for _, walkIn := range r.walkIns {
+		if walkIn.RestaurantID == restaurantID && walkIn.Status.Queued() {
+			copied := *walkIn
+			queue = append(queue, &copied)
+		}
+	}
+	sort.Slice(queue, func(i, j int) bool { return queue[i].ID < queue[j].ID })
+
+	return queue, nil
+}
+
+func (r *InMemoryWalkInRepo) UpdateWalkIn(ctx context.Context, walkIn *models.WalkIn) error {
+	defer r.writeGuard.enter(ctx)()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.walkIns[walkIn.ID]
+	if !ok {
+		return fmt.Errorf("InMemoryWalkInRepo.UpdateWalkIn: %w", domain.ErrWalkInNotFound)
+	}
+
+	updated := *existing
+	updated.Status = walkIn.Status
+	updated.NotifiedAt = walkIn.NotifiedAt
+	updated.SeatedAt = walkIn.SeatedAt
+	updated.TableID = walkIn.TableID
+	r.walkIns[walkIn.ID] = &updated
+
+	return nil
+}
+
+// Snapshot copies the walk-ins, the returned func puts the copy back
+func (r *InMemoryWalkInRepo) Snapshot() (restore func()) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	walkIns, nextID := copyRecords(r.walkIns), r.nextID
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.walkIns, r.nextID = walkIns, nextID
+	}
+}
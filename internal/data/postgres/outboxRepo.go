@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+type OutboxRepo struct {
+	db DB
+}
+
+func NewOutboxRepo(db DB) *OutboxRepo {
+	return &OutboxRepo{db: db}
+}
+
+// CreateOutboxTable creates the "outbox" table if it doesn't exist.
+// Events are pending until delivered, then deleted, or dead after too many failed deliveries.
+func (r *OutboxRepo) CreateOutboxTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS outbox (
+		id BIGSERIAL PRIMARY KEY,
+		event_type TEXT NOT NULL,
+		payload JSONB NOT NULL,
+		trace_parent TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL DEFAULT 'pending',
+		attempts INTEGER NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL DEFAULT '',
+		next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		locked_until TIMESTAMPTZ,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	);
+	CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (next_attempt_at) WHERE status = 'pending';
+	`
+	_, err := r.db.Exec(context.Background(), query)
+	if err != nil {
+		return fmt.Errorf("CreateOutboxTable: %w", err)
+	}
+	return nil
+}
+
+// AddEvent writes an event to the outbox, with the trace of ctx.
+// It is meant to run in the transaction of the change the event describes.
+func (r *OutboxRepo) AddEvent(ctx context.Context, event models.ReservationEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("OutboxRepo.AddEvent: %w", err)
+	}
+
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+
+	query := "INSERT INTO outbox (event_type, payload, trace_parent) VALUES ($1, $2, $3)"
+	if _, err := r.db.Exec(ctx, query, string(event.Type), payload, carrier.Get("traceparent")); err != nil {
+		return fmt.Errorf("OutboxRepo.AddEvent: %w", err)
+	}
+
+	return nil
+}
+
+// LeaseEvents claims up to limit pending events that are due, oldest first, for leaseFor.
+// Rows locked by another dispatcher are skipped, and leased events aren't returned again until the lease
+// runs out, so a dispatcher that dies mid-delivery only delays its events.
+func (r *OutboxRepo) LeaseEvents(ctx context.Context, limit int, leaseFor time.Duration) ([]*models.OutboxEvent, error) {
+	// RETURNING doesn't keep the order of the subquery, the leased rows are sorted again
+	query := `
+	WITH leased AS (
+		UPDATE outbox SET locked_until = now() + $2::interval
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE status = 'pending' AND next_attempt_at <= now() AND (locked_until IS NULL OR locked_until < now())
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, payload, trace_parent, attempts
+	)
+	SELECT id, payload, trace_parent, attempts FROM leased ORDER BY id
+	`
+	rows, err := r.db.Query(ctx, query, limit, leaseFor)
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepo.LeaseEvents: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.OutboxEvent{}
+	for rows.Next() {
+		var event models.OutboxEvent
+		var payload []byte
+		if err := rows.Scan(&event.ID, &payload, &event.TraceParent, &event.Attempts); err != nil {
+			return nil, fmt.Errorf("OutboxRepo.LeaseEvents: %w", err)
+		}
+		if err := json.Unmarshal(payload, &event.Event); err != nil {
+			return nil, fmt.Errorf("OutboxRepo.LeaseEvents: event %d: %w", event.ID, err)
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("OutboxRepo.LeaseEvents: %w", err)
+	}
+
+	return events, nil
+}
+
+// DeleteEvent removes a delivered event.
+func (r *OutboxRepo) DeleteEvent(ctx context.Context, id uint) error {
+	if _, err := r.db.Exec(ctx, "DELETE FROM outbox WHERE id = $1", id); err != nil {
+		return fmt.Errorf("OutboxRepo.DeleteEvent: %w", err)
+	}
+	return nil
+}
+
+// RetryEvent records a failed delivery and releases the event until nextAttemptAt.
+func (r *OutboxRepo) RetryEvent(ctx context.Context, id uint, attempts int, nextAttemptAt time.Time, lastError string) error {
+	query := `
+	UPDATE outbox SET attempts = $2, next_attempt_at = $3, last_error = $4, locked_until = NULL
+	WHERE id = $1
+	`
+	if _, err := r.db.Exec(ctx, query, id, attempts, nextAttemptAt, lastError); err != nil {
+		return fmt.Errorf("OutboxRepo.RetryEvent: %w", err)
+	}
+	return nil
+}
+
+// BuryEvent records the last failed delivery and dead-letters the event, it isn't leased anymore.
+func (r *OutboxRepo) BuryEvent(ctx context.Context, id uint, attempts int, lastError string) error {
+	query := `
+	UPDATE outbox SET status = 'dead', attempts = $2, last_error = $3, locked_until = NULL
+	WHERE id = $1
+	`
+	if _, err := r.db.Exec(ctx, query, id, attempts, lastError); err != nil {
+		return fmt.Errorf("OutboxRepo.BuryEvent: %w", err)
+	}
+	return nil
+}
@@ -3,22 +3,47 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// PoolOptions configures the connection pool, zero values keep the pgx defaults
+type PoolOptions struct {
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+}
+
 // ConnectPool establishes a connection pool to the PostgreSQL database using the provided connection string.
-func ConnectPool(ctx context.Context, connString string) (*pgxpool.Pool, error) {
+func ConnectPool(ctx context.Context, connString string, opts PoolOptions) (*pgxpool.Pool, error) {
 	// Parse the connection string into a configuration struct.
 	config, err := pgxpool.ParseConfig(connString)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse connection string: %w", err)
 	}
 
-	// Optional: Configure pool settings.
-	// config.MaxConns = 10                      // Maximum number of connections.
-	// config.MinConns = 2                       // Minimum number of connections.
-	// config.MaxConnIdleTime = 30 * time.Minute // Idle time before closing a connection.
+	// Configure pool settings.
+	if opts.MaxConns > 0 {
+		config.MaxConns = opts.MaxConns
+	}
+	if opts.MinConns > 0 {
+		config.MinConns = opts.MinConns
+	}
+	if opts.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = opts.MaxConnLifetime
+	}
+	if opts.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = opts.MaxConnIdleTime
+	}
+	if opts.HealthCheckPeriod > 0 {
+		config.HealthCheckPeriod = opts.HealthCheckPeriod
+	}
+
+	// Every query gets its own span in the caller's trace.
+	config.ConnConfig.Tracer = queryTracer{}
 
 	// Create the connection pool.
 	pool, err := pgxpool.NewWithConfig(ctx, config)
@@ -28,6 +53,7 @@ func ConnectPool(ctx context.Context, connString string) (*pgxpool.Pool, error)
 
 	// Verify connectivity with a ping.
 	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("unable to ping database: %w", err)
 	}
 
@@ -0,0 +1,495 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+const reservationColumns = "id, party_size, start_time, end_time, status, restaurant_id, table_id, joined_table_ids, user_id, buffer, policy_outcomes"
+
+const holdColumns = "id, token, party_size, start_time, end_time, expires_at, restaurant_id, table_id, user_id, buffer"
+
+const transitionColumns = "id, reservation_id, from_status, to_status, actor_id, actor_role, reason, at"
+
+type ReservationRepo struct {
+	db DB
+}
+
+func NewReservationRepo(db DB) *ReservationRepo {
+	return &ReservationRepo{db: db}
+}
+
+// CreateReservationTable creates the "reservations" table and the audit table of their status transitions
+// if they don't exist. Reservations made before statuses existed are taken as confirmed.
+func (r *ReservationRepo) CreateReservationTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS reservations (
+		id SERIAL PRIMARY KEY,
+		party_size INTEGER NOT NULL,
+		start_time TIMESTAMPTZ NOT NULL,
+		end_time TIMESTAMPTZ NOT NULL,
+		status TEXT NOT NULL DEFAULT 'confirmed',
+		restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+		table_id INTEGER NOT NULL REFERENCES restaurant_tables(id) ON DELETE CASCADE,
+		joined_table_ids INTEGER[] NOT NULL DEFAULT '{}',
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		buffer INTERVAL NOT NULL DEFAULT '0',
+		policy_outcomes JSONB NOT NULL DEFAULT '[]',
+		CHECK (end_time > start_time)
+	);
+	ALTER TABLE reservations ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'confirmed';
+	ALTER TABLE reservations ADD COLUMN IF NOT EXISTS joined_table_ids INTEGER[] NOT NULL DEFAULT '{}';
+	ALTER TABLE reservations ADD COLUMN IF NOT EXISTS buffer INTERVAL NOT NULL DEFAULT '0';
+	ALTER TABLE reservations ADD COLUMN IF NOT EXISTS policy_outcomes JSONB NOT NULL DEFAULT '[]';
+	CREATE INDEX IF NOT EXISTS reservations_table_time_idx ON reservations (table_id, start_time);
+	CREATE INDEX IF NOT EXISTS reservations_joined_tables_idx ON reservations USING GIN (joined_table_ids);
+	CREATE INDEX IF NOT EXISTS reservations_restaurant_start_idx ON reservations (restaurant_id, start_time);
+
+	CREATE TABLE IF NOT EXISTS reservation_transitions (
+		id SERIAL PRIMARY KEY,
+		reservation_id INTEGER NOT NULL REFERENCES reservations(id) ON DELETE CASCADE,
+		from_status TEXT NOT NULL,
+		to_status TEXT NOT NULL,
+		actor_id INTEGER NOT NULL,
+		actor_role TEXT NOT NULL,
+		reason TEXT NOT NULL DEFAULT '',
+		at TIMESTAMPTZ NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS reservation_transitions_reservation_idx ON reservation_transitions (reservation_id);
+	`
+	_, err := r.db.Exec(context.Background(), query)
+	if err != nil {
+		return fmt.Errorf("CreateReservationTable: %w", err)
+	}
+	return nil
+}
+
+// CreateReservation creates a new reservation if its tables are free for the whole time and returns its id.
+func (r *ReservationRepo) CreateReservation(ctx context.Context, reservation *models.Reservation) (uint, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ReservationRepo.CreateReservation: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	end := reservation.EndTime.Add(reservation.Buffer)
+	if err := lockFreeTables(ctx, tx, reservation.TableIDs(), reservation.ID, reservation.StartTime, end); err != nil {
+		return 0, fmt.Errorf("ReservationRepo.CreateReservation: %w", err)
+	}
+
+	query := `
+	INSERT INTO reservations (party_size, start_time, end_time, status, restaurant_id, table_id, joined_table_ids, user_id, buffer)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id
+	`
+	var id uint
+	err = tx.QueryRow(ctx, query,
+		reservation.PartySize, reservation.StartTime, reservation.EndTime, reservation.Status,
+		reservation.RestaurantID, reservation.TableID, joinedTableIDs(reservation), reservation.UserID, reservation.Buffer,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("ReservationRepo.CreateReservation: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("ReservationRepo.CreateReservation: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetReservationByID retrieves a reservation by its ID.
+func (r *ReservationRepo) GetReservationByID(ctx context.Context, id uint) (*models.Reservation, error) {
+	query := "SELECT " + reservationColumns + " FROM reservations WHERE id = $1"
+
+	reservation, err := scanReservation(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("ReservationRepo.GetReservationByID: %w", domain.ErrReservationNotFound)
+		}
+		return nil, fmt.Errorf("ReservationRepo.GetReservationByID: %w", err)
+	}
+
+	return reservation, nil
+}
+
+// GetReservationsByRestaurantID retrieves all reservations of a restaurant.
+func (r *ReservationRepo) GetReservationsByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.Reservation, error) {
+	query := "SELECT " + reservationColumns + " FROM reservations WHERE restaurant_id = $1 ORDER BY start_time"
+	reservations, err := r.queryReservations(ctx, query, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("ReservationRepo.GetReservationsByRestaurantID: %w", err)
+	}
+	return reservations, nil
+}
+
+// GetReservationsByUserID retrieves all reservations made by a user.
+func (r *ReservationRepo) GetReservationsByUserID(ctx context.Context, userID uint) ([]*models.Reservation, error) {
+	query := "SELECT " + reservationColumns + " FROM reservations WHERE user_id = $1 ORDER BY start_time"
+	reservations, err := r.queryReservations(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ReservationRepo.GetReservationsByUserID: %w", err)
+	}
+	return reservations, nil
+}
+
+// UpdateReservation replaces a reservation if its (possibly new) tables are free for the whole time.
+// The status is left as it is.
+func (r *ReservationRepo) UpdateReservation(ctx context.Context, reservation *models.Reservation) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("ReservationRepo.UpdateReservation: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	end := reservation.EndTime.Add(reservation.Buffer)
+	if err := lockFreeTables(ctx, tx, reservation.TableIDs(), reservation.ID, reservation.StartTime, end); err != nil {
+		return fmt.Errorf("ReservationRepo.UpdateReservation: %w", err)
+	}
+
+	query := `
+	UPDATE reservations SET party_size = $2, start_time = $3, end_time = $4, table_id = $5, joined_table_ids = $6, buffer = $7
+	WHERE id = $1
+	`
+	tag, err := tx.Exec(ctx, query,
+		reservation.ID, reservation.PartySize, reservation.StartTime, reservation.EndTime,
+		reservation.TableID, joinedTableIDs(reservation), reservation.Buffer,
+	)
+	if err != nil {
+		return fmt.Errorf("ReservationRepo.UpdateReservation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("ReservationRepo.UpdateReservation: %w", domain.ErrReservationNotFound)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("ReservationRepo.UpdateReservation: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteReservation deletes a reservation by its ID.
+func (r *ReservationRepo) DeleteReservation(ctx context.Context, id uint) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM reservations WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("ReservationRepo.DeleteReservation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("ReservationRepo.DeleteReservation: %w", domain.ErrReservationNotFound)
+	}
+
+	return nil
+}
+
+// UpdateReservationStatus sets the status of a reservation.
+func (r *ReservationRepo) UpdateReservationStatus(ctx context.Context, id uint, status models.ReservationStatus) error {
+	tag, err := r.db.Exec(ctx, "UPDATE reservations SET status = $2 WHERE id = $1", id, status)
+	if err != nil {
+		return fmt.Errorf("ReservationRepo.UpdateReservationStatus: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("ReservationRepo.UpdateReservationStatus: %w", domain.ErrReservationNotFound)
+	}
+
+	return nil
+}
+
+// AddPolicyOutcome appends an outcome of the change policy to a reservation.
+func (r *ReservationRepo) AddPolicyOutcome(ctx context.Context, id uint, outcome models.PolicyOutcome) error {
+	query := "UPDATE reservations SET policy_outcomes = policy_outcomes || $2 WHERE id = $1"
+	tag, err := r.db.Exec(ctx, query, id, []models.PolicyOutcome{outcome})
+	if err != nil {
+		return fmt.Errorf("ReservationRepo.AddPolicyOutcome: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("ReservationRepo.AddPolicyOutcome: %w", domain.ErrReservationNotFound)
+	}
+
+	return nil
+}
+
+// AddReservationTransition records a status transition and returns its id.
+func (r *ReservationRepo) AddReservationTransition(ctx context.Context, transition *models.ReservationTransition) (uint, error) {
+	query := `
+	INSERT INTO reservation_transitions (reservation_id, from_status, to_status, actor_id, actor_role, reason, at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+	`
+	var id uint
+	err := r.db.QueryRow(ctx, query,
+		transition.ReservationID, transition.From, transition.To,
+		transition.ActorID, transition.ActorRole, transition.Reason, transition.At,
+	).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign key violation
+			return 0, fmt.Errorf("ReservationRepo.AddReservationTransition: %w", domain.ErrReservationNotFound)
+		}
+		return 0, fmt.Errorf("ReservationRepo.AddReservationTransition: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetReservationTransitions retrieves the status transitions of a reservation, oldest first.
+func (r *ReservationRepo) GetReservationTransitions(ctx context.Context, reservationID uint) ([]*models.ReservationTransition, error) {
+	query := "SELECT " + transitionColumns + " FROM reservation_transitions WHERE reservation_id = $1 ORDER BY id"
+	rows, err := r.db.Query(ctx, query, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("ReservationRepo.GetReservationTransitions: %w", err)
+	}
+	defer rows.Close()
+
+	transitions := []*models.ReservationTransition{}
+	for rows.Next() {
+		var t models.ReservationTransition
+		if err := rows.Scan(&t.ID, &t.ReservationID, &t.From, &t.To, &t.ActorID, &t.ActorRole, &t.Reason, &t.At); err != nil {
+			return nil, fmt.Errorf("ReservationRepo.GetReservationTransitions: %w", err)
+		}
+		transitions = append(transitions, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ReservationRepo.GetReservationTransitions: %w", err)
+	}
+
+	return transitions, nil
+}
+
+// CreateHoldTable creates the "holds" table if it doesn't exist.
+// Holds are kept by the reservation repository, they share the overlap check with reservations.
+func (r *ReservationRepo) CreateHoldTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS holds (
+		id SERIAL PRIMARY KEY,
+		token TEXT NOT NULL UNIQUE,
+		party_size INTEGER NOT NULL,
+		start_time TIMESTAMPTZ NOT NULL,
+		end_time TIMESTAMPTZ NOT NULL,
+		expires_at TIMESTAMPTZ NOT NULL,
+		restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+		table_id INTEGER NOT NULL REFERENCES restaurant_tables(id) ON DELETE CASCADE,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		buffer INTERVAL NOT NULL DEFAULT '0',
+		CHECK (end_time > start_time)
+	);
+	ALTER TABLE holds ADD COLUMN IF NOT EXISTS buffer INTERVAL NOT NULL DEFAULT '0';
+	CREATE INDEX IF NOT EXISTS holds_table_time_idx ON holds (table_id, start_time);
+	CREATE INDEX IF NOT EXISTS holds_expires_at_idx ON holds (expires_at);
+	`
+	_, err := r.db.Exec(context.Background(), query)
+	if err != nil {
+		return fmt.Errorf("CreateHoldTable: %w", err)
+	}
+	return nil
+}
+
+// GetOccupiedTableIDs retrieves the tables of a restaurant taken by an active reservation or a live hold
+// at any moment of [start, end), the buffers of reservations and holds included.
+func (r *ReservationRepo) GetOccupiedTableIDs(ctx context.Context, restaurantID uint, start, end time.Time) ([]uint, error) {
+	query := `
+	SELECT unnest(table_id || joined_table_ids) AS table_id FROM reservations
+	WHERE restaurant_id = $1 AND start_time < $3 AND $2 < end_time + buffer AND status IN ('pending', 'confirmed', 'seated')
+	UNION
+	SELECT table_id FROM holds
+	WHERE restaurant_id = $1 AND start_time < $3 AND $2 < end_time + buffer AND expires_at > now()
+	ORDER BY table_id
+	`
+	rows, err := r.db.Query(ctx, query, restaurantID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("ReservationRepo.GetOccupiedTableIDs: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uint])
+	if err != nil {
+		return nil, fmt.Errorf("ReservationRepo.GetOccupiedTableIDs: %w", err)
+	}
+
+	return ids, nil
+}
+
+// CountArrivals counts the active reservations of a restaurant other than reservationID starting in [start, end),
+// and the guests of their parties.
+func (r *ReservationRepo) CountArrivals(
+	ctx context.Context,
+	restaurantID uint,
+	start, end time.Time,
+	reservationID uint,
+) (parties, covers uint, err error) {
+	query := `
+	SELECT count(*), COALESCE(sum(party_size), 0) FROM reservations
+	WHERE restaurant_id = $1 AND start_time >= $2 AND start_time < $3 AND id != $4
+		AND status IN ('pending', 'confirmed', 'seated')
+	`
+	err = r.db.QueryRow(ctx, query, restaurantID, start, end, reservationID).Scan(&parties, &covers)
+	if err != nil {
+		return 0, 0, fmt.Errorf("ReservationRepo.CountArrivals: %w", err)
+	}
+
+	return parties, covers, nil
+}
+
+// CreateHold creates a hold if the table is free for the whole time and returns its id.
+func (r *ReservationRepo) CreateHold(ctx context.Context, hold *models.Hold) (uint, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("ReservationRepo.CreateHold: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// the buffer is taken like the buffer of the reservation the hold is converted into
+	end := hold.EndTime.Add(hold.Buffer)
+	if err := lockFreeTables(ctx, tx, []uint{hold.TableID}, 0, hold.StartTime, end); err != nil {
+		return 0, fmt.Errorf("ReservationRepo.CreateHold: %w", err)
+	}
+
+	query := `
+	INSERT INTO holds (token, party_size, start_time, end_time, expires_at, restaurant_id, table_id, user_id, buffer)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id
+	`
+	var id uint
+	err = tx.QueryRow(ctx, query,
+		hold.Token, hold.PartySize, hold.StartTime, hold.EndTime, hold.ExpiresAt,
+		hold.RestaurantID, hold.TableID, hold.UserID, hold.Buffer,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("ReservationRepo.CreateHold: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("ReservationRepo.CreateHold: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetHoldByToken retrieves a hold by its token, expired or not.
+func (r *ReservationRepo) GetHoldByToken(ctx context.Context, token string) (*models.Hold, error) {
+	query := "SELECT " + holdColumns + " FROM holds WHERE token = $1"
+
+	var hold models.Hold
+	err := r.db.QueryRow(ctx, query, token).Scan(
+		&hold.ID, &hold.Token, &hold.PartySize, &hold.StartTime, &hold.EndTime, &hold.ExpiresAt,
+		&hold.RestaurantID, &hold.TableID, &hold.UserID, &hold.Buffer,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("ReservationRepo.GetHoldByToken: %w", domain.ErrHoldNotFound)
+		}
+		return nil, fmt.Errorf("ReservationRepo.GetHoldByToken: %w", err)
+	}
+
+	return &hold, nil
+}
+
+// DeleteHold deletes a hold by its ID.
+func (r *ReservationRepo) DeleteHold(ctx context.Context, id uint) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM holds WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("ReservationRepo.DeleteHold: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("ReservationRepo.DeleteHold: %w", domain.ErrHoldNotFound)
+	}
+
+	return nil
+}
+
+// DeleteExpiredHolds deletes the holds that have expired and returns how many there were.
+func (r *ReservationRepo) DeleteExpiredHolds(ctx context.Context) (int, error) {
+	tag, err := r.db.Exec(ctx, "DELETE FROM holds WHERE expires_at <= now()")
+	if err != nil {
+		return 0, fmt.Errorf("ReservationRepo.DeleteExpiredHolds: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// lockFreeTables locks the table rows in ID order, so concurrent bookings, holds and seatings of the same tables
+// are serialized without deadlocks, and checks that no party seated at any of the tables is expected to stay past
+// start, and that no active reservation other than reservationID, and no live hold, takes any of them at any moment
+// of [start, end), the buffers of reservations and holds included.
+func lockFreeTables(ctx context.Context, tx pgx.Tx, tableIDs []uint, reservationID uint, start, end time.Time) error {
+	rows, err := tx.Query(ctx,
+		"SELECT occupied_until > $2 FROM restaurant_tables WHERE id = ANY($1) ORDER BY id FOR UPDATE", tableIDs, start)
+	if err != nil {
+		return err
+	}
+	// NULL for free tables
+	locked, err := pgx.CollectRows(rows, pgx.RowTo[*bool])
+	if err != nil {
+		return err
+	}
+	if len(locked) != len(tableIDs) {
+		return domain.ErrTableNotFound
+	}
+	for _, occupied := range locked {
+		if occupied != nil && *occupied {
+			return fmt.Errorf("%w: a party seated at the table stays past the start", domain.ErrTableAlreadyReserved)
+		}
+	}
+
+	query := `
+	SELECT EXISTS(
+		SELECT 1 FROM reservations
+		WHERE (table_id = ANY($1) OR joined_table_ids && $1) AND id != $2 AND start_time < $4 AND $3 < end_time + buffer
+			AND status IN ('pending', 'confirmed', 'seated')
+	) OR EXISTS(
+		SELECT 1 FROM holds
+		WHERE table_id = ANY($1) AND start_time < $4 AND $3 < end_time + buffer AND expires_at > now()
+	)
+	`
+	var reserved bool
+	err = tx.QueryRow(ctx, query, tableIDs, reservationID, start, end).Scan(&reserved)
+	if err != nil {
+		return err
+	}
+	if reserved {
+		return domain.ErrTableAlreadyReserved
+	}
+
+	return nil
+}
+
+// joinedTableIDs returns the joined tables of the reservation, empty rather than nil for the NOT NULL column
+func joinedTableIDs(reservation *models.Reservation) []uint {
+	if reservation.JoinedTableIDs == nil {
+		return []uint{}
+	}
+	return reservation.JoinedTableIDs
+}
+
+func scanReservation(row pgx.Row) (*models.Reservation, error) {
+	var res models.Reservation
+	err := row.Scan(
+		&res.ID, &res.PartySize, &res.StartTime, &res.EndTime, &res.Status,
+		&res.RestaurantID, &res.TableID, &res.JoinedTableIDs, &res.UserID, &res.Buffer, &res.PolicyOutcomes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (r *ReservationRepo) queryReservations(ctx context.Context, query string, args ...interface{}) ([]*models.Reservation, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reservations := []*models.Reservation{}
+	for rows.Next() {
+		res, err := scanReservation(rows)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, res)
+	}
+
+	return reservations, rows.Err()
+}
@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+type RestaurantRepo struct {
+	db DB
+}
+
+func NewRestaurantRepo(db DB) *RestaurantRepo {
+	return &RestaurantRepo{db: db}
+}
+
+// CreateRestaurantTables creates the "restaurants" and "opening_hours" tables if they don't exist.
+func (r *RestaurantRepo) CreateRestaurantTables() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS restaurants (
+		id SERIAL PRIMARY KEY,
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		address TEXT NOT NULL DEFAULT '',
+		time_zone TEXT NOT NULL DEFAULT 'UTC',
+		table_assignment TEXT NOT NULL DEFAULT 'tightest_fit',
+		booking_rules JSONB NOT NULL DEFAULT '{}',
+		owner_id INTEGER NOT NULL REFERENCES users(id)
+	);
+	ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'UTC';
+	ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS table_assignment TEXT NOT NULL DEFAULT 'tightest_fit';
+	ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS booking_rules JSONB NOT NULL DEFAULT '{}';
+	CREATE TABLE IF NOT EXISTS opening_hours (
+		restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+		day_of_week TEXT NOT NULL,
+		open_time TEXT NOT NULL,
+		close_time TEXT NOT NULL,
+		PRIMARY KEY (restaurant_id, day_of_week)
+	);
+	`
+	_, err := r.db.Exec(context.Background(), query)
+	if err != nil {
+		return fmt.Errorf("CreateRestaurantTables: %w", err)
+	}
+	return nil
+}
+
+// CreateRestaurant creates a new restaurant together with its opening hours and returns its id.
+func (r *RestaurantRepo) CreateRestaurant(ctx context.Context, restaurant *models.Restaurant) (uint, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("RestaurantRepo.CreateRestaurant: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+	INSERT INTO restaurants (name, description, address, time_zone, table_assignment, booking_rules, owner_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+	`
+	var id uint
+	err = tx.QueryRow(ctx, query,
+		restaurant.Name, restaurant.Description, restaurant.Address, restaurant.TimeZone, restaurant.TableAssignment,
+		restaurant.BookingRules, restaurant.OwnerID,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("RestaurantRepo.CreateRestaurant: %w", err)
+	}
+
+	if err := insertOpeningHours(ctx, tx, id, restaurant.OpeningHours); err != nil {
+		return 0, fmt.Errorf("RestaurantRepo.CreateRestaurant: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("RestaurantRepo.CreateRestaurant: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetRestaurants retrieves all restaurants with their opening hours.
+func (r *RestaurantRepo) GetRestaurants(ctx context.Context) ([]*models.Restaurant, error) {
+	query := "SELECT id, name, description, address, time_zone, table_assignment, booking_rules, owner_id FROM restaurants ORDER BY id"
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("RestaurantRepo.GetRestaurants: %w", err)
+	}
+	defer rows.Close()
+
+	restaurants := []*models.Restaurant{}
+	byID := make(map[uint]*models.Restaurant)
+	for rows.Next() {
+		var restaurant models.Restaurant
+		if err := rows.Scan(
+			&restaurant.ID, &restaurant.Name, &restaurant.Description, &restaurant.Address, &restaurant.TimeZone, &restaurant.TableAssignment,
+			&restaurant.BookingRules, &restaurant.OwnerID,
+		); err != nil {
+			return nil, fmt.Errorf("RestaurantRepo.GetRestaurants: %w", err)
+		}
+		restaurants = append(restaurants, &restaurant)
+		byID[restaurant.ID] = &restaurant
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("RestaurantRepo.GetRestaurants: %w", err)
+	}
+
+	hoursRows, err := r.db.Query(ctx, "SELECT restaurant_id, day_of_week, open_time, close_time FROM opening_hours")
+	if err != nil {
+		return nil, fmt.Errorf("RestaurantRepo.GetRestaurants: %w", err)
+	}
+	defer hoursRows.Close()
+
+	for hoursRows.Next() {
+		var oh models.OpeningHours
+		if err := hoursRows.Scan(&oh.RestaurantID, &oh.DayOfWeek, &oh.OpenTime, &oh.CloseTime); err != nil {
+			return nil, fmt.Errorf("RestaurantRepo.GetRestaurants: %w", err)
+		}
+		if restaurant, ok := byID[oh.RestaurantID]; ok {
+			restaurant.OpeningHours = append(restaurant.OpeningHours, oh)
+		}
+	}
+	if err := hoursRows.Err(); err != nil {
+		return nil, fmt.Errorf("RestaurantRepo.GetRestaurants: %w", err)
+	}
+
+	return restaurants, nil
+}
+
+// GetRestaurantByID retrieves a restaurant with its opening hours by its ID.
+func (r *RestaurantRepo) GetRestaurantByID(ctx context.Context, id uint) (*models.Restaurant, error) {
+	query := "SELECT id, name, description, address, time_zone, table_assignment, booking_rules, owner_id FROM restaurants WHERE id = $1"
+	var restaurant models.Restaurant
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&restaurant.ID, &restaurant.Name, &restaurant.Description, &restaurant.Address, &restaurant.TimeZone, &restaurant.TableAssignment,
+		&restaurant.BookingRules, &restaurant.OwnerID,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("RestaurantRepo.GetRestaurantByID: %w", domain.ErrRestaurantNotFound)
+		}
+		return nil, fmt.Errorf("RestaurantRepo.GetRestaurantByID: %w", err)
+	}
+
+	rows, err := r.db.Query(ctx, "SELECT restaurant_id, day_of_week, open_time, close_time FROM opening_hours WHERE restaurant_id = $1", id)
+	if err != nil {
+		return nil, fmt.Errorf("RestaurantRepo.GetRestaurantByID: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var oh models.OpeningHours
+		if err := rows.Scan(&oh.RestaurantID, &oh.DayOfWeek, &oh.OpenTime, &oh.CloseTime); err != nil {
+			return nil, fmt.Errorf("RestaurantRepo.GetRestaurantByID: %w", err)
+		}
+		restaurant.OpeningHours = append(restaurant.OpeningHours, oh)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("RestaurantRepo.GetRestaurantByID: %w", err)
+	}
+
+	return &restaurant, nil
+}
+
+// UpdateRestraunt updates the non-empty fields of a restaurant.
+// Opening hours are replaced when provided.
+func (r *RestaurantRepo) UpdateRestraunt(ctx context.Context, restaurant *models.Restaurant) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("RestaurantRepo.UpdateRestraunt: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+	UPDATE restaurants SET
+		name = COALESCE(NULLIF($2, ''), name),
+		description = COALESCE(NULLIF($3, ''), description),
+		address = COALESCE(NULLIF($4, ''), address),
+		time_zone = COALESCE(NULLIF($5, ''), time_zone),
+		table_assignment = COALESCE(NULLIF($6, ''), table_assignment)
+	WHERE id = $1
+	`
+	tag, err := tx.Exec(ctx, query,
+		restaurant.ID, restaurant.Name, restaurant.Description, restaurant.Address, restaurant.TimeZone, restaurant.TableAssignment)
+	if err != nil {
+		return fmt.Errorf("RestaurantRepo.UpdateRestraunt: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("RestaurantRepo.UpdateRestraunt: %w", domain.ErrRestaurantNotFound)
+	}
+
+	if len(restaurant.OpeningHours) > 0 {
+		if _, err := tx.Exec(ctx, "DELETE FROM opening_hours WHERE restaurant_id = $1", restaurant.ID); err != nil {
+			return fmt.Errorf("RestaurantRepo.UpdateRestraunt: %w", err)
+		}
+		if err := insertOpeningHours(ctx, tx, restaurant.ID, restaurant.OpeningHours); err != nil {
+			return fmt.Errorf("RestaurantRepo.UpdateRestraunt: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("RestaurantRepo.UpdateRestraunt: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateBookingRules replaces the booking rules of a restaurant.
+func (r *RestaurantRepo) UpdateBookingRules(ctx context.Context, restaurantID uint, rules models.BookingRules) error {
+	tag, err := r.db.Exec(ctx, "UPDATE restaurants SET booking_rules = $2 WHERE id = $1", restaurantID, rules)
+	if err != nil {
+		return fmt.Errorf("RestaurantRepo.UpdateBookingRules: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("RestaurantRepo.UpdateBookingRules: %w", domain.ErrRestaurantNotFound)
+	}
+
+	return nil
+}
+
+// DeleteRestraunt deletes a restaurant, its opening hours and its tables.
+func (r *RestaurantRepo) DeleteRestraunt(ctx context.Context, id uint) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM restaurants WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("RestaurantRepo.DeleteRestraunt: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("RestaurantRepo.DeleteRestraunt: %w", domain.ErrRestaurantNotFound)
+	}
+
+	return nil
+}
+
+func insertOpeningHours(ctx context.Context, tx pgx.Tx, restaurantID uint, hours []models.OpeningHours) error {
+	query := "INSERT INTO opening_hours (restaurant_id, day_of_week, open_time, close_time) VALUES ($1, $2, $3, $4)"
+	for _, oh := range hours {
+		if _, err := tx.Exec(ctx, query, restaurantID, oh.DayOfWeek, oh.OpenTime, oh.CloseTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+type TableRepo struct {
+	db DB
+}
+
+func NewTableRepo(db DB) *TableRepo {
+	return &TableRepo{db: db}
+}
+
+// CreateTableTable creates the "restaurant_tables" and "table_combinations" tables if they don't exist.
+func (r *TableRepo) CreateTableTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS restaurant_tables (
+		id SERIAL PRIMARY KEY,
+		number INTEGER NOT NULL,
+		capacity INTEGER NOT NULL,
+		is_available BOOLEAN NOT NULL DEFAULT TRUE,
+		min_party_size INTEGER NOT NULL DEFAULT 0,
+		attributes TEXT[] NOT NULL DEFAULT '{}',
+		occupied_since TIMESTAMPTZ,
+		occupied_until TIMESTAMPTZ,
+		occupied_party_size INTEGER NOT NULL DEFAULT 0,
+		restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+		UNIQUE (restaurant_id, number)
+	);
+	ALTER TABLE restaurant_tables ADD COLUMN IF NOT EXISTS min_party_size INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE restaurant_tables ADD COLUMN IF NOT EXISTS attributes TEXT[] NOT NULL DEFAULT '{}';
+	ALTER TABLE restaurant_tables ADD COLUMN IF NOT EXISTS occupied_since TIMESTAMPTZ;
+	ALTER TABLE restaurant_tables ADD COLUMN IF NOT EXISTS occupied_until TIMESTAMPTZ;
+	ALTER TABLE restaurant_tables ADD COLUMN IF NOT EXISTS occupied_party_size INTEGER NOT NULL DEFAULT 0;
+
+	CREATE TABLE IF NOT EXISTS table_combinations (
+		id SERIAL PRIMARY KEY,
+		table_ids INTEGER[] NOT NULL,
+		capacity INTEGER NOT NULL,
+		restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+		UNIQUE (restaurant_id, table_ids)
+	);
+	`
+	_, err := r.db.Exec(context.Background(), query)
+	if err != nil {
+		return fmt.Errorf("CreateTableTable: %w", err)
+	}
+	return nil
+}
+
+// CreateTable creates a new table in a restaurant and returns the new table's id.
+func (r *TableRepo) CreateTable(ctx context.Context, table *models.Table) (uint, error) {
+	query := `
+	INSERT INTO restaurant_tables (number, capacity, is_available, min_party_size, attributes, restaurant_id)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id
+	`
+	attributes := table.Attributes
+	if attributes == nil {
+		attributes = []string{}
+	}
+	var id uint
+	err := r.db.QueryRow(ctx, query,
+		table.Number, table.Capacity, table.IsAvailable, table.MinPartySize, attributes, table.RestaurantID,
+	).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505": // unique constraint violation
+				return 0, fmt.Errorf("TableRepo.CreateTable: %w", domain.ErrTableAlreadyExists)
+			case "23503": // foreign key violation
+				return 0, fmt.Errorf("TableRepo.CreateTable: %w", domain.ErrRestaurantNotFound)
+			}
+		}
+		return 0, fmt.Errorf("TableRepo.CreateTable: %w", err)
+	}
+	return id, nil
+}
+
+// GetTablesByRestaurantID retrieves all tables of a restaurant.
+func (r *TableRepo) GetTablesByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.Table, error) {
+	query := "SELECT " + tableColumns + " FROM restaurant_tables WHERE restaurant_id = $1 ORDER BY number"
+	tables, err := r.queryTables(ctx, query, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("TableRepo.GetTablesByRestaurantID: %w", err)
+	}
+	return tables, nil
+}
+
+// GetAvailableTablesByRestaurantID retrieves the available tables of a restaurant.
+func (r *TableRepo) GetAvailableTablesByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.Table, error) {
+	query := "SELECT " + tableColumns + " FROM restaurant_tables WHERE restaurant_id = $1 AND is_available ORDER BY number"
+	tables, err := r.queryTables(ctx, query, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("TableRepo.GetAvailableTablesByRestaurantID: %w", err)
+	}
+	return tables, nil
+}
+
+// GetTableByID retrieves a table by its ID.
+func (r *TableRepo) GetTableByID(ctx context.Context, id uint) (*models.Table, error) {
+	query := "SELECT " + tableColumns + " FROM restaurant_tables WHERE id = $1"
+
+	table, err := scanTable(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("TableRepo.GetTableByID: %w", domain.ErrTableNotFound)
+		}
+		return nil, fmt.Errorf("TableRepo.GetTableByID: %w", err)
+	}
+
+	return table, nil
+}
+
+// UpdateTable updates the number, capacity, minimum party size and attributes of a table when they are set,
+// and always its availability.
+func (r *TableRepo) UpdateTable(ctx context.Context, table *models.Table) error {
+	query := `
+	UPDATE restaurant_tables SET
+		number = COALESCE(NULLIF($2, 0), number),
+		capacity = COALESCE(NULLIF($3, 0), capacity),
+		is_available = $4,
+		min_party_size = COALESCE(NULLIF($5, 0), min_party_size),
+		attributes = COALESCE($6, attributes)
+	WHERE id = $1
+	`
+	tag, err := r.db.Exec(ctx, query,
+		table.ID, table.Number, table.Capacity, table.IsAvailable, table.MinPartySize, table.Attributes)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("TableRepo.UpdateTable: %w", domain.ErrTableAlreadyExists)
+		}
+		return fmt.Errorf("TableRepo.UpdateTable: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("TableRepo.UpdateTable: %w", domain.ErrTableNotFound)
+	}
+
+	return nil
+}
+
+// SetTableOccupancy seats a party of partySize at a table since the time until it's expected to be free,
+// a zero since frees the table.
+func (r *TableRepo) SetTableOccupancy(ctx context.Context, id uint, since, until time.Time, partySize uint) error {
+	if since.IsZero() {
+		until, partySize = time.Time{}, 0
+	}
+	tag, err := r.db.Exec(ctx,
+		"UPDATE restaurant_tables SET occupied_since = $2, occupied_until = $3, occupied_party_size = $4 WHERE id = $1",
+		id, nullTime(since), nullTime(until), partySize)
+	if err != nil {
+		return fmt.Errorf("TableRepo.SetTableOccupancy: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("TableRepo.SetTableOccupancy: %w", domain.ErrTableNotFound)
+	}
+
+	return nil
+}
+
+// DeleteTable deletes a table by its ID.
+func (r *TableRepo) DeleteTable(ctx context.Context, id uint) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM restaurant_tables WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("TableRepo.DeleteTable: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("TableRepo.DeleteTable: %w", domain.ErrTableNotFound)
+	}
+
+	return nil
+}
+
+// CreateTableCombination creates a combination of tables and returns its id.
+func (r *TableRepo) CreateTableCombination(ctx context.Context, combination *models.TableCombination) (uint, error) {
+	query := "INSERT INTO table_combinations (table_ids, capacity, restaurant_id) VALUES ($1, $2, $3) RETURNING id"
+	var id uint
+	err := r.db.QueryRow(ctx, query, combination.TableIDs, combination.Capacity, combination.RestaurantID).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505": // unique constraint violation
+				return 0, fmt.Errorf("TableRepo.CreateTableCombination: %w", domain.ErrTableCombinationAlreadyExists)
+			case "23503": // foreign key violation
+				return 0, fmt.Errorf("TableRepo.CreateTableCombination: %w", domain.ErrRestaurantNotFound)
+			}
+		}
+		return 0, fmt.Errorf("TableRepo.CreateTableCombination: %w", err)
+	}
+	return id, nil
+}
+
+// GetTableCombinationsByRestaurantID retrieves the table combinations of a restaurant.
+func (r *TableRepo) GetTableCombinationsByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.TableCombination, error) {
+	query := "SELECT id, table_ids, capacity, restaurant_id FROM table_combinations WHERE restaurant_id = $1 ORDER BY id"
+	rows, err := r.db.Query(ctx, query, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("TableRepo.GetTableCombinationsByRestaurantID: %w", err)
+	}
+	defer rows.Close()
+
+	combinations := []*models.TableCombination{}
+	for rows.Next() {
+		var c models.TableCombination
+		if err := rows.Scan(&c.ID, &c.TableIDs, &c.Capacity, &c.RestaurantID); err != nil {
+			return nil, fmt.Errorf("TableRepo.GetTableCombinationsByRestaurantID: %w", err)
+		}
+		combinations = append(combinations, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("TableRepo.GetTableCombinationsByRestaurantID: %w", err)
+	}
+
+	return combinations, nil
+}
+
+// DeleteTableCombination deletes a table combination by its ID.
+func (r *TableRepo) DeleteTableCombination(ctx context.Context, id uint) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM table_combinations WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("TableRepo.DeleteTableCombination: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("TableRepo.DeleteTableCombination: %w", domain.ErrTableCombinationNotFound)
+	}
+
+	return nil
+}
+
+func (r *TableRepo) queryTables(ctx context.Context, query string, args ...interface{}) ([]*models.Table, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := []*models.Table{}
+	for rows.Next() {
+		table, err := scanTable(rows)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+
+	return tables, rows.Err()
+}
+
+const tableColumns = "id, number, capacity, is_available, min_party_size, attributes, occupied_since, occupied_until, occupied_party_size, restaurant_id"
+
+func scanTable(row pgx.Row) (*models.Table, error) {
+	var table models.Table
+	var occupiedSince, occupiedUntil *time.Time
+	err := row.Scan(
+		&table.ID, &table.Number, &table.Capacity, &table.IsAvailable, &table.MinPartySize, &table.Attributes,
+		&occupiedSince, &occupiedUntil, &table.OccupiedPartySize, &table.RestaurantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if occupiedSince != nil {
+		table.OccupiedSince = *occupiedSince
+	}
+	if occupiedUntil != nil {
+		table.OccupiedUntil = *occupiedUntil
+	}
+	return &table, nil
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/kourai55k/booking-service/internal/data/txhooks"
+)
+
+// TxOptions configures the transactions of a TxManager
+type TxOptions struct {
+	// Isolation is the isolation level, serializable if empty
+	Isolation pgx.TxIsoLevel
+	// MaxRetries is how many times a transaction is retried after a serialization failure or a deadlock
+	MaxRetries int
+	// RetryBackoff is the base wait before a retry, doubled after every attempt and jittered
+	RetryBackoff time.Duration
+}
+
+// TxManager runs units of work in one transaction, with the repositories built by bind on top of it.
+// R is whatever set of repositories the caller needs.
+type TxManager[R any] struct {
+	pool *pgxpool.Pool
+	opts TxOptions
+	bind func(db DB) R
+}
+
+func NewTxManager[R any](pool *pgxpool.Pool, opts TxOptions, bind func(db DB) R) *TxManager[R] {
+	if opts.Isolation == "" {
+		opts.Isolation = pgx.Serializable
+	}
+	return &TxManager[R]{pool: pool, opts: opts, bind: bind}
+}
+
+// Do runs fn in a transaction, committing if it returns nil and rolling back otherwise.
+// Serialization failures and deadlocks, from fn or the commit, rerun fn in a new transaction,
+// so fn must not have side effects outside the database.
+func (m *TxManager[R]) Do(ctx context.Context, fn func(ctx context.Context, repos R) error) error {
+	const op = "TxManager.Do"
+
+	backoff := m.opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := m.run(ctx, fn)
+		if err == nil {
+			return nil
+		}
+		if !retryable(err) || attempt >= m.opts.MaxRetries {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		wait := backoff
+		if wait > 0 {
+			wait += rand.N(wait)
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", op, errors.Join(err, ctx.Err()))
+		}
+		backoff *= 2
+	}
+}
+
+// run makes one attempt, the hooks registered in it run once it has committed
+func (m *TxManager[R]) run(ctx context.Context, fn func(ctx context.Context, repos R) error) error {
+	tx, err := m.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: m.opts.Isolation})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	ctx, commit := txhooks.Begin(ctx)
+	if err := fn(ctx, m.bind(tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	commit()
+	return nil
+}
+
+// retryable reports whether err is a serialization failure or a deadlock, which succeed when retried
+func retryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}
@@ -5,19 +5,18 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/kourai55k/booking-service/internal/domain"
 	"github.com/kourai55k/booking-service/internal/domain/models"
 )
 
 type UserRepo struct {
-	pool *pgxpool.Pool
+	db DB
 }
 
-func NewUserRepo(pool *pgxpool.Pool) *UserRepo {
-	return &UserRepo{pool: pool}
+func NewUserRepo(db DB) *UserRepo {
+	return &UserRepo{db: db}
 }
 
 // CreateUserTable creates the "users" table if it doesn't exist.
@@ -31,7 +30,7 @@ func (u *UserRepo) CreateUserTable() error {
 		role TEXT
 	);
 	`
-	_, err := u.pool.Exec(context.Background(), query)
+	_, err := u.db.Exec(context.Background(), query)
 	if err != nil {
 		return fmt.Errorf("CreateUserTable: %w", err)
 	}
@@ -39,10 +38,10 @@ func (u *UserRepo) CreateUserTable() error {
 }
 
 // CreateUser creates a new user in the database and returns the new user's id.
-func (r *UserRepo) CreateUser(user *models.User) (uint, error) {
+func (r *UserRepo) CreateUser(ctx context.Context, user *models.User) (uint, error) {
 	query := "INSERT INTO users (name, login, hashpass, role) VALUES ($1, $2, $3, $4) RETURNING id"
 	var id uint
-	err := r.pool.QueryRow(context.Background(), query, user.Name, user.Login, user.HashPass, user.Role).Scan(&id)
+	err := r.db.QueryRow(ctx, query, user.Name, user.Login, user.HashPass, user.Role).Scan(&id)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // Check unique constraint violation
@@ -54,9 +53,9 @@ func (r *UserRepo) CreateUser(user *models.User) (uint, error) {
 }
 
 // GetUsers retrieves all users from the database.
-func (r *UserRepo) GetUsers() ([]*models.User, error) {
-	query := "SELECT id, name, login, hashpass, role FROM users"
-	rows, err := r.pool.Query(context.Background(), query)
+func (r *UserRepo) GetUsers(ctx context.Context) ([]*models.User, error) {
+	query := "SELECT id, name, login, hashpass, role FROM users ORDER BY id"
+	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("UserRepo.GetUsers: %w", err)
 	}
@@ -79,9 +78,9 @@ func (r *UserRepo) GetUsers() ([]*models.User, error) {
 }
 
 // GetUserByID retrieves a user by its ID.
-func (r *UserRepo) GetUserByID(id uint) (*models.User, error) {
+func (r *UserRepo) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
 	query := "SELECT id, name, login, hashpass, role FROM users WHERE id = $1"
-	row := r.pool.QueryRow(context.Background(), query, id)
+	row := r.db.QueryRow(ctx, query, id)
 
 	var user models.User
 	if err := row.Scan(&user.ID, &user.Name, &user.Login, &user.HashPass, &user.Role); err != nil {
@@ -95,9 +94,9 @@ func (r *UserRepo) GetUserByID(id uint) (*models.User, error) {
 }
 
 // GetUserByLogin retrieves a user by its login.
-func (r *UserRepo) GetUserByLogin(login string) (*models.User, error) {
+func (r *UserRepo) GetUserByLogin(ctx context.Context, login string) (*models.User, error) {
 	query := "SELECT id, name, login, hashpass, role FROM users WHERE login = $1"
-	row := r.pool.QueryRow(context.Background(), query, login)
+	row := r.db.QueryRow(ctx, query, login)
 
 	var user models.User
 	if err := row.Scan(&user.ID, &user.Name, &user.Login, &user.HashPass, &user.Role); err != nil {
@@ -111,12 +110,10 @@ func (r *UserRepo) GetUserByLogin(login string) (*models.User, error) {
 }
 
 // UpdateUser updates an existing user in the database.
-func (r *UserRepo) UpdateUser(user *models.User) error {
-	ctx := context.Background()
-
+func (r *UserRepo) UpdateUser(ctx context.Context, user *models.User) error {
 	// Check if the user exists
 	var exists bool
-	err := r.pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", user.ID).Scan(&exists)
+	err := r.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)", user.ID).Scan(&exists)
 	if err != nil {
 		return fmt.Errorf("UserRepo.UpdateUser: %w", err) // Wrapping the DB error
 	}
@@ -127,7 +124,7 @@ func (r *UserRepo) UpdateUser(user *models.User) error {
 	// Check if the new login is already taken by another user (if login is being updated)
 	if user.Login != "" {
 		var loginExists bool
-		err := r.pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE login = $1 AND id != $2)", user.Login, user.ID).Scan(&loginExists)
+		err := r.db.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE login = $1 AND id != $2)", user.Login, user.ID).Scan(&loginExists)
 		if err != nil {
 			return fmt.Errorf("UserRepo.UpdateUser: %w", err) // Wrapping the DB error
 		}
@@ -173,7 +170,7 @@ func (r *UserRepo) UpdateUser(user *models.User) error {
 	query = query[:len(query)-1] + " WHERE id = $1"
 
 	// Execute the query
-	_, err = r.pool.Exec(ctx, query, args...)
+	_, err = r.db.Exec(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("UserRepo.UpdateUser: %w", err) // Wrapping the DB error
 	}
@@ -182,11 +179,11 @@ func (r *UserRepo) UpdateUser(user *models.User) error {
 }
 
 // DeleteUser deletes a user from the database.
-func (r *UserRepo) DeleteUser(id uint) error {
+func (r *UserRepo) DeleteUser(ctx context.Context, id uint) error {
 	// Проверяем, существует ли пользователь
 	existsQuery := "SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)"
 	var exists bool
-	err := r.pool.QueryRow(context.Background(), existsQuery, id).Scan(&exists)
+	err := r.db.QueryRow(ctx, existsQuery, id).Scan(&exists)
 	if err != nil {
 		return fmt.Errorf("UserRepo.DeleteUser: %w", err) // Ошибка БД
 	}
@@ -198,8 +195,12 @@ func (r *UserRepo) DeleteUser(id uint) error {
 
 	// Удаляем пользователя
 	query := "DELETE FROM users WHERE id = $1"
-	_, err = r.pool.Exec(context.Background(), query, id)
+	_, err = r.db.Exec(ctx, query, id)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // restaurants.owner_id references the user
+			return fmt.Errorf("UserRepo.DeleteUser: %w", domain.ErrUserOwnsRestaurants)
+		}
 		return fmt.Errorf("UserRepo.DeleteUser: %w", err)
 	}
 
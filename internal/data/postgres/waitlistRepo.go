@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+const waitlistColumns = "id, party_size, window_start, window_end, contact, status, created_at, offer, restaurant_id, user_id"
+
+type WaitlistRepo struct {
+	db DB
+}
+
+func NewWaitlistRepo(db DB) *WaitlistRepo {
+	return &WaitlistRepo{db: db}
+}
+
+// CreateWaitlistTable creates the "waitlist" table if it doesn't exist.
+// The offer of an entry is kept as JSON, NULL until one is made.
+func (r *WaitlistRepo) CreateWaitlistTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS waitlist (
+		id SERIAL PRIMARY KEY,
+		party_size INTEGER NOT NULL,
+		window_start TIMESTAMPTZ NOT NULL,
+		window_end TIMESTAMPTZ NOT NULL,
+		contact TEXT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'waiting',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		offer JSONB,
+		restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+		user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+		CHECK (window_end >= window_start)
+	);
+	CREATE INDEX IF NOT EXISTS waitlist_restaurant_status_idx ON waitlist (restaurant_id, status);
+	CREATE INDEX IF NOT EXISTS waitlist_offer_token_idx ON waitlist ((offer->>'Token'));
+	`
+	_, err := r.db.Exec(context.Background(), query)
+	if err != nil {
+		return fmt.Errorf("CreateWaitlistTable: %w", err)
+	}
+	return nil
+}
+
+// CreateWaitlistEntry creates a new waitlist entry and returns its id.
+func (r *WaitlistRepo) CreateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) (uint, error) {
+	query := `
+	INSERT INTO waitlist (party_size, window_start, window_end, contact, status, created_at, offer, restaurant_id, user_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id
+	`
+	var id uint
+	err := r.db.QueryRow(ctx, query,
+		entry.PartySize, entry.WindowStart, entry.WindowEnd, entry.Contact, entry.Status, entry.CreatedAt, entry.Offer,
+		entry.RestaurantID, entry.UserID,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("WaitlistRepo.CreateWaitlistEntry: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetWaitlistEntryByID retrieves a waitlist entry by its ID.
+func (r *WaitlistRepo) GetWaitlistEntryByID(ctx context.Context, id uint) (*models.WaitlistEntry, error) {
+	query := "SELECT " + waitlistColumns + " FROM waitlist WHERE id = $1"
+
+	entry, err := scanWaitlistEntry(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("WaitlistRepo.GetWaitlistEntryByID: %w", domain.ErrWaitlistEntryNotFound)
+		}
+		return nil, fmt.Errorf("WaitlistRepo.GetWaitlistEntryByID: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetWaitlistEntryByOfferToken retrieves the entry last offered the hold of the token.
+func (r *WaitlistRepo) GetWaitlistEntryByOfferToken(ctx context.Context, token string) (*models.WaitlistEntry, error) {
+	query := "SELECT " + waitlistColumns + " FROM waitlist WHERE offer->>'Token' = $1"
+
+	entry, err := scanWaitlistEntry(r.db.QueryRow(ctx, query, token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("WaitlistRepo.GetWaitlistEntryByOfferToken: %w", domain.ErrWaitlistEntryNotFound)
+		}
+		return nil, fmt.Errorf("WaitlistRepo.GetWaitlistEntryByOfferToken: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetWaitingEntries retrieves the waiting entries of a restaurant whose window takes start, oldest first.
+func (r *WaitlistRepo) GetWaitingEntries(ctx context.Context, restaurantID uint, start time.Time) ([]*models.WaitlistEntry, error) {
+	query := "SELECT " + waitlistColumns + ` FROM waitlist
+	WHERE restaurant_id = $1 AND status = 'waiting' AND window_start <= $2 AND $2 <= window_end
+	ORDER BY id`
+	entries, err := r.queryWaitlist(ctx, query, restaurantID, start)
+	if err != nil {
+		return nil, fmt.Errorf("WaitlistRepo.GetWaitingEntries: %w", err)
+	}
+	return entries, nil
+}
+
+// GetExpiredOffers retrieves the offered entries whose offer expired by now.
+func (r *WaitlistRepo) GetExpiredOffers(ctx context.Context, now time.Time) ([]*models.WaitlistEntry, error) {
+	query := "SELECT " + waitlistColumns + ` FROM waitlist
+	WHERE status = 'offered' AND (offer->>'ExpiresAt')::timestamptz <= $1
+	ORDER BY id`
+	entries, err := r.queryWaitlist(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("WaitlistRepo.GetExpiredOffers: %w", err)
+	}
+	return entries, nil
+}
+
+// UpdateWaitlistEntry sets the status and the offer of an entry.
+func (r *WaitlistRepo) UpdateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) error {
+	tag, err := r.db.Exec(ctx, "UPDATE waitlist SET status = $2, offer = $3 WHERE id = $1", entry.ID, entry.Status, entry.Offer)
+	if err != nil {
+		return fmt.Errorf("WaitlistRepo.UpdateWaitlistEntry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("WaitlistRepo.UpdateWaitlistEntry: %w", domain.ErrWaitlistEntryNotFound)
+	}
+
+	return nil
+}
+
+func scanWaitlistEntry(row pgx.Row) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := row.Scan(
+		&entry.ID, &entry.PartySize, &entry.WindowStart, &entry.WindowEnd, &entry.Contact, &entry.Status,
+		&entry.CreatedAt, &entry.Offer, &entry.RestaurantID, &entry.UserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *WaitlistRepo) queryWaitlist(ctx context.Context, query string, args ...interface{}) ([]*models.WaitlistEntry, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*models.WaitlistEntry{}
+	for rows.Next() {
+		entry, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+const walkInColumns = "id, name, party_size, contact, status, quoted_wait, joined_at, notified_at, seated_at, restaurant_id, table_id"
+
+type WalkInRepo struct {
+	db DB
+}
+
+func NewWalkInRepo(db DB) *WalkInRepo {
+	return &WalkInRepo{db: db}
+}
+
+// CreateWalkInTable creates the "walk_ins" table if it doesn't exist.
+// The notification and seating times are NULL and the table 0 until they happen.
+func (r *WalkInRepo) CreateWalkInTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS walk_ins (
+		id SERIAL PRIMARY KEY,
+		name TEXT NOT NULL,
+		party_size INTEGER NOT NULL,
+		contact TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL DEFAULT 'waiting',
+		quoted_wait INTERVAL NOT NULL DEFAULT '0',
+		joined_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		notified_at TIMESTAMPTZ,
+		seated_at TIMESTAMPTZ,
+		restaurant_id INTEGER NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+		table_id INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE INDEX IF NOT EXISTS walk_ins_restaurant_status_idx ON walk_ins (restaurant_id, status);
+	`
+	_, err := r.db.Exec(context.Background(), query)
+	if err != nil {
+		return fmt.Errorf("CreateWalkInTable: %w", err)
+	}
+	return nil
+}
+
+// CreateWalkIn creates a new walk-in and returns its id.
+func (r *WalkInRepo) CreateWalkIn(ctx context.Context, walkIn *models.WalkIn) (uint, error) {
+	query := `
+	INSERT INTO walk_ins (name, party_size, contact, status, quoted_wait, joined_at, notified_at, seated_at, restaurant_id, table_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id
+	`
+	var id uint
+	err := r.db.QueryRow(ctx, query,
+		walkIn.Name, walkIn.PartySize, walkIn.Contact, walkIn.Status, walkIn.QuotedWait, walkIn.JoinedAt,
+		nullTime(walkIn.NotifiedAt), nullTime(walkIn.SeatedAt), walkIn.RestaurantID, walkIn.TableID,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("WalkInRepo.CreateWalkIn: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetWalkInByID retrieves a walk-in by its ID.
+func (r *WalkInRepo) GetWalkInByID(ctx context.Context, id uint) (*models.WalkIn, error) {
+	query := "SELECT " + walkInColumns + " FROM walk_ins WHERE id = $1"
+
+	walkIn, err := scanWalkIn(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("WalkInRepo.GetWalkInByID: %w", domain.ErrWalkInNotFound)
+		}
+		return nil, fmt.Errorf("WalkInRepo.GetWalkInByID: %w", err)
+	}
+
+	return walkIn, nil
+}
+
+// GetWalkInQueue retrieves the waiting and notified walk-ins of a restaurant, first come first.
+func (r *WalkInRepo) GetWalkInQueue(ctx context.Context, restaurantID uint) ([]*models.WalkIn, error) {
+	query := "SELECT " + walkInColumns + ` FROM walk_ins
+	WHERE restaurant_id = $1 AND status IN ('waiting', 'notified')
+	ORDER BY id`
+	rows, err := r.db.Query(ctx, query, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("WalkInRepo.GetWalkInQueue: %w", err)
+	}
+	defer rows.Close()
+
+	queue := []*models.WalkIn{}
+	for rows.Next() {
+		walkIn, err := scanWalkIn(rows)
+		if err != nil {
+			return nil, fmt.Errorf("WalkInRepo.GetWalkInQueue: %w", err)
+		}
+		queue = append(queue, walkIn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("WalkInRepo.GetWalkInQueue: %w", err)
+	}
+
+	return queue, nil
+}
+
+// UpdateWalkIn sets the status, the notification and seating times and the table of a walk-in.
+func (r *WalkInRepo) UpdateWalkIn(ctx context.Context, walkIn *models.WalkIn) error {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE walk_ins SET status = $2, notified_at = $3, seated_at = $4, table_id = $5 WHERE id = $1",
+		walkIn.ID, walkIn.Status, nullTime(walkIn.NotifiedAt), nullTime(walkIn.SeatedAt), walkIn.TableID,
+	)
+	if err != nil {
+		return fmt.Errorf("WalkInRepo.UpdateWalkIn: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("WalkInRepo.UpdateWalkIn: %w", domain.ErrWalkInNotFound)
+	}
+
+	return nil
+}
+
+func scanWalkIn(row pgx.Row) (*models.WalkIn, error) {
+	var walkIn models.WalkIn
+	var notifiedAt, seatedAt *time.Time
+	err := row.Scan(
+		&walkIn.ID, &walkIn.Name, &walkIn.PartySize, &walkIn.Contact, &walkIn.Status, &walkIn.QuotedWait, &walkIn.JoinedAt,
+		&notifiedAt, &seatedAt, &walkIn.RestaurantID, &walkIn.TableID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if notifiedAt != nil {
+		walkIn.NotifiedAt = *notifiedAt
+	}
+	if seatedAt != nil {
+		walkIn.SeatedAt = *seatedAt
+	}
+	return &walkIn, nil
+}
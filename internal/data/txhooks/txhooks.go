@@ -0,0 +1,46 @@
+// Package txhooks runs code once the unit of work it was registered in has committed,
+// for side effects that must not be seen before the writes of the unit of work are, like cache invalidations.
+package txhooks
+
+import (
+	"context"
+	"sync"
+)
+
+type hooksKey struct{}
+
+type hooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+// Begin returns the context of a unit of work, the tx manager calls commit once it has committed.
+// Hooks of a unit of work rolled back are dropped with its context.
+func Begin(ctx context.Context) (_ context.Context, commit func()) {
+	h := &hooks{}
+	return context.WithValue(ctx, hooksKey{}, h), h.run
+}
+
+// AfterCommit runs fn once the unit of work of ctx has committed, right away outside units of work
+func AfterCommit(ctx context.Context, fn func()) {
+	h, ok := ctx.Value(hooksKey{}).(*hooks)
+	if !ok {
+		fn()
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fns = append(h.fns, fn)
+}
+
+func (h *hooks) run() {
+	h.mu.Lock()
+	fns := h.fns
+	h.fns = nil
+	h.mu.Unlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
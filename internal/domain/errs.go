@@ -8,7 +8,58 @@ var (
 	ErrUsersNotFound     = errors.New("users not found")
 	ErrUserAlreadyExists = errors.New("user already exists")
 	ErrWrongPassword     = errors.New("wrong password")
+	// ErrUserOwnsRestaurants is returned when a user is deleted before the restaurants they own
+	ErrUserOwnsRestaurants = errors.New("user still owns restaurants")
+
+	// auth errors
+	ErrRegistrationDisabled = errors.New("registration is disabled")
 
 	// restaurant errors
+	ErrRestaurantNotFound = errors.New("restaurant not found")
+	ErrTableNotFound      = errors.New("table not found")
 	ErrTableAlreadyExists = errors.New("table already exists")
+	ErrInvalidRestaurant  = errors.New("invalid restaurant")
+	ErrInvalidTable       = errors.New("invalid table")
+
+	ErrTableCombinationNotFound      = errors.New("table combination not found")
+	ErrTableCombinationAlreadyExists = errors.New("table combination already exists")
+
+	// reservation errors
+	ErrReservationNotFound  = errors.New("reservation not found")
+	ErrTableAlreadyReserved = errors.New("table is already reserved for this time")
+	ErrTableTooSmall        = errors.New("table capacity is less than party size")
+	ErrInvalidReservation   = errors.New("invalid reservation")
+	// ErrNoTableAvailable is returned when no free table of the restaurant can be assigned to the party
+	ErrNoTableAvailable = errors.New("no table is available for the party")
+	// ErrIllegalTransition is returned for a status change the lifecycle doesn't allow from the current status
+	ErrIllegalTransition = errors.New("illegal reservation status transition")
+	// ErrTransitionForbidden is returned when the user may manage the reservation, but not make the transition,
+	// like a guest seating themselves
+	ErrTransitionForbidden = errors.New("transition is not allowed for the user")
+	// ErrReservationClosed is returned when a reservation that is seated or over is changed
+	ErrReservationClosed = errors.New("reservation can't be changed in its status")
+	// ErrPacingExceeded is returned when the restaurant takes no more arrivals in the pacing interval of the reservation
+	ErrPacingExceeded = errors.New("too many guests arrive at this time")
+	// ErrModificationLimit is returned when a guest changes a reservation more times than the change policy allows
+	ErrModificationLimit = errors.New("reservation can't be changed anymore")
+
+	// hold errors
+	ErrHoldNotFound = errors.New("hold not found")
+	ErrHoldExpired  = errors.New("hold has expired")
+
+	// waitlist errors
+	ErrWaitlistEntryNotFound = errors.New("waitlist entry not found")
+	ErrInvalidWaitlistEntry  = errors.New("invalid waitlist entry")
+	// ErrWaitlistEntryClosed is returned when a guest leaves the waitlist after their entry was claimed or expired
+	ErrWaitlistEntryClosed = errors.New("waitlist entry is closed")
+
+	// host stand errors
+	ErrWalkInNotFound = errors.New("walk-in not found")
+	ErrInvalidWalkIn  = errors.New("invalid walk-in")
+	// ErrWalkInClosed is returned when a walk-in that was seated or left the queue is changed
+	ErrWalkInClosed = errors.New("walk-in is no longer in the queue")
+	// ErrTableOccupied is returned when a party is seated at a table another party sits at
+	ErrTableOccupied = errors.New("table is occupied")
+	// ErrHostOnly is returned when a user other than the owner of the restaurant or an admin works its host stand
+	ErrHostOnly = errors.New("only the hosts of the restaurant may do this")
 )
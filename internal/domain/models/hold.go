@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Hold keeps a table free for a guest until ExpiresAt, while they complete their booking.
+// A live hold takes its table like an active reservation.
+type Hold struct {
+	ID uint
+	// Token is the secret the guest converts the hold into a reservation with
+	Token     string
+	PartySize uint
+	StartTime time.Time
+	EndTime   time.Time
+	ExpiresAt time.Time
+	// Buffer is the cleaning time of the booking rules after the seating, the hold takes the table meanwhile
+	// like the reservation it's converted into will
+	Buffer time.Duration
+
+	RestaurantID uint
+	TableID      uint
+	UserID       uint
+}
+
+// Expired reports whether the hold no longer takes its table at now
+func (h *Hold) Expired(now time.Time) bool {
+	return !now.Before(h.ExpiresAt)
+}
+
+// Overlaps reports whether the hold takes the table at any moment of [start, end), its buffer included
+func (h *Hold) Overlaps(start, end time.Time) bool {
+	return h.StartTime.Before(end) && start.Before(h.EndTime.Add(h.Buffer))
+}
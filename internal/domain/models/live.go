@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// LiveEventType is what changed at the restaurant, it names the event in the live stream
+type LiveEventType string
+
+const (
+	// LiveTable events carry a table whose state changed: it was created, edited, occupied or freed
+	LiveTable LiveEventType = "table"
+	// LiveTableRemoved events carry the table that was deleted
+	LiveTableRemoved LiveEventType = "table_removed"
+	// LiveReservation events carry a reservation that was created, changed or moved to another status
+	LiveReservation LiveEventType = "reservation"
+	// LiveWalkIn events carry a walk-in that joined the queue, was notified, seated or left
+	LiveWalkIn LiveEventType = "walk_in"
+)
+
+// LiveEvent is a committed change streamed to the host stand and the owner dashboard of the restaurant.
+// Only the field of its type is set.
+type LiveEvent struct {
+	// ID is set by the hub when the event is published, clients resume after it
+	ID          string
+	Type        LiveEventType
+	Table       *Table
+	Reservation *Reservation
+	WalkIn      *WalkIn
+	OccurredAt  time.Time
+}
@@ -0,0 +1,12 @@
+package models
+
+// OutboxEvent is a reservation event written to the outbox together with the change it describes,
+// waiting to be delivered
+type OutboxEvent struct {
+	ID    uint
+	Event ReservationEvent
+	// TraceParent is the W3C traceparent of the request that caused the event, so its delivery joins the trace
+	TraceParent string
+	// Attempts is the number of failed deliveries so far
+	Attempts int
+}
@@ -0,0 +1,146 @@
+package models
+
+import "time"
+
+type Reservation struct {
+	ID        uint
+	PartySize uint
+	StartTime time.Time
+	EndTime   time.Time
+	Status    ReservationStatus
+
+	RestaurantID uint
+	TableID      uint
+	// JoinedTableIDs are the tables of a combination joined to TableID for a large party, usually none
+	JoinedTableIDs []uint
+	UserID         uint
+
+	// Buffer is the cleanup time after EndTime the tables stay taken for, from the rules of the restaurant
+	Buffer time.Duration
+
+	// PolicyOutcomes record how the change policy of the restaurant applied to every change
+	// and the cancellation of the reservation, oldest first
+	PolicyOutcomes []PolicyOutcome
+}
+
+// Modifications counts the changes the guest made to the reservation
+func (r *Reservation) Modifications() uint {
+	var n uint
+	for _, o := range r.PolicyOutcomes {
+		if o.Action == PolicyModification && o.ActorRole == ActorGuest {
+			n++
+		}
+	}
+	return n
+}
+
+// Fees sums the fees the guest was charged for changing and cancelling the reservation
+func (r *Reservation) Fees() uint {
+	var fees uint
+	for _, o := range r.PolicyOutcomes {
+		fees += o.Fee
+	}
+	return fees
+}
+
+// TableIDs returns all the tables the reservation takes
+func (r *Reservation) TableIDs() []uint {
+	return append([]uint{r.TableID}, r.JoinedTableIDs...)
+}
+
+// Overlaps reports whether the reservation takes the table at any moment of [start, end), its buffer included
+func (r *Reservation) Overlaps(start, end time.Time) bool {
+	return r.StartTime.Before(end) && start.Before(r.EndTime.Add(r.Buffer))
+}
+
+// ReservationStatus is the state of a reservation in its lifecycle,
+// the allowed transitions are enforced by the reservation service
+type ReservationStatus string
+
+const (
+	ReservationStatusPending   ReservationStatus = "pending"
+	ReservationStatusConfirmed ReservationStatus = "confirmed"
+	ReservationStatusSeated    ReservationStatus = "seated"
+	ReservationStatusCompleted ReservationStatus = "completed"
+	ReservationStatusCancelled ReservationStatus = "cancelled"
+	ReservationStatusNoShow    ReservationStatus = "no_show"
+)
+
+// Active reports whether a reservation in the status takes its table, the others are over
+func (s ReservationStatus) Active() bool {
+	return s == ReservationStatusPending || s == ReservationStatusConfirmed || s == ReservationStatusSeated
+}
+
+// Reservation actors are the capacities a transition is made in
+const (
+	ActorGuest = "guest"
+	ActorStaff = "staff"
+)
+
+// ReservationTransition is the audit record of a status change.
+// The creation of a reservation is recorded as a transition from the empty status to pending.
+type ReservationTransition struct {
+	ID            uint
+	ReservationID uint
+	From          ReservationStatus
+	To            ReservationStatus
+	ActorID       uint
+	// ActorRole is ActorGuest or ActorStaff
+	ActorRole string
+	Reason    string
+	At        time.Time
+}
+
+type ReservationEventType string
+
+const (
+	ReservationCreated   ReservationEventType = "created"
+	ReservationChanged   ReservationEventType = "changed"
+	ReservationCancelled ReservationEventType = "cancelled"
+	// WaitlistOffered events are sent to the waiting guest instead of the owner,
+	// their reservation is the seating offered
+	WaitlistOffered ReservationEventType = "waitlist_offered"
+	// WalkInNotified events tell a walk-in their table is ready, their reservation is the table
+	WalkInNotified ReservationEventType = "walk_in_notified"
+)
+
+// PolicyAction is the kind of change the change policy of a restaurant applies to
+type PolicyAction string
+
+const (
+	PolicyModification PolicyAction = "modification"
+	PolicyCancellation PolicyAction = "cancellation"
+)
+
+// PolicyOutcome is how the change policy of the restaurant applied to a change or the cancellation of a reservation
+type PolicyOutcome struct {
+	Action PolicyAction
+	// ActorRole is ActorGuest or ActorStaff, only guests are charged and counted
+	ActorRole string
+	// Fee is what the change cost under the policy, 0 if it was free
+	Fee uint
+	// Reason explains the fee, or why the change was free
+	Reason string
+	At     time.Time
+}
+
+// ChangeQuote is what changing and cancelling a reservation would cost the user now
+type ChangeQuote struct {
+	Policy ChangePolicy
+	// Modification is the outcome of a change now, nil if the reservation can't be changed anymore
+	Modification *PolicyOutcome
+	// Cancellation is the outcome of cancelling now, nil if the reservation can't be cancelled anymore
+	Cancellation *PolicyOutcome
+}
+
+// ReservationEvent describes a change of a reservation that the restaurant owner is notified about
+type ReservationEvent struct {
+	Type        ReservationEventType
+	Reservation Reservation
+	OwnerID     uint
+	OccurredAt  time.Time
+	// Waitlist is the entry offered the seating of a WaitlistOffered event
+	Waitlist *WaitlistEntry
+	// WalkIn is the walk-in told about the table of a WalkInNotified event
+	WalkIn *WalkIn
+}
@@ -1,15 +1,30 @@
 package models
 
+import "time"
+
 type Restaurant struct {
 	ID           uint
 	Name         string
 	Description  string
 	Address      string
 	OpeningHours []OpeningHours
+	// TimeZone is the IANA name of the time zone the opening hours are in, UTC when empty
+	TimeZone        string
+	TableAssignment TableAssignment
+	BookingRules    BookingRules
 
 	OwnerID uint
 }
 
+// Location returns the time zone of the restaurant, UTC when it has none or it's unknown
+func (r *Restaurant) Location() *time.Location {
+	loc, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 type OpeningHours struct {
 	DayOfWeek string
 	OpenTime  string
@@ -17,3 +32,83 @@ type OpeningHours struct {
 
 	RestaurantID uint
 }
+
+// TableAssignment is the strategy the tables of a restaurant are assigned to parties by
+type TableAssignment string
+
+const (
+	// TableAssignmentTightestFit gives the party the smallest table that seats it, keeping the big ones free.
+	// It's the default, also for restaurants without a strategy.
+	TableAssignmentTightestFit TableAssignment = "tightest_fit"
+	// TableAssignmentSpreadLoad gives the party the table with the fewest reservations that day,
+	// so the work is spread over the tables and their staff
+	TableAssignmentSpreadLoad TableAssignment = "spread_load"
+)
+
+// Valid reports whether the strategy is one of the known ones
+func (a TableAssignment) Valid() bool {
+	return a == TableAssignmentTightestFit || a == TableAssignmentSpreadLoad
+}
+
+// BookingRules are the limits a restaurant takes reservations within, zero values don't limit
+type BookingRules struct {
+	// Durations are the default seating durations by party size, sorted by MaxPartySize
+	Durations []SeatingDuration
+	// Buffer is the time a table is cleaned for after a seating, it stays taken meanwhile
+	Buffer time.Duration
+	// MinLeadTime is how long before its start a reservation must be made
+	MinLeadTime time.Duration
+	// MaxDaysAhead is how many days ahead reservations are taken
+	MaxDaysAhead uint
+	// LastSeating is how long before closing the last reservation may start,
+	// checked against the opening hours in the time zone of the restaurant
+	LastSeating  time.Duration
+	MinPartySize uint
+	MaxPartySize uint
+	// MaxCoversPerInterval and MaxPartiesPerInterval limit the guests and the parties
+	// arriving in every PacingInterval, so the kitchen isn't swamped
+	MaxCoversPerInterval  uint
+	MaxPartiesPerInterval uint
+	// ChangePolicy is what guests may change and cancel their reservations for
+	ChangePolicy ChangePolicy
+}
+
+// ChangePolicy is how guests may change and cancel their reservations, zero values don't limit or charge.
+// Fees are in the minor units of the restaurant's currency. Changes made by staff are free and unlimited.
+type ChangePolicy struct {
+	// CancellationCutoff is how long before its start a reservation can be cancelled free of charge,
+	// later cancellations are charged CancellationFee
+	CancellationCutoff time.Duration
+	CancellationFee    uint
+	// ModificationCutoff is how long before its start a reservation can be changed free of charge,
+	// later changes are charged ModificationFee
+	ModificationCutoff time.Duration
+	ModificationFee    uint
+	// MaxModifications is how many times a guest may change a reservation
+	MaxModifications uint
+}
+
+// PacingInterval is the length of the intervals arrivals are paced in, they start on the quarter hours
+const PacingInterval = 15 * time.Minute
+
+// PacingSlot returns the pacing interval the time falls in
+func PacingSlot(t time.Time) (start, end time.Time) {
+	start = t.Truncate(PacingInterval)
+	return start, start.Add(PacingInterval)
+}
+
+// SeatingDuration is how long parties of up to MaxPartySize stay by default
+type SeatingDuration struct {
+	MaxPartySize uint
+	Duration     time.Duration
+}
+
+// Duration returns the default seating duration for the party, false if no rule covers it
+func (r BookingRules) Duration(partySize uint) (time.Duration, bool) {
+	for _, d := range r.Durations {
+		if partySize <= d.MaxPartySize {
+			return d.Duration, true
+		}
+	}
+	return 0, false
+}
@@ -1,10 +1,71 @@
 package models
 
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
 type Table struct {
 	ID          uint
 	Number      uint
 	Capacity    uint
 	IsAvailable bool
+	// MinPartySize is the smallest party seated at the table, 0 or 1 for any party
+	MinPartySize uint
+	// Attributes are the features guests can ask for, like "window" or "accessible", see NormalizeAttributes
+	Attributes []string
+	// OccupiedSince is when the host seated the party at the table, zero while it's free.
+	// IsAvailable takes a table out of service, occupancy is who sits there right now.
+	OccupiedSince time.Time
+	// OccupiedPartySize is the size of the party at the table
+	OccupiedPartySize uint
+	// OccupiedUntil is when the party is expected to have left and the table to be cleaned,
+	// it can't be reserved or held from before then
+	OccupiedUntil time.Time
+
+	RestaurantID uint
+}
+
+// Occupied reports whether a party sits at the table
+func (t *Table) Occupied() bool {
+	return !t.OccupiedSince.IsZero()
+}
+
+// Seats reports whether the party fits the capacity and the minimum occupancy of the table
+func (t *Table) Seats(partySize uint) bool {
+	return partySize <= t.Capacity && partySize >= t.MinPartySize
+}
+
+// HasAttributes reports whether the table has all the attributes
+func (t *Table) HasAttributes(attributes []string) bool {
+	for _, attribute := range attributes {
+		if !slices.Contains(t.Attributes, attribute) {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeAttributes returns the attributes trimmed, lowercased, sorted and without duplicates or empty ones
+func NormalizeAttributes(attributes []string) []string {
+	normalized := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		if attribute = strings.ToLower(strings.TrimSpace(attribute)); attribute != "" {
+			normalized = append(normalized, attribute)
+		}
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// TableCombination is a group of adjacent tables the restaurant can join for a party too large for one of them
+type TableCombination struct {
+	ID uint
+	// TableIDs are the joined tables, sorted
+	TableIDs []uint
+	// Capacity is the number of guests the joined tables seat, usually less than the sum of their capacities
+	Capacity uint
 
 	RestaurantID uint
 }
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// WaitlistEntry is a guest waiting for a table of the restaurant to free up, for a seating starting
+// between WindowStart and WindowEnd. A freed table is offered to the first waiting guest it seats.
+type WaitlistEntry struct {
+	ID          uint
+	PartySize   uint
+	WindowStart time.Time
+	WindowEnd   time.Time
+	// Contact is where the offers are sent, like a phone number or an e-mail address
+	Contact   string
+	Status    WaitlistStatus
+	CreatedAt time.Time
+	// Offer is the last table offered to the guest, nil until one is
+	Offer *WaitlistOffer
+
+	RestaurantID uint
+	UserID       uint
+}
+
+// Takes reports whether a seating starting at start is within the window of the entry
+func (e *WaitlistEntry) Takes(start time.Time) bool {
+	return !start.Before(e.WindowStart) && !start.After(e.WindowEnd)
+}
+
+// WaitlistOffer is a freed table offered to a waiting guest as a hold of it.
+// The guest claims the table by converting the hold before it expires.
+type WaitlistOffer struct {
+	// Token is the token of the hold, the claim link
+	Token     string
+	TableID   uint
+	StartTime time.Time
+	EndTime   time.Time
+	ExpiresAt time.Time
+}
+
+// WaitlistStatus is the stage of a waitlist entry
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting WaitlistStatus = "waiting"
+	WaitlistStatusOffered WaitlistStatus = "offered"
+	WaitlistStatusClaimed WaitlistStatus = "claimed"
+	// WaitlistStatusExpired entries let their offer expire, the table was offered to the next guest
+	WaitlistStatusExpired WaitlistStatus = "expired"
+	WaitlistStatusLeft    WaitlistStatus = "left"
+)
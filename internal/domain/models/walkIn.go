@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// WalkIn is a party without a reservation in the queue at the host stand of the restaurant
+type WalkIn struct {
+	ID        uint
+	Name      string
+	PartySize uint
+	// Contact is where the guest is told their table is ready, optional
+	Contact string
+	Status  WalkInStatus
+	// QuotedWait is the wait the guest was quoted when they joined the queue
+	QuotedWait time.Duration
+	// EstimatedWait is the wait estimated when the queue is read, it isn't stored
+	EstimatedWait time.Duration
+	JoinedAt      time.Time
+	// NotifiedAt is when the guest was told their table is ready, zero until then
+	NotifiedAt time.Time
+	// SeatedAt is when the guest was seated, zero until then
+	SeatedAt time.Time
+
+	RestaurantID uint
+	// TableID is the table the guest was told about or seated at, 0 until then
+	TableID uint
+}
+
+// WalkInStatus is the stage of a walk-in in the queue
+type WalkInStatus string
+
+const (
+	WalkInStatusWaiting  WalkInStatus = "waiting"
+	WalkInStatusNotified WalkInStatus = "notified"
+	WalkInStatusSeated   WalkInStatus = "seated"
+	WalkInStatusLeft     WalkInStatus = "left"
+)
+
+// Queued reports whether a walk-in in the status is still in the queue
+func (s WalkInStatus) Queued() bool {
+	return s == WalkInStatusWaiting || s == WalkInStatusNotified
+}
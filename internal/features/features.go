@@ -0,0 +1,30 @@
+// Package features holds the feature flags, they can be switched while the service runs.
+package features
+
+import "sync/atomic"
+
+// Set is the state of every feature flag
+type Set struct {
+	// Registration allows new users to sign up
+	Registration bool
+}
+
+// Flags is safe for concurrent use
+type Flags struct {
+	set atomic.Pointer[Set]
+}
+
+func New(set Set) *Flags {
+	f := &Flags{}
+	f.Set(set)
+	return f
+}
+
+// Set replaces all flags at once
+func (f *Flags) Set(set Set) {
+	f.set.Store(&set)
+}
+
+func (f *Flags) RegistrationEnabled() bool {
+	return f.set.Load().Registration
+}
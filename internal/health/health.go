@@ -0,0 +1,111 @@
+// Package health serves the liveness and readiness probes.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check reports whether a dependency is usable, it must return once ctx is done
+type Check func(ctx context.Context) error
+
+// Logger records why checks failed, the probe responses only tell which ones did
+type Logger interface {
+	Warn(msg string, args ...any)
+}
+
+type check struct {
+	name     string
+	check    Check
+	required bool
+}
+
+// Health runs the dependency checks for the readiness probe.
+// The service is ready when every required check passes and it isn't shutting down,
+// failing optional checks are only reported.
+type Health struct {
+	timeout      time.Duration
+	checks       []check
+	shuttingDown atomic.Bool
+	log          Logger
+}
+
+// New creates a Health whose checks get timeout to finish
+func New(timeout time.Duration, log Logger) *Health {
+	return &Health{timeout: timeout, log: log}
+}
+
+// AddCheck registers a dependency check, it must be called before the handlers serve requests
+func (h *Health) AddCheck(name string, c Check, required bool) {
+	h.checks = append(h.checks, check{name: name, check: c, required: required})
+}
+
+// SetShuttingDown makes the service not ready, so it's taken out of rotation before the servers stop
+func (h *Health) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+type response struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// LivenessHandler reports that the process is alive, it doesn't check any dependency
+func (h *Health) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, http.StatusOK, response{Status: "ok"})
+	})
+}
+
+// ReadinessHandler runs all checks concurrently and responds 503 if the service can't serve requests.
+// The response has the status of every check, ok or failing, the errors are only logged since the probe may be public.
+func (h *Health) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.shuttingDown.Load() {
+			writeResponse(w, http.StatusServiceUnavailable, response{Status: "shutting down"})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+		defer cancel()
+
+		errs := make([]error, len(h.checks))
+		var wg sync.WaitGroup
+		for i, c := range h.checks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = c.check(ctx)
+			}()
+		}
+		wg.Wait()
+
+		res := response{Status: "ready", Checks: make(map[string]string, len(h.checks))}
+		status := http.StatusOK
+		for i, c := range h.checks {
+			if errs[i] == nil {
+				res.Checks[c.name] = "ok"
+				continue
+			}
+			res.Checks[c.name] = "failing"
+			h.log.Warn("health check failed", "check", c.name, "required", c.required, "err", errs[i].Error())
+			if c.required {
+				res.Status = "not ready"
+				status = http.StatusServiceUnavailable
+			}
+		}
+
+		writeResponse(w, status, res)
+	})
+}
+
+func writeResponse(w http.ResponseWriter, status int, res response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,227 @@
+// Package live fans the committed changes of restaurants out to the live streams of their host stands
+// and owner dashboards.
+//
+// The hub is in-process: a stream only gets the changes made through the same instance of the service.
+// Every event gets an ID "<epoch>-<sequence>", the epoch changes whenever the hub is created, so a client
+// resuming with an ID from another instance or from before a restart is told to reload instead.
+package live
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+// ErrClosed is returned by Subscribe once the hub is shut down
+var ErrClosed = errors.New("live hub is shut down")
+
+type Options struct {
+	// History is the number of recent events kept per restaurant, for clients resuming after a reconnect
+	History int
+	// Buffer is the number of events a subscriber may lag behind, a slower one is dropped and has to resume
+	Buffer int
+}
+
+// Hub keeps the recent events of every restaurant and the subscriptions to them
+type Hub struct {
+	opts  Options
+	epoch string
+
+	mu     sync.Mutex
+	topics map[uint]*topic
+	closed bool
+
+	// subscriptions counts the open subscriptions, Shutdown waits for them to be closed
+	subscriptions sync.WaitGroup
+}
+
+// topic is the stream of one restaurant
+type topic struct {
+	seq uint64
+	// history holds the last events, oldest first
+	history []entry
+	subs    map[*Subscription]struct{}
+}
+
+type entry struct {
+	seq   uint64
+	event models.LiveEvent
+}
+
+func New(opts Options) *Hub {
+	return &Hub{
+		opts:   opts,
+		epoch:  strconv.FormatInt(time.Now().UnixNano(), 36),
+		topics: make(map[uint]*topic),
+	}
+}
+
+// Subscription receives the events of a restaurant published after it was made
+type Subscription struct {
+	hub          *Hub
+	restaurantID uint
+	events       chan models.LiveEvent
+
+	// Backlog holds the events published after the one the client resumed from, to be sent before Events
+	Backlog []models.LiveEvent
+	// Reset is set if the client asked to resume but the events it missed aren't known anymore:
+	// they were published by another instance, before a restart, or fell out of the history.
+	// The client has to reload the state of the restaurant.
+	Reset bool
+	// Position is the ID of the last event published before the subscription, the stream is at it after the backlog
+	Position string
+
+	closeOnce sync.Once
+}
+
+// Events is closed when the subscription is closed, the subscriber fell behind or the hub shuts down
+func (s *Subscription) Events() <-chan models.LiveEvent {
+	return s.events
+}
+
+// Close stops the subscription, it must be called once the subscriber is done, even if Events was closed
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	if t, ok := s.hub.topics[s.restaurantID]; ok {
+		if _, ok := t.subs[s]; ok {
+			delete(t.subs, s)
+			close(s.events)
+		}
+	}
+	s.hub.mu.Unlock()
+
+	s.closeOnce.Do(s.hub.subscriptions.Done)
+}
+
+// Subscribe subscribes to the events of the restaurant. With the ID of the last event the client got,
+// the events published after it are put in the backlog of the subscription.
+func (h *Hub) Subscribe(restaurantID uint, lastEventID string) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrClosed
+	}
+
+	t := h.topic(restaurantID)
+	sub := &Subscription{
+		hub:          h,
+		restaurantID: restaurantID,
+		events:       make(chan models.LiveEvent, h.opts.Buffer),
+		Position:     h.eventID(t.seq),
+	}
+	if lastEventID != "" {
+		sub.Backlog, sub.Reset = t.since(h.epoch, lastEventID)
+	}
+
+	t.subs[sub] = struct{}{}
+	h.subscriptions.Add(1)
+
+	return sub, nil
+}
+
+// Publish assigns the event its ID and sends it to the subscribers of the restaurant.
+// It never blocks: subscribers whose buffer is full are dropped.
+func (h *Hub) Publish(restaurantID uint, event models.LiveEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	t := h.topic(restaurantID)
+	t.seq++
+	event.ID = h.eventID(t.seq)
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	t.history = append(t.history, entry{seq: t.seq, event: event})
+	if len(t.history) > h.opts.History {
+		t.history = t.history[len(t.history)-h.opts.History:]
+	}
+
+	for sub := range t.subs {
+		select {
+		case sub.events <- event:
+		default:
+			// the subscriber resumes from its last event, which is still in the history if it reconnects soon
+			delete(t.subs, sub)
+			close(sub.events)
+		}
+	}
+}
+
+// Shutdown closes the events of every subscription and waits for the subscribers to close them,
+// or until ctx is done. Subscribe fails with ErrClosed after it.
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	h.closed = true
+	for _, t := range h.topics {
+		for sub := range t.subs {
+			delete(t.subs, sub)
+			close(sub.events)
+		}
+	}
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.subscriptions.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (h *Hub) eventID(seq uint64) string {
+	return h.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// topic returns the topic of the restaurant, creating it if needed. h.mu must be held.
+func (h *Hub) topic(restaurantID uint) *topic {
+	t, ok := h.topics[restaurantID]
+	if !ok {
+		t = &topic{subs: make(map[*Subscription]struct{})}
+		h.topics[restaurantID] = t
+	}
+	return t
+}
+
+// since returns the events after the one with the ID, or reset if they can't be told
+func (t *topic) since(epoch, lastEventID string) (_ []models.LiveEvent, reset bool) {
+	eventEpoch, seqStr, ok := strings.Cut(lastEventID, "-")
+	if !ok || eventEpoch != epoch {
+		return nil, true
+	}
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if err != nil || seq > t.seq {
+		return nil, true
+	}
+	if seq == t.seq {
+		return nil, false
+	}
+	// the event right after the last one must still be in the history
+	if len(t.history) == 0 || t.history[0].seq > seq+1 {
+		return nil, true
+	}
+
+	var backlog []models.LiveEvent
+	for _, e := range t.history {
+		if e.seq > seq {
+			backlog = append(backlog, e.event)
+		}
+	}
+	return backlog, false
+}
@@ -0,0 +1,127 @@
+// Package metrics exposes the service metrics in the Prometheus format.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "booking"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	logins       *prometheus.CounterVec
+	reservations *prometheus.CounterVec
+	cache        *prometheus.CounterVec
+	outbox       *prometheus.CounterVec
+}
+
+// New creates the metrics in their own registry, together with the Go runtime and process collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests by route pattern, method and status code.",
+		}, []string{"route", "method", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests by route pattern and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "auth",
+			Name:      "logins_total",
+			Help:      "Number of login attempts by result (success or failure).",
+		}, []string{"result"}),
+		reservations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reservations_total",
+			Help:      "Number of reservation events by type (created, changed, cancelled).",
+		}, []string{"event"}),
+		cache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      "requests_total",
+			Help:      "Number of cache lookups by kind of key and result (hit, miss or error).",
+		}, []string{"kind", "result"}),
+		outbox: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "outbox",
+			Name:      "deliveries_total",
+			Help:      "Number of outbox event deliveries by result (delivered, retried or dead).",
+		}, []string{"result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.logins,
+		m.reservations,
+		m.cache,
+		m.outbox,
+	)
+
+	return m
+}
+
+// Handler serves the metrics for scraping
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// InstrumentHandler counts and times the requests to next.
+// route must be the pattern the handler is registered with (like "GET /user/{id}"),
+// not the request path, to keep the label cardinality bounded.
+func (m *Metrics) InstrumentHandler(route string, next http.Handler) http.Handler {
+	labels := prometheus.Labels{"route": route}
+
+	h := promhttp.InstrumentHandlerDuration(m.httpDuration.MustCurryWith(labels), next)
+	h = promhttp.InstrumentHandlerCounter(m.httpRequests.MustCurryWith(labels), h)
+
+	return h
+}
+
+// ObserveLogin counts a login attempt
+func (m *Metrics) ObserveLogin(success bool) {
+	result := "failure"
+	if success {
+		result = "success"
+	}
+	m.logins.WithLabelValues(result).Inc()
+}
+
+// ObserveReservation counts a reservation event
+func (m *Metrics) ObserveReservation(eventType models.ReservationEventType) {
+	m.reservations.WithLabelValues(string(eventType)).Inc()
+}
+
+// ObserveCache counts a cache lookup
+func (m *Metrics) ObserveCache(kind, result string) {
+	m.cache.WithLabelValues(kind, result).Inc()
+}
+
+// ObserveOutbox counts a delivery of an outbox event
+func (m *Metrics) ObserveOutbox(result string) {
+	m.outbox.WithLabelValues(result).Inc()
+}
+
+// RegisterPool exposes the statistics of the database connection pool
+func (m *Metrics) RegisterPool(pool *pgxpool.Pool) {
+	m.registry.MustRegister(newPoolCollector(pool))
+}
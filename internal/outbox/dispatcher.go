@@ -0,0 +1,178 @@
+// Package outbox delivers the reservation events written to the outbox by the services.
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/kourai55k/booking-service/internal/outbox")
+
+// Store is the outbox the events are leased from
+type Store interface {
+	// LeaseEvents claims up to limit due events for leaseFor, events leased by someone else are skipped
+	LeaseEvents(ctx context.Context, limit int, leaseFor time.Duration) ([]*models.OutboxEvent, error)
+	DeleteEvent(ctx context.Context, id uint) error
+	// RetryEvent releases a failed event until nextAttemptAt
+	RetryEvent(ctx context.Context, id uint, attempts int, nextAttemptAt time.Time, lastError string) error
+	// BuryEvent dead-letters an event, it's kept for inspection but never leased again
+	BuryEvent(ctx context.Context, id uint, attempts int, lastError string) error
+}
+
+// Publisher delivers an event, an error makes the dispatcher retry it later
+type Publisher interface {
+	Publish(ctx context.Context, event models.ReservationEvent) error
+}
+
+// PublisherFunc adapts a function to Publisher
+type PublisherFunc func(ctx context.Context, event models.ReservationEvent) error
+
+func (f PublisherFunc) Publish(ctx context.Context, event models.ReservationEvent) error {
+	return f(ctx, event)
+}
+
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Metrics counts deliveries by result: delivered, retried or dead
+type Metrics interface {
+	ObserveOutbox(result string)
+}
+
+type Options struct {
+	PollInterval    time.Duration
+	BatchSize       int
+	LeaseTimeout    time.Duration
+	MaxAttempts     int
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+}
+
+// Dispatcher polls the outbox and publishes the due events, oldest first.
+// Delivery is at least once: an event is deleted only after it's published, so a crash in between
+// publishes it again once its lease runs out.
+type Dispatcher struct {
+	store     Store
+	publisher Publisher
+	opts      Options
+	metrics   Metrics
+	log       Logger
+}
+
+func New(store Store, publisher Publisher, opts Options, metrics Metrics, log Logger) *Dispatcher {
+	return &Dispatcher{
+		store:     store,
+		publisher: publisher,
+		opts:      opts,
+		metrics:   metrics,
+		log:       log,
+	}
+}
+
+// Run dispatches events until ctx is done. The event being published then is abandoned
+// without counting as an attempt, it and the rest of the batch are leased again after LeaseTimeout.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// a full batch means more events are probably due, so the next one is leased right away
+		leased := d.opts.BatchSize
+		for leased == d.opts.BatchSize && ctx.Err() == nil {
+			leased = d.dispatchBatch(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchBatch leases and publishes one batch, returning the number of leased events
+func (d *Dispatcher) dispatchBatch(ctx context.Context) int {
+	events, err := d.store.LeaseEvents(ctx, d.opts.BatchSize, d.opts.LeaseTimeout)
+	if err != nil {
+		if ctx.Err() == nil {
+			d.log.Error("failed to lease outbox events", "err", err.Error())
+		}
+		return 0
+	}
+
+	for _, event := range events {
+		if ctx.Err() != nil {
+			break
+		}
+		d.dispatch(ctx, event)
+	}
+
+	return len(events)
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, event *models.OutboxEvent) {
+	const op = "outbox.dispatch"
+
+	// the delivery joins the trace of the request that caused the event
+	carrier := propagation.MapCarrier{"traceparent": event.TraceParent}
+	ctx = propagation.TraceContext{}.Extract(ctx, carrier)
+	ctx, span := tracer.Start(ctx, op, trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	err := d.publisher.Publish(ctx, event.Event)
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	// the outcome is recorded even if the dispatcher is stopping meanwhile, or a delivered event would be sent again
+	storeCtx := context.WithoutCancel(ctx)
+
+	if err == nil {
+		if err := d.store.DeleteEvent(storeCtx, event.ID); err != nil {
+			d.log.Error("failed to delete delivered outbox event", "event_id", event.ID, "err", err.Error())
+			span.RecordError(err)
+		}
+		d.metrics.ObserveOutbox("delivered")
+		d.log.Debug("outbox event delivered", "event_id", event.ID, "type", event.Event.Type)
+		return
+	}
+
+	span.RecordError(err)
+	attempts := event.Attempts + 1
+
+	if attempts >= d.opts.MaxAttempts {
+		if err := d.store.BuryEvent(storeCtx, event.ID, attempts, err.Error()); err != nil {
+			d.log.Error("failed to dead-letter outbox event", "event_id", event.ID, "err", err.Error())
+		}
+		d.metrics.ObserveOutbox("dead")
+		d.log.Error("outbox event dead-lettered",
+			"event_id", event.ID, "type", event.Event.Type, "attempts", attempts, "err", err.Error())
+		return
+	}
+
+	nextAttemptAt := time.Now().Add(d.backoff(attempts))
+	if err := d.store.RetryEvent(storeCtx, event.ID, attempts, nextAttemptAt, err.Error()); err != nil {
+		d.log.Error("failed to reschedule outbox event", "event_id", event.ID, "err", err.Error())
+	}
+	d.metrics.ObserveOutbox("retried")
+	d.log.Warn("outbox event delivery failed, will retry",
+		"event_id", event.ID, "type", event.Event.Type, "attempts", attempts, "next_attempt_at", nextAttemptAt,
+		"err", err.Error())
+}
+
+// backoff is RetryBackoff doubled for every failed attempt after the first, up to MaxRetryBackoff
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	backoff := d.opts.RetryBackoff
+	for i := 1; i < attempts && backoff < d.opts.MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, d.opts.MaxRetryBackoff)
+}
@@ -0,0 +1,284 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+func TestBackoff(t *testing.T) {
+	d := New(nil, nil, Options{RetryBackoff: time.Second, MaxRetryBackoff: 10 * time.Second}, nil, nil)
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 3, want: 4 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 5, want: 10 * time.Second},
+		{attempts: 100, want: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d): got %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	opts := Options{BatchSize: 10, LeaseTimeout: time.Minute, MaxAttempts: 3, RetryBackoff: time.Second, MaxRetryBackoff: time.Minute}
+	errPublish := errors.New("notifications down")
+
+	tests := []struct {
+		name     string
+		attempts int // the failed attempts before this one
+		err      error
+		want     storedEvent
+		// backoff is how long after the attempt the retry is due
+		backoff time.Duration
+		result  string
+	}{
+		{name: "delivered", result: "delivered", want: storedEvent{deleted: true}},
+		{
+			name:    "first failure",
+			err:     errPublish,
+			result:  "retried",
+			want:    storedEvent{attempts: 1, lastError: errPublish.Error()},
+			backoff: time.Second,
+		},
+		{
+			name:     "later failure",
+			attempts: 1,
+			err:      errPublish,
+			result:   "retried",
+			want:     storedEvent{attempts: 2, lastError: errPublish.Error()},
+			backoff:  2 * time.Second,
+		},
+		{
+			name:     "last attempt",
+			attempts: 2,
+			err:      errPublish,
+			result:   "dead",
+			want:     storedEvent{attempts: 3, dead: true, lastError: errPublish.Error()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore()
+			id := store.add(tt.attempts)
+			metrics := &testMetrics{}
+			d := New(store, PublisherFunc(func(context.Context, models.ReservationEvent) error { return tt.err }), opts, metrics, testLogger{})
+
+			start := time.Now()
+			if n := d.dispatchBatch(context.Background()); n != 1 {
+				t.Fatalf("dispatchBatch: leased %d events, want 1", n)
+			}
+
+			got := *store.events[id]
+			if tt.backoff != 0 {
+				if due := got.nextAttemptAt.Sub(start); due < tt.backoff || due > tt.backoff+time.Second {
+					t.Errorf("next attempt in %v, want %v", due, tt.backoff)
+				}
+				if !got.lockedUntil.IsZero() {
+					t.Errorf("the retried event is still leased")
+				}
+			}
+			got.nextAttemptAt, got.lockedUntil = time.Time{}, time.Time{}
+			if got != tt.want {
+				t.Errorf("stored event: got %+v, want %+v", got, tt.want)
+			}
+			if want := []string{tt.result}; !slices.Equal(metrics.observed, want) {
+				t.Errorf("metrics: got %v, want %v", metrics.observed, want)
+			}
+		})
+	}
+}
+
+func TestDispatchRetriesUntilDelivered(t *testing.T) {
+	store := newTestStore()
+	id := store.add(0)
+	// fails twice, then delivers
+	published := 0
+	publisher := PublisherFunc(func(context.Context, models.ReservationEvent) error {
+		published++
+		if published <= 2 {
+			return errors.New("notifications down")
+		}
+		return nil
+	})
+	metrics := &testMetrics{}
+	opts := Options{BatchSize: 10, LeaseTimeout: time.Minute, MaxAttempts: 5, RetryBackoff: time.Nanosecond, MaxRetryBackoff: time.Nanosecond}
+	d := New(store, publisher, opts, metrics, testLogger{})
+
+	for range 3 {
+		time.Sleep(time.Millisecond)
+		if n := d.dispatchBatch(context.Background()); n != 1 {
+			t.Fatalf("dispatchBatch: leased %d events, want 1", n)
+		}
+	}
+
+	if !store.events[id].deleted {
+		t.Fatalf("the event wasn't deleted after its delivery")
+	}
+	if want := []string{"retried", "retried", "delivered"}; !slices.Equal(metrics.observed, want) {
+		t.Fatalf("metrics: got %v, want %v", metrics.observed, want)
+	}
+}
+
+func TestRunAbandonsOnShutdown(t *testing.T) {
+	store := newTestStore()
+	first := store.add(0)
+	second := store.add(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	publishing := make(chan struct{})
+	var mu sync.Mutex
+	var published []uint
+	publisher := PublisherFunc(func(ctx context.Context, event models.ReservationEvent) error {
+		mu.Lock()
+		published = append(published, event.Reservation.ID)
+		mu.Unlock()
+		close(publishing)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	metrics := &testMetrics{}
+	opts := Options{PollInterval: time.Hour, BatchSize: 10, LeaseTimeout: time.Minute, MaxAttempts: 3, RetryBackoff: time.Second, MaxRetryBackoff: time.Minute}
+	d := New(store, publisher, opts, metrics, testLogger{})
+
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+	<-publishing
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Run didn't return after its context was cancelled")
+	}
+
+	// the event being published and the rest of the batch stay leased as they were, without an attempt
+	if !slices.Equal(published, []uint{first}) {
+		t.Errorf("published the events of reservations %v, want only %d", published, first)
+	}
+	for _, id := range []uint{first, second} {
+		got := store.events[id]
+		if got.deleted || got.dead || got.attempts != 0 || got.lastError != "" || got.lockedUntil.IsZero() {
+			t.Errorf("event %d: got %+v, want it leased without an attempt", id, *got)
+		}
+	}
+	if len(metrics.observed) != 0 {
+		t.Errorf("metrics: got %v, want none", metrics.observed)
+	}
+}
+
+// storedEvent is the state of an event in testStore
+type storedEvent struct {
+	attempts      int
+	nextAttemptAt time.Time
+	lockedUntil   time.Time
+	lastError     string
+	deleted       bool
+	dead          bool
+}
+
+// testStore is an in-memory Store, its events are ReservationCreated events of the reservation with their ID
+type testStore struct {
+	mu     sync.Mutex
+	events map[uint]*storedEvent
+	nextID uint
+}
+
+func newTestStore() *testStore {
+	return &testStore{events: make(map[uint]*storedEvent), nextID: 1}
+}
+
+// add stores a due event that failed attempts times
+func (s *testStore) add(attempts int) uint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextID
+	s.nextID++
+	s.events[id] = &storedEvent{attempts: attempts}
+	return id
+}
+
+func (s *testStore) LeaseEvents(_ context.Context, limit int, leaseFor time.Duration) ([]*models.OutboxEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var events []*models.OutboxEvent
+	for id, e := range s.events {
+		if e.deleted || e.dead || e.nextAttemptAt.After(now) || e.lockedUntil.After(now) {
+			continue
+		}
+		events = append(events, &models.OutboxEvent{
+			ID:       id,
+			Event:    models.ReservationEvent{Type: models.ReservationCreated, Reservation: models.Reservation{ID: id}},
+			Attempts: e.attempts,
+		})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	for _, event := range events {
+		s.events[event.ID].lockedUntil = now.Add(leaseFor)
+	}
+	return events, nil
+}
+
+func (s *testStore) DeleteEvent(_ context.Context, id uint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events[id].deleted = true
+	return nil
+}
+
+func (s *testStore) RetryEvent(_ context.Context, id uint, attempts int, nextAttemptAt time.Time, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.events[id]
+	e.attempts, e.nextAttemptAt, e.lastError, e.lockedUntil = attempts, nextAttemptAt, lastError, time.Time{}
+	return nil
+}
+
+func (s *testStore) BuryEvent(_ context.Context, id uint, attempts int, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.events[id]
+	e.attempts, e.lastError, e.dead = attempts, lastError, true
+	return nil
+}
+
+type testMetrics struct {
+	mu       sync.Mutex
+	observed []string
+}
+
+func (m *testMetrics) ObserveOutbox(result string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observed = append(m.observed, result)
+}
+
+type testLogger struct{}
+
+func (testLogger) Debug(string, ...interface{}) {}
+func (testLogger) Warn(string, ...interface{})  {}
+func (testLogger) Error(string, ...interface{}) {}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	// policy is the one of the last take, the bucket refills by it
+	policy Policy
+}
+
+// MemoryStore keeps the buckets in process memory. Full buckets are dropped periodically.
+type MemoryStore struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, policy Policy, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cleanup(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(policy.Capacity()), last: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = refill(policy, b.tokens, b.last, now)
+	b.last = now
+	b.policy = policy
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	return newResult(policy, b.tokens, allowed), nil
+}
+
+// cleanup drops the buckets that have been idle long enough to be full again under their own policy,
+// they are indistinguishable from new ones. The caller must hold the lock.
+func (s *MemoryStore) cleanup(now time.Time) {
+	const interval = time.Minute
+
+	if now.Sub(s.lastCleanup) < interval {
+		return
+	}
+	s.lastCleanup = now
+
+	for key, b := range s.buckets {
+		if refill(b.policy, b.tokens, b.last, now) >= float64(b.policy.Capacity()) {
+			delete(s.buckets, key)
+		}
+	}
+}
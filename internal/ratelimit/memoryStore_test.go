@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, func(*testing.T) Store { return NewMemoryStore() })
+}
+
+func TestMemoryStoreCleanup(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		policy Policy
+		idle   time.Duration
+		kept   bool
+	}{
+		{name: "full again", policy: Policy{Limit: 1, Period: time.Second}, idle: 70 * time.Second, kept: false},
+		{name: "still refilling", policy: Policy{Limit: 5, Period: 15 * time.Minute}, idle: 70 * time.Second, kept: true},
+		{name: "refilled after its period", policy: Policy{Limit: 5, Period: 15 * time.Minute}, idle: 16 * time.Minute, kept: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore()
+			for range tt.policy.Capacity() {
+				take(t, ctx, store, "idle", tt.policy, now)
+			}
+
+			// the cleanup runs on takes with other policies too, at most once a minute
+			busy := Policy{Limit: 100, Period: time.Second}
+			take(t, ctx, store, "busy", busy, now.Add(tt.idle))
+
+			if _, ok := store.buckets["idle"]; ok != tt.kept {
+				t.Fatalf("bucket kept = %v, want %v", ok, tt.kept)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Package ratelimit implements token bucket rate limiting with pluggable bucket stores.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Policy describes a token bucket: it holds up to Burst tokens and refills at Limit tokens per Period.
+// Every request takes one token.
+type Policy struct {
+	Limit  int           `yaml:"limit"`
+	Period time.Duration `yaml:"period"`
+	Burst  int           `yaml:"burst"`
+}
+
+// Enabled reports whether the policy limits anything
+func (p Policy) Enabled() bool {
+	return p.Limit > 0 && p.Period > 0
+}
+
+// Capacity is the size of the bucket, Limit if Burst isn't set
+func (p Policy) Capacity() int {
+	if p.Burst > 0 {
+		return p.Burst
+	}
+	return p.Limit
+}
+
+// rate is the refill rate in tokens per second
+func (p Policy) rate() float64 {
+	return float64(p.Limit) / p.Period.Seconds()
+}
+
+// Result is the outcome of taking a token
+type Result struct {
+	Allowed bool
+	// Limit is the bucket capacity
+	Limit int
+	// Remaining is the number of whole tokens left
+	Remaining int
+	// RetryAfter is the time until the next token is available, zero if one is available now
+	RetryAfter time.Duration
+	// ResetAfter is the time until the bucket is full again
+	ResetAfter time.Duration
+}
+
+// Store keeps the buckets. Take must be atomic per key.
+type Store interface {
+	Take(ctx context.Context, key string, policy Policy, now time.Time) (Result, error)
+}
+
+// refill returns the tokens in a bucket that had tokens at last
+func refill(policy Policy, tokens float64, last, now time.Time) float64 {
+	elapsed := now.Sub(last).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return math.Min(float64(policy.Capacity()), tokens+elapsed*policy.rate())
+}
+
+// newResult describes a bucket holding tokens after the take attempt
+func newResult(policy Policy, tokens float64, allowed bool) Result {
+	res := Result{
+		Allowed:   allowed,
+		Limit:     policy.Capacity(),
+		Remaining: int(math.Floor(tokens)),
+	}
+
+	rate := policy.rate()
+	if tokens < 1 {
+		res.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Second))
+	}
+	res.ResetAfter = time.Duration((float64(policy.Capacity()) - tokens) / rate * float64(time.Second))
+
+	return res
+}
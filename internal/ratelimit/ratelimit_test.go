@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// testStore checks the token bucket behaviour every store must have, newStore returns an empty store
+func testStore(t *testing.T, newStore func(t *testing.T) Store) {
+	ctx := context.Background()
+	// 3 tokens, one every 500ms
+	policy := Policy{Limit: 2, Period: time.Second, Burst: 3}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("burst", func(t *testing.T) {
+		store := newStore(t)
+		for i := range policy.Burst {
+			res := take(t, ctx, store, "key", policy, now)
+			want := Result{Allowed: true, Limit: 3, Remaining: 2 - i, ResetAfter: time.Duration(i+1) * 500 * time.Millisecond}
+			if want.Remaining == 0 {
+				// the next take has to wait for a token
+				want.RetryAfter = 500 * time.Millisecond
+			}
+			if res != want {
+				t.Fatalf("take %d: got %+v, want %+v", i+1, res, want)
+			}
+		}
+
+		res := take(t, ctx, store, "key", policy, now)
+		want := Result{Allowed: false, Limit: 3, Remaining: 0, RetryAfter: 500 * time.Millisecond, ResetAfter: 1500 * time.Millisecond}
+		if res != want {
+			t.Fatalf("take over the burst: got %+v, want %+v", res, want)
+		}
+	})
+
+	t.Run("refill", func(t *testing.T) {
+		store := newStore(t)
+		for range policy.Burst + 1 {
+			take(t, ctx, store, "key", policy, now)
+		}
+
+		if res := take(t, ctx, store, "key", policy, now.Add(250*time.Millisecond)); res.Allowed {
+			t.Fatalf("take before a token is refilled: got %+v, want it denied", res)
+		}
+		if res := take(t, ctx, store, "key", policy, now.Add(500*time.Millisecond)); !res.Allowed || res.Remaining != 0 {
+			t.Fatalf("take once a token is refilled: got %+v, want it allowed with none remaining", res)
+		}
+		// the bucket doesn't fill past its capacity
+		if res := take(t, ctx, store, "key", policy, now.Add(time.Hour)); !res.Allowed || res.Remaining != 2 {
+			t.Fatalf("take after a long pause: got %+v, want it allowed with 2 remaining", res)
+		}
+	})
+
+	t.Run("keys", func(t *testing.T) {
+		store := newStore(t)
+		for range policy.Burst {
+			take(t, ctx, store, "key", policy, now)
+		}
+
+		if res := take(t, ctx, store, "other", policy, now); !res.Allowed || res.Remaining != 2 {
+			t.Fatalf("take of another key: got %+v, want it allowed with 2 remaining", res)
+		}
+	})
+
+	t.Run("clock going back", func(t *testing.T) {
+		store := newStore(t)
+		for range policy.Burst {
+			take(t, ctx, store, "key", policy, now)
+		}
+
+		if res := take(t, ctx, store, "key", policy, now.Add(-time.Hour)); res.Allowed {
+			t.Fatalf("take with an earlier time: got %+v, want it denied", res)
+		}
+	})
+}
+
+func take(t *testing.T, ctx context.Context, store Store, key string, policy Policy, now time.Time) Result {
+	t.Helper()
+	res, err := store.Take(ctx, key, policy, now)
+	if err != nil {
+		t.Fatalf("Take: %v", err)
+	}
+	return res
+}
+
+func TestPolicy(t *testing.T) {
+	tests := []struct {
+		policy   Policy
+		enabled  bool
+		capacity int
+	}{
+		{Policy{Limit: 10, Period: time.Minute}, true, 10},
+		{Policy{Limit: 10, Period: time.Minute, Burst: 20}, true, 20},
+		{Policy{Period: time.Minute}, false, 0},
+		{Policy{Limit: 10}, false, 10},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.Enabled(); got != tt.enabled {
+			t.Errorf("%+v: Enabled() = %v, want %v", tt.policy, got, tt.enabled)
+		}
+		if got := tt.policy.Capacity(); got != tt.capacity {
+			t.Errorf("%+v: Capacity() = %d, want %d", tt.policy, got, tt.capacity)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript refills and takes a token from the bucket stored in a hash, atomically.
+// ARGV: refill rate in tokens per millisecond, capacity, now in unix milliseconds.
+// Returns {allowed, tokens left as a string}.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or capacity
+local ts = tonumber(state[2]) or now
+
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity / rate))
+
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore keeps the buckets in Redis (or anything speaking its protocol with Lua scripting),
+// so the limits are shared between instances
+type RedisStore struct {
+	client redis.Scripter
+	prefix string
+}
+
+// NewRedisStore creates a store on top of a Redis client, keys are prefixed with prefix
+func NewRedisStore(client redis.Scripter, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, policy Policy, now time.Time) (Result, error) {
+	const op = "RedisStore.Take"
+
+	ratePerMs := policy.rate() / 1000
+	res, err := takeScript.Run(ctx, s.client, []string{s.prefix + key},
+		strconv.FormatFloat(ratePerMs, 'g', -1, 64), policy.Capacity(), now.UnixMilli(),
+	).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(res) != 2 {
+		return Result{}, fmt.Errorf("%s: unexpected script result %v", op, res)
+	}
+
+	allowed, _ := res[0].(int64)
+	tokensStr, _ := res[1].(string)
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil || math.IsNaN(tokens) {
+		return Result{}, fmt.Errorf("%s: unexpected tokens %q", op, tokensStr)
+	}
+
+	return newResult(policy, tokens, allowed == 1), nil
+}
@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newFakeRedis starts an in-process Redis for the test, closed with it
+func newFakeRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+func TestRedisStore(t *testing.T) {
+	testStore(t, func(t *testing.T) Store {
+		_, client := newFakeRedis(t)
+		return NewRedisStore(client, "ratelimit:")
+	})
+}
+
+func TestRedisStoreKeys(t *testing.T) {
+	ctx := context.Background()
+	// refills in 2s
+	policy := Policy{Limit: 1, Period: time.Second, Burst: 2}
+	server, client := newFakeRedis(t)
+	store := NewRedisStore(client, "ratelimit:")
+
+	take(t, ctx, store, "key", policy, time.Now())
+
+	if !server.Exists("ratelimit:key") {
+		t.Fatalf("bucket isn't stored under the prefixed key, keys: %v", server.Keys())
+	}
+	// a bucket expires once it would be full again
+	if ttl := server.TTL("ratelimit:key"); ttl <= 0 || ttl > 2*time.Second {
+		t.Fatalf("bucket expires in %s, want within the 2s it takes to refill", ttl)
+	}
+	server.FastForward(2 * time.Second)
+	if server.Exists("ratelimit:key") {
+		t.Fatal("full bucket wasn't expired")
+	}
+}
+
+func TestRedisStoreUnavailable(t *testing.T) {
+	server, client := newFakeRedis(t)
+	store := NewRedisStore(client, "ratelimit:")
+	server.Close()
+
+	_, err := store.Take(context.Background(), "key", Policy{Limit: 1, Period: time.Second}, time.Now())
+	if err == nil {
+		t.Fatal("Take without Redis: got no error")
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -11,22 +12,41 @@ import (
 )
 
 type UserServiceInterface interface {
-	GetUserByLogin(login string) (*models.User, error)
-	CreateUser(user *models.User) (uint, error)
+	GetUserByLogin(ctx context.Context, login string) (*models.User, error)
+	CreateUser(ctx context.Context, user *models.User) (uint, error)
+}
+
+// AuthMetrics counts login attempts
+type AuthMetrics interface {
+	ObserveLogin(success bool)
+}
+
+// Features tells which features are switched on
+type Features interface {
+	RegistrationEnabled() bool
 }
 
 type AuthService struct {
 	userService UserServiceInterface
+	metrics     AuthMetrics
+	features    Features
 }
 
-func NewAuthService(userService UserServiceInterface) *AuthService {
-	return &AuthService{userService: userService}
+func NewAuthService(userService UserServiceInterface, metrics AuthMetrics, features Features) *AuthService {
+	return &AuthService{userService: userService, metrics: metrics, features: features}
 }
 
-func (s *AuthService) Register(user *models.User) (uint, error) {
+func (s *AuthService) Register(ctx context.Context, user *models.User) (_ uint, err error) {
 	const op = "AuthService.Register"
 
-	id, err := s.userService.CreateUser(user)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	if !s.features.RegistrationEnabled() {
+		return 0, fmt.Errorf("%s: %w", op, domain.ErrRegistrationDisabled)
+	}
+
+	id, err := s.userService.CreateUser(ctx, user)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
@@ -34,11 +54,18 @@ func (s *AuthService) Register(user *models.User) (uint, error) {
 	return id, nil
 }
 
-func (s *AuthService) Login(login, password string) (string, error) {
+func (s *AuthService) Login(ctx context.Context, login, password string) (token string, err error) {
 	const op = "AuthService.Login"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	defer func() {
+		s.metrics.ObserveLogin(err == nil)
+	}()
+
 	// check if user with provided login exist
-	user, err := s.userService.GetUserByLogin(login)
+	user, err := s.userService.GetUserByLogin(ctx, login)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
 			return "", fmt.Errorf("%s: %w", op, domain.ErrUserNotFound)
@@ -46,14 +73,16 @@ func (s *AuthService) Login(login, password string) (string, error) {
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	// check if password is correct
+	// check if password is correct, bcrypt is slow on purpose so it gets its own span
+	_, hashSpan := tracer.Start(ctx, "hashing.CheckPassword")
 	err = hashing.CheckPassword(user.HashPass, password)
+	hashSpan.End()
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", op, domain.ErrWrongPassword)
 	}
 
 	// generating JWT
-	token, err := jwthelper.GenerateToken(user)
+	token, err = jwthelper.GenerateToken(user)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", op, err)
 	}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+// normalizeBookingRules sorts the seating durations by party size and checks the rules make sense
+func normalizeBookingRules(rules *models.BookingRules) error {
+	rules.Durations = slices.SortedFunc(slices.Values(rules.Durations), func(a, b models.SeatingDuration) int {
+		return cmp.Compare(a.MaxPartySize, b.MaxPartySize)
+	})
+	for i, d := range rules.Durations {
+		if d.MaxPartySize == 0 || d.Duration <= 0 {
+			return fmt.Errorf("%w: a seating duration needs a party size and a positive duration", domain.ErrInvalidRestaurant)
+		}
+		if i > 0 && rules.Durations[i-1].MaxPartySize == d.MaxPartySize {
+			return fmt.Errorf("%w: two seating durations for parties of up to %d", domain.ErrInvalidRestaurant, d.MaxPartySize)
+		}
+	}
+	if rules.Buffer < 0 || rules.MinLeadTime < 0 || rules.LastSeating < 0 {
+		return fmt.Errorf("%w: negative booking rule", domain.ErrInvalidRestaurant)
+	}
+	if rules.MaxPartySize != 0 && rules.MinPartySize > rules.MaxPartySize {
+		return fmt.Errorf("%w: minimum party size is over the maximum", domain.ErrInvalidRestaurant)
+	}
+
+	return checkChangePolicy(rules.ChangePolicy)
+}
+
+// applyBookingRules fills in the default end time and the buffer of the reservation from the rules of the restaurant
+// and checks that the reservation keeps to them
+func applyBookingRules(restaurant *models.Restaurant, reservation *models.Reservation, now time.Time) error {
+	rules := restaurant.BookingRules
+
+	if rules.MinPartySize != 0 && reservation.PartySize < rules.MinPartySize {
+		return fmt.Errorf("%w: the restaurant seats parties of %d or more", domain.ErrInvalidReservation, rules.MinPartySize)
+	}
+	if rules.MaxPartySize != 0 && reservation.PartySize > rules.MaxPartySize {
+		return fmt.Errorf("%w: the restaurant seats parties of %d at most", domain.ErrInvalidReservation, rules.MaxPartySize)
+	}
+
+	if reservation.EndTime.IsZero() {
+		duration, ok := rules.Duration(reservation.PartySize)
+		if !ok {
+			return fmt.Errorf("%w: end time is required", domain.ErrInvalidReservation)
+		}
+		reservation.EndTime = reservation.StartTime.Add(duration)
+	}
+
+	if rules.MinLeadTime != 0 && reservation.StartTime.Sub(now) < rules.MinLeadTime {
+		return fmt.Errorf("%w: reservations are made %s ahead at least", domain.ErrInvalidReservation, rules.MinLeadTime)
+	}
+	if rules.MaxDaysAhead != 0 && reservation.StartTime.After(now.AddDate(0, 0, int(rules.MaxDaysAhead))) {
+		return fmt.Errorf("%w: reservations are taken %d days ahead at most", domain.ErrInvalidReservation, rules.MaxDaysAhead)
+	}
+	if rules.LastSeating != 0 && len(restaurant.OpeningHours) > 0 &&
+		!seatingAllowed(restaurant.OpeningHours, reservation.StartTime.In(restaurant.Location()), rules.LastSeating) {
+		return fmt.Errorf("%w: the restaurant doesn't seat at this time, the last seating is %s before closing",
+			domain.ErrInvalidReservation, rules.LastSeating)
+	}
+
+	reservation.Buffer = rules.Buffer
+
+	return nil
+}
+
+// checkPacing checks that the party of the reservation can arrive in its pacing interval
+// without going over the pacing limits of the rules
+func checkPacing(ctx context.Context, reservations ReservationRepository, rules models.BookingRules, reservation *models.Reservation) error {
+	if rules.MaxCoversPerInterval == 0 && rules.MaxPartiesPerInterval == 0 {
+		return nil
+	}
+
+	start, end := models.PacingSlot(reservation.StartTime)
+	parties, covers, err := reservations.CountArrivals(ctx, reservation.RestaurantID, start, end, reservation.ID)
+	if err != nil {
+		return err
+	}
+	if rules.MaxPartiesPerInterval != 0 && parties+1 > rules.MaxPartiesPerInterval {
+		return fmt.Errorf("%w: the restaurant takes %d parties arriving from %s",
+			domain.ErrPacingExceeded, rules.MaxPartiesPerInterval, start.Format("15:04"))
+	}
+	if rules.MaxCoversPerInterval != 0 && covers+reservation.PartySize > rules.MaxCoversPerInterval {
+		return fmt.Errorf("%w: the restaurant takes %d guests arriving from %s, %d are booked",
+			domain.ErrPacingExceeded, rules.MaxCoversPerInterval, start.Format("15:04"), covers)
+	}
+
+	return nil
+}
+
+// seatingAllowed reports whether the restaurant is open at start, and closes lastSeating after it or later.
+// The hours are taken in the time zone of start, hours closing at or before they open close after midnight.
+func seatingAllowed(hours []models.OpeningHours, start time.Time, lastSeating time.Duration) bool {
+	today := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+
+	// the hours of the day before may run past midnight
+	for _, day := range []time.Time{today, today.AddDate(0, 0, -1)} {
+		for _, oh := range hours {
+			if !strings.EqualFold(oh.DayOfWeek, day.Weekday().String()) {
+				continue
+			}
+			opens, errOpen := atClock(day, oh.OpenTime)
+			closes, errClose := atClock(day, oh.CloseTime)
+			if errOpen != nil || errClose != nil {
+				continue
+			}
+			if !closes.After(opens) {
+				closes = closes.AddDate(0, 0, 1)
+			}
+			if !start.Before(opens) && !start.After(closes.Add(-lastSeating)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// atClock returns the time of the day at the "15:04" clock
+func atClock(day time.Time, clock string) (time.Time, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, day.Location()), nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+// checkChangePolicy checks the change policy makes sense
+func checkChangePolicy(policy models.ChangePolicy) error {
+	if policy.CancellationCutoff < 0 || policy.ModificationCutoff < 0 {
+		return fmt.Errorf("%w: negative change policy cutoff", domain.ErrInvalidRestaurant)
+	}
+	if (policy.CancellationFee != 0 && policy.CancellationCutoff == 0) || (policy.ModificationFee != 0 && policy.ModificationCutoff == 0) {
+		return fmt.Errorf("%w: a change policy fee needs the cutoff it's charged after", domain.ErrInvalidRestaurant)
+	}
+	return nil
+}
+
+// modificationOutcome returns what the actor changing the reservation at now costs under the policy.
+// It fails with domain.ErrModificationLimit if the guest changed the reservation as many times as the policy allows.
+func modificationOutcome(policy models.ChangePolicy, reservation *models.Reservation, actor string, now time.Time) (models.PolicyOutcome, error) {
+	outcome := models.PolicyOutcome{Action: models.PolicyModification, ActorRole: actor, At: now}
+	if actor == models.ActorStaff {
+		outcome.Reason = "changed by the restaurant"
+		return outcome, nil
+	}
+
+	if policy.MaxModifications != 0 && reservation.Modifications() >= policy.MaxModifications {
+		return models.PolicyOutcome{}, fmt.Errorf("%w: the restaurant allows %d changes", domain.ErrModificationLimit, policy.MaxModifications)
+	}
+	outcome.Fee, outcome.Reason = lateFee(policy.ModificationCutoff, policy.ModificationFee, reservation.StartTime, now, "changes")
+
+	return outcome, nil
+}
+
+// changesBooking reports whether updated books other tables, times or a party size than reservation
+func changesBooking(reservation, updated *models.Reservation) bool {
+	return updated.TableID != reservation.TableID ||
+		!slices.Equal(updated.JoinedTableIDs, reservation.JoinedTableIDs) ||
+		updated.PartySize != reservation.PartySize ||
+		!updated.StartTime.Equal(reservation.StartTime) ||
+		!updated.EndTime.Equal(reservation.EndTime)
+}
+
+// cancellationOutcome returns what the actor cancelling the reservation at now costs under the policy
+func cancellationOutcome(policy models.ChangePolicy, reservation *models.Reservation, actor string, now time.Time) models.PolicyOutcome {
+	outcome := models.PolicyOutcome{Action: models.PolicyCancellation, ActorRole: actor, At: now}
+	if actor == models.ActorStaff {
+		outcome.Reason = "cancelled by the restaurant"
+		return outcome
+	}
+
+	outcome.Fee, outcome.Reason = lateFee(policy.CancellationCutoff, policy.CancellationFee, reservation.StartTime, now, "cancellations")
+	return outcome
+}
+
+// lateFee returns the fee of a change made at now to a reservation starting at start, it's charged within cutoff of the start
+func lateFee(cutoff time.Duration, fee uint, start, now time.Time, changes string) (uint, string) {
+	if fee == 0 {
+		return 0, changes + " are free of charge"
+	}
+	if start.Sub(now) >= cutoff {
+		return 0, fmt.Sprintf("%s are free until %s before the start", changes, cutoff)
+	}
+	return fee, fmt.Sprintf("%s within %s of the start are charged", changes, cutoff)
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+// LiveFeed streams the committed changes of restaurants to their host stands and owner dashboards
+type LiveFeed interface {
+	Publish(restaurantID uint, event models.LiveEvent)
+}
+
+// liveBatch collects the live events of a unit of work, they're published once it commits.
+// The unit of work may run its function more than once, so the function resets the batch first.
+type liveBatch struct {
+	events []liveEvent
+}
+
+type liveEvent struct {
+	restaurantID uint
+	event        models.LiveEvent
+}
+
+func (b *liveBatch) reset() {
+	b.events = b.events[:0]
+}
+
+// table adds the new state of the table, it's copied so later changes in the unit of work don't leak into the event
+func (b *liveBatch) table(table *models.Table) {
+	t := *table
+	b.add(t.RestaurantID, models.LiveEvent{Type: models.LiveTable, Table: &t})
+}
+
+func (b *liveBatch) tableRemoved(table *models.Table) {
+	t := *table
+	b.add(t.RestaurantID, models.LiveEvent{Type: models.LiveTableRemoved, Table: &t})
+}
+
+func (b *liveBatch) reservation(reservation *models.Reservation) {
+	r := *reservation
+	b.add(r.RestaurantID, models.LiveEvent{Type: models.LiveReservation, Reservation: &r})
+}
+
+func (b *liveBatch) walkIn(walkIn *models.WalkIn) {
+	w := *walkIn
+	b.add(w.RestaurantID, models.LiveEvent{Type: models.LiveWalkIn, WalkIn: &w})
+}
+
+func (b *liveBatch) add(restaurantID uint, event models.LiveEvent) {
+	event.OccurredAt = time.Now()
+	b.events = append(b.events, liveEvent{restaurantID: restaurantID, event: event})
+}
+
+// publish sends the events to the feed in the order they were added
+func (b *liveBatch) publish(feed LiveFeed) {
+	for _, e := range b.events {
+		feed.Publish(e.restaurantID, e.event)
+	}
+}
+
+// publishReservation publishes the committed state of a reservation changed outside a live batch
+func publishReservation(feed LiveFeed, reservation *models.Reservation) {
+	var live liveBatch
+	live.reservation(reservation)
+	live.publish(feed)
+}
+
+// publishTable publishes the committed state of a table changed outside a live batch
+func publishTable(feed LiveFeed, table *models.Table) {
+	var live liveBatch
+	live.table(table)
+	live.publish(feed)
+}
@@ -0,0 +1,1120 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/assignment"
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+type ReservationRepository interface {
+	// CreateReservation must fail with domain.ErrTableAlreadyReserved
+	// if the table is taken by an active reservation at any moment of the reservation
+	CreateReservation(ctx context.Context, reservation *models.Reservation) (uint, error)
+	GetReservationByID(ctx context.Context, id uint) (*models.Reservation, error)
+	GetReservationsByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.Reservation, error)
+	GetReservationsByUserID(ctx context.Context, userID uint) ([]*models.Reservation, error)
+	// UpdateReservation has the same overlap check as CreateReservation, it doesn't change the status
+	UpdateReservation(ctx context.Context, reservation *models.Reservation) error
+	// UpdateReservationStatus has no overlap check: the lifecycle only leads from active statuses to inactive ones
+	UpdateReservationStatus(ctx context.Context, id uint, status models.ReservationStatus) error
+	// AddPolicyOutcome appends the outcome to the policy outcomes of the reservation,
+	// the other methods leave them as they are
+	AddPolicyOutcome(ctx context.Context, id uint, outcome models.PolicyOutcome) error
+	DeleteReservation(ctx context.Context, id uint) error
+
+	AddReservationTransition(ctx context.Context, transition *models.ReservationTransition) (uint, error)
+	// GetReservationTransitions lists the transitions of a reservation oldest first,
+	// they are deleted together with the reservation
+	GetReservationTransitions(ctx context.Context, reservationID uint) ([]*models.ReservationTransition, error)
+
+	// GetOccupiedTableIDs lists the tables of the restaurant taken by an active reservation or a live hold
+	// at any moment of [start, end)
+	GetOccupiedTableIDs(ctx context.Context, restaurantID uint, start, end time.Time) ([]uint, error)
+	// CountArrivals counts the active reservations of the restaurant other than reservationID starting in [start, end),
+	// and the guests of their parties
+	CountArrivals(ctx context.Context, restaurantID uint, start, end time.Time, reservationID uint) (parties, covers uint, err error)
+}
+
+// HoldRepository stores the short-lived holds of tables.
+// Holds share the overlap check with reservations, so the reservation repositories implement it.
+type HoldRepository interface {
+	// CreateHold must fail with domain.ErrTableAlreadyReserved like CreateReservation,
+	// and CreateReservation must treat a live hold like an active reservation
+	CreateHold(ctx context.Context, hold *models.Hold) (uint, error)
+	// GetHoldByToken returns expired holds too, until they are deleted
+	GetHoldByToken(ctx context.Context, token string) (*models.Hold, error)
+	DeleteHold(ctx context.Context, id uint) error
+	// DeleteExpiredHolds returns the number of deleted holds
+	DeleteExpiredHolds(ctx context.Context) (int, error)
+}
+
+// actors are the capacities a transition may be made in
+type actors int
+
+const (
+	guest actors = 1 << iota
+	staff
+)
+
+type transition struct {
+	from, to models.ReservationStatus
+}
+
+// reservationTransitions is the lifecycle of a reservation: the allowed transitions and who may make them.
+// The guest is the user who made the reservation, the staff are the restaurant owner and admins.
+var reservationTransitions = map[transition]actors{
+	{models.ReservationStatusPending, models.ReservationStatusConfirmed}:   staff,
+	{models.ReservationStatusPending, models.ReservationStatusCancelled}:   guest | staff,
+	{models.ReservationStatusConfirmed, models.ReservationStatusSeated}:    staff,
+	{models.ReservationStatusConfirmed, models.ReservationStatusCancelled}: guest | staff,
+	{models.ReservationStatusConfirmed, models.ReservationStatusNoShow}:    staff,
+	{models.ReservationStatusSeated, models.ReservationStatusCompleted}:    staff,
+}
+
+// ReservationMetrics counts reservation events
+type ReservationMetrics interface {
+	ObserveReservation(eventType models.ReservationEventType)
+}
+
+type ReservationService struct {
+	reservationRepo ReservationRepository
+	holdRepo        HoldRepository
+	tableRepo       TableRepository
+	restaurantRepo  RestaurantRepository
+	uow             UnitOfWork
+	metrics         ReservationMetrics
+	live            LiveFeed
+	holdTTL         time.Duration
+	offerTTL        time.Duration
+}
+
+// NewReservationService creates the service, holds of tables last for holdTTL
+// and offers of freed tables to waitlisted guests for offerTTL
+func NewReservationService(
+	reservationRepo ReservationRepository,
+	holdRepo HoldRepository,
+	tableRepo TableRepository,
+	restaurantRepo RestaurantRepository,
+	uow UnitOfWork,
+	metrics ReservationMetrics,
+	live LiveFeed,
+	holdTTL time.Duration,
+	offerTTL time.Duration,
+) *ReservationService {
+	return &ReservationService{
+		reservationRepo: reservationRepo,
+		holdRepo:        holdRepo,
+		tableRepo:       tableRepo,
+		restaurantRepo:  restaurantRepo,
+		uow:             uow,
+		metrics:         metrics,
+		live:            live,
+		holdTTL:         holdTTL,
+		offerTTL:        offerTTL,
+	}
+}
+
+// CreateReservation creates a pending reservation within the booking rules of the restaurant, without an end time
+// it lasts the default seating duration for the party. Without a table the restaurant assigns one
+// that seats the party and has all the attributes by its strategy, or a combination of tables if none does.
+func (s *ReservationService) CreateReservation(
+	ctx context.Context,
+	reservation *models.Reservation,
+	attributes []string,
+) (_ uint, err error) {
+	const op = "ReservationService.CreateReservation"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	rules, err := s.applyRules(ctx, reservation)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if reservation.TableID == 0 {
+		err = validateTimes(reservation)
+	} else {
+		err = validate(ctx, s.tableRepo, reservation)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	reservation.Status = models.ReservationStatusPending
+
+	// every attempt of the unit of work starts from the request, a retried one mustn't keep the tables of the failed one
+	var created models.Reservation
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		created = *reservation
+		created.JoinedTableIDs = slices.Clone(reservation.JoinedTableIDs)
+		reservation := &created
+
+		if err := checkPacing(ctx, repos.Reservations, rules, reservation); err != nil {
+			return err
+		}
+		// the table is assigned in the transaction of the reservation, so it's still free when it's taken
+		if reservation.TableID == 0 {
+			if err := assignTables(ctx, repos, reservation, attributes); err != nil {
+				return err
+			}
+		}
+
+		id, err := repos.Reservations.CreateReservation(ctx, reservation)
+		if err != nil {
+			return err
+		}
+		reservation.ID = id
+
+		_, err = repos.Reservations.AddReservationTransition(ctx, &models.ReservationTransition{
+			ReservationID: id,
+			To:            models.ReservationStatusPending,
+			ActorID:       reservation.UserID,
+			ActorRole:     models.ActorGuest,
+			At:            time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+
+		return addEvent(ctx, repos, models.ReservationCreated, reservation)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	*reservation = created
+	s.metrics.ObserveReservation(models.ReservationCreated)
+	publishReservation(s.live, reservation)
+
+	return reservation.ID, nil
+}
+
+func (s *ReservationService) GetReservationByID(ctx context.Context, id uint) (_ *models.Reservation, err error) {
+	const op = "ReservationService.GetReservationByID"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	reservation, err := s.reservationRepo.GetReservationByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reservation, nil
+}
+
+func (s *ReservationService) GetReservationsByRestaurantID(ctx context.Context, restaurantID uint) (_ []*models.Reservation, err error) {
+	const op = "ReservationService.GetReservationsByRestaurantID"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	reservations, err := s.reservationRepo.GetReservationsByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reservations, nil
+}
+
+func (s *ReservationService) GetReservationsByUserID(ctx context.Context, userID uint) (_ []*models.Reservation, err error) {
+	const op = "ReservationService.GetReservationsByUserID"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	reservations, err := s.reservationRepo.GetReservationsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reservations, nil
+}
+
+// UpdateReservation changes the tables, time or party size of a pending or confirmed reservation on behalf of the user,
+// within the current booking rules and the change policy of the restaurant. Zero fields are taken from the existing
+// reservation, a new table replaces the joined ones too. Without a new table the reservation keeps its tables
+// if they still seat the party and are free at the new time, or gets tables assigned like a new reservation.
+// Availability is checked again in the unit of work of the change, so the tables can't be taken in between.
+// The policy outcome of the change is recorded on the reservation: guests may be charged for late changes
+// and fail with domain.ErrModificationLimit once they changed it as often as the policy allows.
+// A change to the tables, time and party size the reservation already has is a no-op, it isn't recorded or counted.
+// The tables or time the reservation no longer takes are offered to the waitlist. It returns the changed reservation.
+func (s *ReservationService) UpdateReservation(
+	ctx context.Context,
+	userID uint,
+	role string,
+	reservation *models.Reservation,
+) (_ *models.Reservation, err error) {
+	const op = "ReservationService.UpdateReservation"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	var updated models.Reservation
+	var unchanged bool
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		unchanged = false
+		existing, err := repos.Reservations.GetReservationByID(ctx, reservation.ID)
+		if err != nil {
+			return err
+		}
+		if existing.Status != models.ReservationStatusPending && existing.Status != models.ReservationStatusConfirmed {
+			return fmt.Errorf("%w: it's %s", domain.ErrReservationClosed, existing.Status)
+		}
+		actor, err := actorOf(ctx, repos, userID, role, existing, guest|staff)
+		if err != nil {
+			return err
+		}
+		restaurant, err := repos.Restaurants.GetRestaurantByID(ctx, existing.RestaurantID)
+		if err != nil {
+			return err
+		}
+
+		updated = *existing
+		if reservation.TableID != 0 {
+			updated.TableID, updated.JoinedTableIDs = reservation.TableID, reservation.JoinedTableIDs
+		}
+		if reservation.PartySize != 0 {
+			updated.PartySize = reservation.PartySize
+		}
+		if !reservation.StartTime.IsZero() {
+			// moved without a new end time, the reservation keeps its length
+			updated.StartTime = reservation.StartTime
+			updated.EndTime = reservation.StartTime.Add(existing.EndTime.Sub(existing.StartTime))
+		}
+		if !reservation.EndTime.IsZero() {
+			updated.EndTime = reservation.EndTime
+		}
+		if !changesBooking(existing, &updated) {
+			// nothing to change, so nothing to charge or count against the modification limit
+			unchanged = true
+			return nil
+		}
+
+		now := time.Now()
+		outcome, err := modificationOutcome(restaurant.BookingRules.ChangePolicy, existing, actor, now)
+		if err != nil {
+			return err
+		}
+
+		if err := applyBookingRules(restaurant, &updated, now); err != nil {
+			return err
+		}
+		if err := validateTimes(&updated); err != nil {
+			return err
+		}
+		if err := checkPacing(ctx, repos.Reservations, restaurant.BookingRules, &updated); err != nil {
+			return err
+		}
+		if err := s.moveReservation(ctx, repos, &updated, reservation.TableID == 0); err != nil {
+			return err
+		}
+		if err := recordOutcome(ctx, repos, &updated, outcome); err != nil {
+			return err
+		}
+		if err := addEvent(ctx, repos, models.ReservationChanged, &updated); err != nil {
+			return err
+		}
+		for _, slot := range freedSlots(existing, &updated) {
+			if err := offerFreedTables(ctx, repos, updated.RestaurantID, slot, s.offerTTL); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if unchanged {
+		return &updated, nil
+	}
+	s.metrics.ObserveReservation(models.ReservationChanged)
+	publishReservation(s.live, &updated)
+
+	return &updated, nil
+}
+
+// QuoteChanges returns what changing and cancelling the reservation would cost the user now
+// under the change policy of its restaurant. It fails with domain.ErrTransitionForbidden
+// if the user is neither the guest nor staff of the restaurant.
+func (s *ReservationService) QuoteChanges(ctx context.Context, userID uint, role string, id uint) (_ *models.ChangeQuote, err error) {
+	const op = "ReservationService.QuoteChanges"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	var quote *models.ChangeQuote
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		reservation, err := repos.Reservations.GetReservationByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		actor, err := actorOf(ctx, repos, userID, role, reservation, guest|staff)
+		if err != nil {
+			return err
+		}
+		restaurant, err := repos.Restaurants.GetRestaurantByID(ctx, reservation.RestaurantID)
+		if err != nil {
+			return err
+		}
+
+		policy := restaurant.BookingRules.ChangePolicy
+		quote = &models.ChangeQuote{Policy: policy}
+		if reservation.Status != models.ReservationStatusPending && reservation.Status != models.ReservationStatusConfirmed {
+			return nil
+		}
+		now := time.Now()
+		// the only error is the modification limit, the reservation can still be cancelled then
+		if outcome, err := modificationOutcome(policy, reservation, actor, now); err == nil {
+			quote.Modification = &outcome
+		}
+		cancellation := cancellationOutcome(policy, reservation, actor, now)
+		quote.Cancellation = &cancellation
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return quote, nil
+}
+
+// TransitionReservation moves a reservation to the status on behalf of the user and records the transition.
+// It fails with domain.ErrIllegalTransition if the lifecycle doesn't lead from the current status to the new one,
+// and with domain.ErrTransitionForbidden if the user isn't the guest or staff the transition needs.
+// Cancellations record the outcome of the change policy of the restaurant, are published to the restaurant owner,
+// and the freed tables offered to the waitlist.
+// Seating the party marks its tables occupied at the host stand, completing the reservation frees them.
+func (s *ReservationService) TransitionReservation(
+	ctx context.Context,
+	userID uint,
+	role string,
+	id uint,
+	to models.ReservationStatus,
+	reason string,
+) (err error) {
+	const op = "ReservationService.TransitionReservation"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	var live liveBatch
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		live.reset()
+		reservation, err := repos.Reservations.GetReservationByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		allowed, ok := reservationTransitions[transition{reservation.Status, to}]
+		if !ok {
+			return fmt.Errorf("%w: %s to %s", domain.ErrIllegalTransition, reservation.Status, to)
+		}
+
+		actor, err := actorOf(ctx, repos, userID, role, reservation, allowed)
+		if err != nil {
+			return fmt.Errorf("%w: %s to %s", err, reservation.Status, to)
+		}
+
+		from := reservation.Status
+		if err := repos.Reservations.UpdateReservationStatus(ctx, id, to); err != nil {
+			return err
+		}
+		reservation.Status = to
+
+		_, err = repos.Reservations.AddReservationTransition(ctx, &models.ReservationTransition{
+			ReservationID: id,
+			From:          from,
+			To:            to,
+			ActorID:       userID,
+			ActorRole:     actor,
+			Reason:        reason,
+			At:            time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		if to == models.ReservationStatusCancelled {
+			restaurant, err := repos.Restaurants.GetRestaurantByID(ctx, reservation.RestaurantID)
+			if err != nil {
+				return err
+			}
+			outcome := cancellationOutcome(restaurant.BookingRules.ChangePolicy, reservation, actor, time.Now())
+			if err := recordOutcome(ctx, repos, reservation, outcome); err != nil {
+				return err
+			}
+		}
+		live.reservation(reservation)
+
+		switch to {
+		case models.ReservationStatusSeated:
+			// the party is expected to leave when its reservation ends
+			until := reservation.EndTime.Add(reservation.Buffer)
+			return occupyTables(ctx, repos, &live, reservation.TableIDs(), time.Now(), until, reservation.PartySize)
+		case models.ReservationStatusCompleted:
+			return occupyTables(ctx, repos, &live, reservation.TableIDs(), time.Time{}, time.Time{}, 0)
+		case models.ReservationStatusCancelled:
+			if err := addEvent(ctx, repos, models.ReservationCancelled, reservation); err != nil {
+				return err
+			}
+			return offerFreedTables(ctx, repos, reservation.RestaurantID, freedSlot{
+				tableIDs: reservation.TableIDs(),
+				start:    reservation.StartTime,
+				end:      reservation.EndTime,
+			}, s.offerTTL)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if to == models.ReservationStatusCancelled {
+		s.metrics.ObserveReservation(models.ReservationCancelled)
+	}
+	live.publish(s.live)
+
+	return nil
+}
+
+// cancelForDeletion cancels the reservations of a user or restaurant that's being deleted as staff would, free of
+// charge: the transition and the outcome are recorded and the ReservationCancelled event is written, so the guest and
+// the owner hear of it even though the reservations are deleted along. Reservations that can't be cancelled any more
+// are left as they are. With offer set, the freed tables are offered to the waitlist.
+// It returns the number of reservations cancelled.
+func cancelForDeletion(
+	ctx context.Context,
+	repos Repositories,
+	live *liveBatch,
+	reservations []*models.Reservation,
+	actorID uint,
+	reason string,
+	offer bool,
+	offerTTL time.Duration,
+) (int, error) {
+	cancelled := 0
+	for _, reservation := range reservations {
+		from := reservation.Status
+		if _, ok := reservationTransitions[transition{from, models.ReservationStatusCancelled}]; !ok {
+			continue
+		}
+		if err := repos.Reservations.UpdateReservationStatus(ctx, reservation.ID, models.ReservationStatusCancelled); err != nil {
+			return cancelled, err
+		}
+		reservation.Status = models.ReservationStatusCancelled
+
+		now := time.Now()
+		_, err := repos.Reservations.AddReservationTransition(ctx, &models.ReservationTransition{
+			ReservationID: reservation.ID,
+			From:          from,
+			To:            models.ReservationStatusCancelled,
+			ActorID:       actorID,
+			ActorRole:     models.ActorStaff,
+			Reason:        reason,
+			At:            now,
+		})
+		if err != nil {
+			return cancelled, err
+		}
+		outcome := cancellationOutcome(models.ChangePolicy{}, reservation, models.ActorStaff, now)
+		if err := recordOutcome(ctx, repos, reservation, outcome); err != nil {
+			return cancelled, err
+		}
+		if err := addEvent(ctx, repos, models.ReservationCancelled, reservation); err != nil {
+			return cancelled, err
+		}
+		live.reservation(reservation)
+		cancelled++
+
+		if !offer {
+			continue
+		}
+		err = offerFreedTables(ctx, repos, reservation.RestaurantID, freedSlot{
+			tableIDs: reservation.TableIDs(),
+			start:    reservation.StartTime,
+			end:      reservation.EndTime,
+		}, offerTTL)
+		if err != nil {
+			return cancelled, err
+		}
+	}
+
+	return cancelled, nil
+}
+
+// GetReservationTransitions returns the audit trail of a reservation, oldest first
+func (s *ReservationService) GetReservationTransitions(ctx context.Context, id uint) (_ []*models.ReservationTransition, err error) {
+	const op = "ReservationService.GetReservationTransitions"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	if _, err := s.reservationRepo.GetReservationByID(ctx, id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	transitions, err := s.reservationRepo.GetReservationTransitions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return transitions, nil
+}
+
+// GetAvailableTables returns the tables of the restaurant that seat the party and are free for [start, end)
+// and the buffer after it. A zero end is the default seating duration for the party.
+// No tables are available when the pacing limits of the restaurant take no more arrivals at start.
+func (s *ReservationService) GetAvailableTables(
+	ctx context.Context,
+	restaurantID uint,
+	start, end time.Time,
+	partySize uint,
+) (_ []*models.Table, err error) {
+	const op = "ReservationService.GetAvailableTables"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	end, err = s.searchWindow(ctx, restaurantID, start, end, partySize)
+	if errors.Is(err, domain.ErrPacingExceeded) {
+		return []*models.Table{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	free, err := freeTables(ctx, s.tableRepo, s.reservationRepo, restaurantID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	seating := make([]*models.Table, 0, len(free))
+	for _, table := range free {
+		if table.Seats(partySize) {
+			seating = append(seating, table)
+		}
+	}
+
+	return seating, nil
+}
+
+// GetAvailableCombinations returns the combinations of tables of the restaurant that seat the party
+// and whose tables are all free for [start, end) and the buffer after it, like GetAvailableTables
+func (s *ReservationService) GetAvailableCombinations(
+	ctx context.Context,
+	restaurantID uint,
+	start, end time.Time,
+	partySize uint,
+) (_ []*models.TableCombination, err error) {
+	const op = "ReservationService.GetAvailableCombinations"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	end, err = s.searchWindow(ctx, restaurantID, start, end, partySize)
+	if errors.Is(err, domain.ErrPacingExceeded) {
+		return []*models.TableCombination{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	combinations, err := s.tableRepo.GetTableCombinationsByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(combinations) == 0 {
+		return combinations, nil
+	}
+	free, err := freeTables(ctx, s.tableRepo, s.reservationRepo, restaurantID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	freeByID := make(map[uint]*models.Table, len(free))
+	for _, table := range free {
+		freeByID[table.ID] = table
+	}
+	available := make([]*models.TableCombination, 0, len(combinations))
+	for _, combination := range combinations {
+		if assignment.CombinationEligible(combination, freeByID, assignment.Request{PartySize: partySize}) {
+			available = append(available, combination)
+		}
+	}
+
+	return available, nil
+}
+
+// HoldTable keeps the table free for the guest for the hold TTL, filling in the ID, token and expiry of the hold,
+// and the end time if it's left to the booking rules
+func (s *ReservationService) HoldTable(ctx context.Context, hold *models.Hold) (err error) {
+	const op = "ReservationService.HoldTable"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	reservation := &models.Reservation{
+		PartySize:    hold.PartySize,
+		StartTime:    hold.StartTime,
+		EndTime:      hold.EndTime,
+		RestaurantID: hold.RestaurantID,
+		TableID:      hold.TableID,
+	}
+	rules, err := s.applyRules(ctx, reservation)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := validate(ctx, s.tableRepo, reservation); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	// held tables don't count against pacing, their conversion is checked again
+	if err := checkPacing(ctx, s.reservationRepo, rules, reservation); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	hold.EndTime = reservation.EndTime
+	hold.Buffer = reservation.Buffer
+
+	token, err := newHoldToken()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	hold.Token = token
+	hold.ExpiresAt = time.Now().Add(s.holdTTL)
+
+	id, err := s.holdRepo.CreateHold(ctx, hold)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	hold.ID = id
+
+	return nil
+}
+
+// ConvertHold turns the user's live hold into a confirmed reservation and returns its ID.
+// Holds of other users are reported as not found. Converting a hold offered from the waitlist claims the offer.
+func (s *ReservationService) ConvertHold(ctx context.Context, userID uint, token string) (_ uint, err error) {
+	const op = "ReservationService.ConvertHold"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	var reservation *models.Reservation
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		hold, err := repos.Holds.GetHoldByToken(ctx, token)
+		if err != nil {
+			return err
+		}
+		if hold.UserID != userID {
+			return domain.ErrHoldNotFound
+		}
+		if hold.Expired(time.Now()) {
+			return domain.ErrHoldExpired
+		}
+
+		// the hold goes first, or it would take the table from its own reservation
+		if err := repos.Holds.DeleteHold(ctx, hold.ID); err != nil {
+			return err
+		}
+		if err := claimOffer(ctx, repos, token); err != nil {
+			return err
+		}
+
+		reservation = &models.Reservation{
+			PartySize:    hold.PartySize,
+			StartTime:    hold.StartTime,
+			EndTime:      hold.EndTime,
+			Status:       models.ReservationStatusConfirmed,
+			RestaurantID: hold.RestaurantID,
+			TableID:      hold.TableID,
+			UserID:       hold.UserID,
+			// the table was held for the same time, buffer included
+			Buffer: hold.Buffer,
+		}
+		if err := validate(ctx, repos.Tables, reservation); err != nil {
+			return err
+		}
+		// the rules were checked when the table was held, only pacing is checked again
+		restaurant, err := repos.Restaurants.GetRestaurantByID(ctx, hold.RestaurantID)
+		if err != nil {
+			return err
+		}
+		if err := checkPacing(ctx, repos.Reservations, restaurant.BookingRules, reservation); err != nil {
+			return err
+		}
+
+		id, err := repos.Reservations.CreateReservation(ctx, reservation)
+		if err != nil {
+			return err
+		}
+		reservation.ID = id
+
+		_, err = repos.Reservations.AddReservationTransition(ctx, &models.ReservationTransition{
+			ReservationID: id,
+			To:            models.ReservationStatusConfirmed,
+			ActorID:       userID,
+			ActorRole:     models.ActorGuest,
+			Reason:        "hold converted",
+			At:            time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+
+		return addEvent(ctx, repos, models.ReservationCreated, reservation)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	s.metrics.ObserveReservation(models.ReservationCreated)
+	publishReservation(s.live, reservation)
+
+	return reservation.ID, nil
+}
+
+// ReleaseHold deletes the user's hold before it expires, holds of other users are reported as not found
+func (s *ReservationService) ReleaseHold(ctx context.Context, userID uint, token string) (err error) {
+	const op = "ReservationService.ReleaseHold"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	hold, err := s.holdRepo.GetHoldByToken(ctx, token)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if hold.UserID != userID {
+		return fmt.Errorf("%s: %w", op, domain.ErrHoldNotFound)
+	}
+
+	if err := s.holdRepo.DeleteHold(ctx, hold.ID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ExpireHolds deletes the expired holds and returns how many there were.
+// Expired holds don't take their table anyway, this only cleans them up.
+func (s *ReservationService) ExpireHolds(ctx context.Context) (_ int, err error) {
+	const op = "ReservationService.ExpireHolds"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	deleted, err := s.holdRepo.DeleteExpiredHolds(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deleted, nil
+}
+
+// CanManageReservation reports whether the user may change or cancel the reservation:
+// admins, the guest who made it and the owner of the restaurant can.
+func (s *ReservationService) CanManageReservation(ctx context.Context, userID uint, role string, reservationID uint) (_ bool, err error) {
+	const op = "ReservationService.CanManageReservation"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	reservation, err := s.reservationRepo.GetReservationByID(ctx, reservationID)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if role == "admin" || reservation.UserID == userID {
+		return true, nil
+	}
+
+	restaurant, err := s.restaurantRepo.GetRestaurantByID(ctx, reservation.RestaurantID)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return restaurant.OwnerID == userID, nil
+}
+
+// applyRules applies the booking rules of the restaurant of the reservation, see applyBookingRules,
+// and returns them for the pacing check
+func (s *ReservationService) applyRules(ctx context.Context, reservation *models.Reservation) (models.BookingRules, error) {
+	if reservation.PartySize == 0 || reservation.RestaurantID == 0 {
+		return models.BookingRules{}, fmt.Errorf("%w: missing required fields", domain.ErrInvalidReservation)
+	}
+	restaurant, err := s.restaurantRepo.GetRestaurantByID(ctx, reservation.RestaurantID)
+	if err != nil {
+		return models.BookingRules{}, err
+	}
+
+	return restaurant.BookingRules, applyBookingRules(restaurant, reservation, time.Now())
+}
+
+// searchWindow checks an availability search against the booking rules and the pacing limits of the restaurant
+// and returns the end of the time the tables must be free for, the default seating duration and the buffer included
+func (s *ReservationService) searchWindow(
+	ctx context.Context,
+	restaurantID uint,
+	start, end time.Time,
+	partySize uint,
+) (time.Time, error) {
+	if partySize == 0 || start.IsZero() || (!end.IsZero() && !end.After(start)) {
+		return time.Time{}, fmt.Errorf("%w: party size and a time range are required", domain.ErrInvalidReservation)
+	}
+
+	probe := &models.Reservation{PartySize: partySize, StartTime: start, EndTime: end, RestaurantID: restaurantID}
+	rules, err := s.applyRules(ctx, probe)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := checkPacing(ctx, s.reservationRepo, rules, probe); err != nil {
+		return time.Time{}, err
+	}
+
+	return probe.EndTime.Add(probe.Buffer), nil
+}
+
+// validate checks the reservation against its table, read from tables: the repository of the unit of work inside one.
+// Overlapping reservations are checked by the repository atomically.
+func validate(ctx context.Context, tables TableRepository, reservation *models.Reservation) error {
+	if reservation.TableID == 0 {
+		return fmt.Errorf("%w: missing required fields", domain.ErrInvalidReservation)
+	}
+	if err := validateTimes(reservation); err != nil {
+		return err
+	}
+	if len(reservation.JoinedTableIDs) > 0 {
+		return validateCombination(ctx, tables, reservation)
+	}
+
+	table, err := tables.GetTableByID(ctx, reservation.TableID)
+	if err != nil {
+		return err
+	}
+	if table.RestaurantID != reservation.RestaurantID {
+		return fmt.Errorf("%w: table doesn't belong to the restaurant", domain.ErrInvalidReservation)
+	}
+	if table.Capacity < reservation.PartySize {
+		return domain.ErrTableTooSmall
+	}
+	if table.MinPartySize > reservation.PartySize {
+		return fmt.Errorf("%w: the table seats parties of %d or more", domain.ErrInvalidReservation, table.MinPartySize)
+	}
+
+	return checkSeatedParty(table, reservation.StartTime)
+}
+
+// validateCombination checks that the tables of the reservation are a combination of its restaurant that seats the party
+func validateCombination(ctx context.Context, tables TableRepository, reservation *models.Reservation) error {
+	combinations, err := tables.GetTableCombinationsByRestaurantID(ctx, reservation.RestaurantID)
+	if err != nil {
+		return err
+	}
+
+	tableIDs := reservation.TableIDs()
+	for _, combination := range combinations {
+		if !slices.Equal(combination.TableIDs, tableIDs) {
+			continue
+		}
+		if combination.Capacity < reservation.PartySize {
+			return domain.ErrTableTooSmall
+		}
+		for _, id := range tableIDs {
+			table, err := tables.GetTableByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			if err := checkSeatedParty(table, reservation.StartTime); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: the tables can't be joined", domain.ErrInvalidReservation)
+}
+
+// checkSeatedParty checks that no party seated at the table is expected to still be there at start,
+// the repository checks it again atomically
+func checkSeatedParty(table *models.Table, start time.Time) error {
+	if expectedFree(table, time.Now()).After(start) {
+		return fmt.Errorf("%w: a party seated at the table stays past the start", domain.ErrTableAlreadyReserved)
+	}
+	return nil
+}
+
+// moveReservation saves the changed reservation, the repository checks its tables are free atomically.
+// With reassign set, a reservation whose tables no longer seat the party or are taken at the new time
+// gets free tables assigned like a new reservation instead.
+func (s *ReservationService) moveReservation(ctx context.Context, repos Repositories, reservation *models.Reservation, reassign bool) error {
+	err := validate(ctx, repos.Tables, reservation)
+	if err == nil {
+		err = repos.Reservations.UpdateReservation(ctx, reservation)
+	}
+	if err == nil || !reassign {
+		return err
+	}
+	// the times were checked before, so these are about the tables
+	if !errors.Is(err, domain.ErrTableTooSmall) && !errors.Is(err, domain.ErrTableAlreadyReserved) &&
+		!errors.Is(err, domain.ErrInvalidReservation) {
+		return err
+	}
+
+	reservation.TableID, reservation.JoinedTableIDs = 0, nil
+	if err := assignTables(ctx, repos, reservation, nil); err != nil {
+		return err
+	}
+	return repos.Reservations.UpdateReservation(ctx, reservation)
+}
+
+// validateTimes checks the fields of the reservation that don't depend on its table
+func validateTimes(reservation *models.Reservation) error {
+	if reservation.PartySize == 0 || reservation.RestaurantID == 0 {
+		return fmt.Errorf("%w: missing required fields", domain.ErrInvalidReservation)
+	}
+	if !reservation.EndTime.After(reservation.StartTime) {
+		return fmt.Errorf("%w: end time must be after start time", domain.ErrInvalidReservation)
+	}
+	if reservation.StartTime.Before(time.Now()) {
+		return fmt.Errorf("%w: start time is in the past", domain.ErrInvalidReservation)
+	}
+
+	return nil
+}
+
+// freeTables returns the tables of the restaurant free for [start, end): available, not taken
+// by an active reservation or a live hold, and without a party expected to still sit there at start
+func freeTables(
+	ctx context.Context,
+	tableRepo TableRepository,
+	reservationRepo ReservationRepository,
+	restaurantID uint,
+	start, end time.Time,
+) ([]*models.Table, error) {
+	tables, err := tableRepo.GetAvailableTablesByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	occupiedIDs, err := reservationRepo.GetOccupiedTableIDs(ctx, restaurantID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	occupied := make(map[uint]bool, len(occupiedIDs))
+	for _, id := range occupiedIDs {
+		occupied[id] = true
+	}
+
+	now := time.Now()
+	free := make([]*models.Table, 0, len(tables))
+	for _, table := range tables {
+		if !occupied[table.ID] && !start.Before(expectedFree(table, now)) {
+			free = append(free, table)
+		}
+	}
+
+	return free, nil
+}
+
+// assignTables picks the free table for the reservation by the strategy of its restaurant,
+// or a combination of free tables if no table seats the party
+func assignTables(ctx context.Context, repos Repositories, reservation *models.Reservation, attributes []string) error {
+	restaurant, err := repos.Restaurants.GetRestaurantByID(ctx, reservation.RestaurantID)
+	if err != nil {
+		return err
+	}
+	free, err := freeTables(
+		ctx, repos.Tables, repos.Reservations, reservation.RestaurantID,
+		reservation.StartTime, reservation.EndTime.Add(reservation.Buffer),
+	)
+	if err != nil {
+		return err
+	}
+
+	var load map[uint]int
+	if restaurant.TableAssignment == models.TableAssignmentSpreadLoad {
+		reservations, err := repos.Reservations.GetReservationsByRestaurantID(ctx, reservation.RestaurantID)
+		if err != nil {
+			return err
+		}
+		load = assignment.DayLoad(reservations, reservation.StartTime)
+	}
+
+	req := assignment.Request{PartySize: reservation.PartySize, Attributes: models.NormalizeAttributes(attributes)}
+	if table := assignment.Choose(free, req, restaurant.TableAssignment, load); table != nil {
+		reservation.TableID = table.ID
+		return nil
+	}
+
+	combinations, err := repos.Tables.GetTableCombinationsByRestaurantID(ctx, reservation.RestaurantID)
+	if err != nil {
+		return err
+	}
+	combination := assignment.ChooseCombination(combinations, free, req, restaurant.TableAssignment, load)
+	if combination == nil {
+		return domain.ErrNoTableAvailable
+	}
+	reservation.TableID = combination.TableIDs[0]
+	reservation.JoinedTableIDs = slices.Clone(combination.TableIDs[1:])
+
+	return nil
+}
+
+// actorOf returns the capacity the user makes a transition in, the guest one if the user may act as both.
+// The owner is only looked up if the guest can't make the transition.
+func actorOf(ctx context.Context, repos Repositories, userID uint, role string, reservation *models.Reservation, allowed actors) (string, error) {
+	if allowed&guest != 0 && reservation.UserID == userID {
+		return models.ActorGuest, nil
+	}
+	if allowed&staff != 0 {
+		if role == "admin" {
+			return models.ActorStaff, nil
+		}
+		restaurant, err := repos.Restaurants.GetRestaurantByID(ctx, reservation.RestaurantID)
+		if err != nil {
+			return "", err
+		}
+		if restaurant.OwnerID == userID {
+			return models.ActorStaff, nil
+		}
+	}
+
+	return "", domain.ErrTransitionForbidden
+}
+
+// newHoldToken returns a random URL-safe token
+func newHoldToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating hold token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// recordOutcome records the policy outcome of a change on the reservation
+func recordOutcome(ctx context.Context, repos Repositories, reservation *models.Reservation, outcome models.PolicyOutcome) error {
+	if err := repos.Reservations.AddPolicyOutcome(ctx, reservation.ID, outcome); err != nil {
+		return err
+	}
+	reservation.PolicyOutcomes = append(slices.Clip(reservation.PolicyOutcomes), outcome)
+	return nil
+}
+
+// addEvent writes the event of a reservation change to the outbox of the unit of work,
+// the restaurant owner is looked up so the dispatcher doesn't have to
+func addEvent(ctx context.Context, repos Repositories, eventType models.ReservationEventType, reservation *models.Reservation) error {
+	restaurant, err := repos.Restaurants.GetRestaurantByID(ctx, reservation.RestaurantID)
+	if err != nil {
+		return err
+	}
+
+	return repos.Outbox.AddEvent(ctx, models.ReservationEvent{
+		Type:        eventType,
+		Reservation: *reservation,
+		OwnerID:     restaurant.OwnerID,
+		OccurredAt:  time.Now(),
+	})
+}
@@ -1,49 +1,104 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"time"
 
+	"github.com/kourai55k/booking-service/internal/domain"
 	"github.com/kourai55k/booking-service/internal/domain/models"
 )
 
 type TableRepository interface {
-	CreateTable(*models.Table) (uint, error)
-	GetTablesByRestaurantID(uint) ([]*models.Table, error)
-	GetAvailableTablesByRestaurantID(uint) ([]*models.Table, error)
-	GetTableByID(uint) (*models.Table, error)
-	UpdateTable(*models.Table) error
-	DeleteTable(uint) error
+	CreateTable(ctx context.Context, table *models.Table) (uint, error)
+	GetTablesByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.Table, error)
+	GetAvailableTablesByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.Table, error)
+	GetTableByID(ctx context.Context, id uint) (*models.Table, error)
+	// UpdateTable leaves a zero number, capacity and minimum party size and nil attributes as they are,
+	// the availability is always set: callers keeping it pass the stored one
+	UpdateTable(ctx context.Context, table *models.Table) error
+	// SetTableOccupancy seats a party of partySize at the table since the time, expected to leave it free at until.
+	// A zero since frees it.
+	SetTableOccupancy(ctx context.Context, id uint, since, until time.Time, partySize uint) error
+	DeleteTable(ctx context.Context, id uint) error
+
+	// CreateTableCombination must fail with domain.ErrTableCombinationAlreadyExists
+	// for the sorted tables of an existing combination of the restaurant
+	CreateTableCombination(ctx context.Context, combination *models.TableCombination) (uint, error)
+	GetTableCombinationsByRestaurantID(ctx context.Context, restaurantID uint) ([]*models.TableCombination, error)
+	DeleteTableCombination(ctx context.Context, id uint) error
 }
 
 type RestaurantRepository interface {
-	CreateRestaurant(*models.Restaurant) (uint, error)
-	GetRestaurants() ([]*models.Restaurant, error)
-	GetRestaurantByID(uint) (*models.Restaurant, error)
-	UpdateRestraunt(*models.Restaurant) error
-	DeleteRestraunt(uint) error
+	CreateRestaurant(ctx context.Context, restaurant *models.Restaurant) (uint, error)
+	GetRestaurants(ctx context.Context) ([]*models.Restaurant, error)
+	GetRestaurantByID(ctx context.Context, id uint) (*models.Restaurant, error)
+	// UpdateRestraunt leaves empty fields as they are and replaces the opening hours when given
+	UpdateRestraunt(ctx context.Context, restaurant *models.Restaurant) error
+	// UpdateBookingRules replaces the booking rules of the restaurant
+	UpdateBookingRules(ctx context.Context, restaurantID uint, rules models.BookingRules) error
+	DeleteRestraunt(ctx context.Context, id uint) error
 }
 
 type RestaurantService struct {
 	tableRepo      TableRepository
 	restaurantRepo RestaurantRepository
+	uow            UnitOfWork
+	metrics        ReservationMetrics
+	live           LiveFeed
+	offerTTL       time.Duration
+}
+
+// NewRestaurantService creates the service, the tables freed by reservations cancelled along with a deleted table
+// are offered to waitlisted guests for offerTTL
+func NewRestaurantService(
+	tableRepo TableRepository,
+	restaurantRepo RestaurantRepository,
+	uow UnitOfWork,
+	metrics ReservationMetrics,
+	live LiveFeed,
+	offerTTL time.Duration,
+) *RestaurantService {
+	return &RestaurantService{
+		tableRepo:      tableRepo,
+		restaurantRepo: restaurantRepo,
+		uow:            uow,
+		metrics:        metrics,
+		live:           live,
+		offerTTL:       offerTTL,
+	}
 }
 
 // Tables management
-func (s *RestaurantService) CreateTable(table *models.Table) (uint, error) {
+func (s *RestaurantService) CreateTable(ctx context.Context, table *models.Table) (_ uint, err error) {
 	const op = "RestaurantService.CreateTable"
 
-	id, err := s.tableRepo.CreateTable(table)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	if table.MinPartySize > table.Capacity {
+		return 0, fmt.Errorf("%s: %w: minimum party size is over the capacity", op, domain.ErrInvalidTable)
+	}
+	table.Attributes = models.NormalizeAttributes(table.Attributes)
+
+	id, err := s.tableRepo.CreateTable(ctx, table)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
+	table.ID = id
+	publishTable(s.live, table)
 
 	return id, nil
 }
 
-func (s *RestaurantService) GetTablesByRestaurantID(restaurantID uint) ([]*models.Table, error) {
+func (s *RestaurantService) GetTablesByRestaurantID(ctx context.Context, restaurantID uint) (_ []*models.Table, err error) {
 	const op = "RestaurantService.GetTablesByRestaurantID"
 
-	tables, err := s.tableRepo.GetTablesByRestaurantID(restaurantID)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	tables, err := s.tableRepo.GetTablesByRestaurantID(ctx, restaurantID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -51,10 +106,13 @@ func (s *RestaurantService) GetTablesByRestaurantID(restaurantID uint) ([]*model
 	return tables, nil
 }
 
-func (s *RestaurantService) GetAvailableTablesByRestaurantID(restaurantID uint) ([]*models.Table, error) {
+func (s *RestaurantService) GetAvailableTablesByRestaurantID(ctx context.Context, restaurantID uint) (_ []*models.Table, err error) {
 	const op = "RestaurantService.GetAvailableTablesByRestaurantID"
 
-	tables, err := s.tableRepo.GetAvailableTablesByRestaurantID(restaurantID)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	tables, err := s.tableRepo.GetAvailableTablesByRestaurantID(ctx, restaurantID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -62,10 +120,13 @@ func (s *RestaurantService) GetAvailableTablesByRestaurantID(restaurantID uint)
 	return tables, nil
 }
 
-func (s *RestaurantService) GetTableByID(id uint) (*models.Table, error) {
+func (s *RestaurantService) GetTableByID(ctx context.Context, id uint) (_ *models.Table, err error) {
 	const op = "RestaurantService.GetTableByID"
 
-	table, err := s.tableRepo.GetTableByID(id)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	table, err := s.tableRepo.GetTableByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -73,33 +134,240 @@ func (s *RestaurantService) GetTableByID(id uint) (*models.Table, error) {
 	return table, nil
 }
 
-func (s *RestaurantService) UpdateTable(table *models.Table) error {
-	const op = "RestaurantService.UpdateUser"
+// UpdateTable changes the table, zero fields and nil attributes keep their value.
+// A nil isAvailable keeps the availability of the table too.
+func (s *RestaurantService) UpdateTable(ctx context.Context, table *models.Table, isAvailable *bool) (err error) {
+	const op = "RestaurantService.UpdateTable"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
 
-	err := s.tableRepo.UpdateTable(table)
+	if table.Attributes != nil {
+		table.Attributes = models.NormalizeAttributes(table.Attributes)
+	}
+
+	var updated *models.Table
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		existing, err := repos.Tables.GetTableByID(ctx, table.ID)
+		if err != nil {
+			return err
+		}
+
+		change := *table
+		if change.MinPartySize != 0 {
+			capacity := change.Capacity
+			if capacity == 0 {
+				capacity = existing.Capacity
+			}
+			if change.MinPartySize > capacity {
+				return fmt.Errorf("%w: minimum party size is over the capacity", domain.ErrInvalidTable)
+			}
+		}
+		change.IsAvailable = existing.IsAvailable
+		if isAvailable != nil {
+			change.IsAvailable = *isAvailable
+		}
+
+		if err := repos.Tables.UpdateTable(ctx, &change); err != nil {
+			return err
+		}
+		// the update leaves some fields as they are, so the whole table is read back for the live stream
+		updated, err = repos.Tables.GetTableByID(ctx, table.ID)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	publishTable(s.live, updated)
 
 	return nil
 }
 
-func (s *RestaurantService) DeleteTable(id uint) error {
-	const op = "RestaurantService.UpdateUser"
+func (s *RestaurantService) DeleteTable(ctx context.Context, id uint) (err error) {
+	const op = "RestaurantService.DeleteTable"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	// the combinations of the table can't be joined without it. The reservations of the table go with it, directly
+	// or through a join, like the ones of a deleted restaurant: the upcoming ones are cancelled by the owner first
+	// so their guests hear of it, and the other tables they joined are offered to the waitlist
+	var live liveBatch
+	cancelled := 0
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		live.reset()
+		table, err := repos.Tables.GetTableByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		restaurant, err := repos.Restaurants.GetRestaurantByID(ctx, table.RestaurantID)
+		if err != nil {
+			return err
+		}
+		all, err := repos.Reservations.GetReservationsByRestaurantID(ctx, table.RestaurantID)
+		if err != nil {
+			return err
+		}
+		var reservations []*models.Reservation
+		for _, reservation := range all {
+			if slices.Contains(reservation.TableIDs(), id) {
+				reservations = append(reservations, reservation)
+			}
+		}
+
+		statuses := make(map[uint]models.ReservationStatus, len(reservations))
+		for _, reservation := range reservations {
+			statuses[reservation.ID] = reservation.Status
+		}
+		cancelled, err = cancelForDeletion(ctx, repos, &live, reservations, restaurant.OwnerID, "the table was removed", false, 0)
+		if err != nil {
+			return err
+		}
+		for _, reservation := range reservations {
+			if err := repos.Reservations.DeleteReservation(ctx, reservation.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := deleteCombinations(ctx, repos, table.RestaurantID, id); err != nil {
+			return err
+		}
+		live.tableRemoved(table)
+		if err := repos.Tables.DeleteTable(ctx, id); err != nil {
+			return err
+		}
+
+		for _, reservation := range reservations {
+			if statuses[reservation.ID] == reservation.Status {
+				continue // it couldn't be cancelled any more
+			}
+			others := slices.DeleteFunc(reservation.TableIDs(), func(tableID uint) bool { return tableID == id })
+			if len(others) == 0 {
+				continue
+			}
+			err := offerFreedTables(ctx, repos, reservation.RestaurantID, freedSlot{
+				tableIDs: others,
+				start:    reservation.StartTime,
+				end:      reservation.EndTime,
+			}, s.offerTTL)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for range cancelled {
+		s.metrics.ObserveReservation(models.ReservationCancelled)
+	}
+	live.publish(s.live)
+
+	return nil
+}
+
+// CreateTableCombination lets the tables of a restaurant be joined for large parties.
+// The tables are sorted, without a capacity the combination seats as many as its tables.
+func (s *RestaurantService) CreateTableCombination(ctx context.Context, combination *models.TableCombination) (_ uint, err error) {
+	const op = "RestaurantService.CreateTableCombination"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	tableIDs := slices.Sorted(slices.Values(combination.TableIDs))
+	tableIDs = slices.Compact(tableIDs)
+	if len(tableIDs) < 2 {
+		return 0, fmt.Errorf("%s: %w: a combination joins two tables or more", op, domain.ErrInvalidTable)
+	}
+
+	var seats uint
+	for _, id := range tableIDs {
+		table, err := s.tableRepo.GetTableByID(ctx, id)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		if table.RestaurantID != combination.RestaurantID {
+			return 0, fmt.Errorf("%s: %w: table %d belongs to another restaurant", op, domain.ErrInvalidTable, table.Number)
+		}
+		seats += table.Capacity
+	}
+	if combination.Capacity == 0 {
+		combination.Capacity = seats
+	}
+	if combination.Capacity > seats {
+		return 0, fmt.Errorf("%s: %w: the tables seat %d at most", op, domain.ErrInvalidTable, seats)
+	}
+	combination.TableIDs = tableIDs
+
+	id, err := s.tableRepo.CreateTableCombination(ctx, combination)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *RestaurantService) GetTableCombinationsByRestaurantID(ctx context.Context, restaurantID uint) (_ []*models.TableCombination, err error) {
+	const op = "RestaurantService.GetTableCombinationsByRestaurantID"
 
-	err := s.tableRepo.DeleteTable(id)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	combinations, err := s.tableRepo.GetTableCombinationsByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return combinations, nil
+}
+
+// DeleteTableCombination deletes a combination of the restaurant, reservations that joined its tables keep them
+func (s *RestaurantService) DeleteTableCombination(ctx context.Context, restaurantID, id uint) (err error) {
+	const op = "RestaurantService.DeleteTableCombination"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	combinations, err := s.tableRepo.GetTableCombinationsByRestaurantID(ctx, restaurantID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	if !slices.ContainsFunc(combinations, func(c *models.TableCombination) bool { return c.ID == id }) {
+		return fmt.Errorf("%s: %w", op, domain.ErrTableCombinationNotFound)
+	}
+
+	if err := s.tableRepo.DeleteTableCombination(ctx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
 
 	return nil
 }
 
 // Restaurants management
-func (s *RestaurantService) CreateRestaurant(restaurant *models.Restaurant) (uint, error) {
+func (s *RestaurantService) CreateRestaurant(ctx context.Context, restaurant *models.Restaurant) (_ uint, err error) {
 	const op = "RestaurantService.CreateRestaurant"
 
-	id, err := s.restaurantRepo.CreateRestaurant(restaurant)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	if restaurant.TableAssignment == "" {
+		restaurant.TableAssignment = models.TableAssignmentTightestFit
+	}
+	if !restaurant.TableAssignment.Valid() {
+		return 0, fmt.Errorf("%s: %w: unknown table assignment %q", op, domain.ErrInvalidRestaurant, restaurant.TableAssignment)
+	}
+	if restaurant.TimeZone == "" {
+		restaurant.TimeZone = "UTC"
+	}
+	if err := checkTimeZone(restaurant.TimeZone); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := normalizeBookingRules(&restaurant.BookingRules); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := s.restaurantRepo.CreateRestaurant(ctx, restaurant)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
@@ -107,10 +375,13 @@ func (s *RestaurantService) CreateRestaurant(restaurant *models.Restaurant) (uin
 	return id, nil
 }
 
-func (s *RestaurantService) GetRestaurants() ([]*models.Restaurant, error) {
+func (s *RestaurantService) GetRestaurants(ctx context.Context) (_ []*models.Restaurant, err error) {
 	const op = "RestaurantService.GetRestaurants"
 
-	restaurants, err := s.restaurantRepo.GetRestaurants()
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	restaurants, err := s.restaurantRepo.GetRestaurants(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -118,10 +389,13 @@ func (s *RestaurantService) GetRestaurants() ([]*models.Restaurant, error) {
 	return restaurants, nil
 }
 
-func (s *RestaurantService) GetRestaurantByID(id uint) (*models.Restaurant, error) {
+func (s *RestaurantService) GetRestaurantByID(ctx context.Context, id uint) (_ *models.Restaurant, err error) {
 	const op = "RestaurantService.GetRestaurantByID"
 
-	restaurant, err := s.restaurantRepo.GetRestaurantByID(id)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	restaurant, err := s.restaurantRepo.GetRestaurantByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -129,10 +403,22 @@ func (s *RestaurantService) GetRestaurantByID(id uint) (*models.Restaurant, erro
 	return restaurant, nil
 }
 
-func (s *RestaurantService) UpdateRestraunt(restaurant *models.Restaurant) error {
+func (s *RestaurantService) UpdateRestraunt(ctx context.Context, restaurant *models.Restaurant) (err error) {
 	const op = "RestaurantService.UpdateRestraunt"
 
-	err := s.restaurantRepo.UpdateRestraunt(restaurant)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	if restaurant.TableAssignment != "" && !restaurant.TableAssignment.Valid() {
+		return fmt.Errorf("%s: %w: unknown table assignment %q", op, domain.ErrInvalidRestaurant, restaurant.TableAssignment)
+	}
+	if restaurant.TimeZone != "" {
+		if err := checkTimeZone(restaurant.TimeZone); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	err = s.restaurantRepo.UpdateRestraunt(ctx, restaurant)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -140,22 +426,70 @@ func (s *RestaurantService) UpdateRestraunt(restaurant *models.Restaurant) error
 	return nil
 }
 
-func (s *RestaurantService) DeleteRestraunt(id uint) error {
+// checkTimeZone checks that the time zone is a known IANA name, like "Europe/Berlin"
+func checkTimeZone(name string) error {
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", domain.ErrInvalidRestaurant, name)
+	}
+	return nil
+}
+
+// UpdateBookingRules replaces the booking rules of the restaurant, reservations already made are left as they are
+func (s *RestaurantService) UpdateBookingRules(ctx context.Context, restaurantID uint, rules models.BookingRules) (err error) {
+	const op = "RestaurantService.UpdateBookingRules"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	if err := normalizeBookingRules(&rules); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.restaurantRepo.UpdateBookingRules(ctx, restaurantID, rules); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *RestaurantService) DeleteRestraunt(ctx context.Context, id uint) (err error) {
 	const op = "RestaurantService.DeleteRestraunt"
 
-	err := s.restaurantRepo.DeleteRestraunt(id)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	// the tables and reservations of the restaurant go with it, the upcoming reservations are cancelled
+	// by the owner first so their guests hear of it
+	var live liveBatch
+	cancelled := 0
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		live.reset()
+		restaurant, err := repos.Restaurants.GetRestaurantByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		cancelled, err = deleteRestaurant(ctx, repos, &live, restaurant)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	for range cancelled {
+		s.metrics.ObserveReservation(models.ReservationCancelled)
+	}
+	live.publish(s.live)
 
 	return nil
 }
 
-func (s *RestaurantService) IsOwnerOfRestaurant(userID, restaurantID uint) (bool, error) {
+func (s *RestaurantService) IsOwnerOfRestaurant(ctx context.Context, userID, restaurantID uint) (_ bool, err error) {
 	const op = "RestaurantService.IsOwnerOfRestaurant"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
 	// Retrieve the restaurant by its ID
-	restaurant, err := s.restaurantRepo.GetRestaurantByID(restaurantID)
+	restaurant, err := s.restaurantRepo.GetRestaurantByID(ctx, restaurantID)
 	if err != nil {
 		return false, fmt.Errorf("%s: %w", op, err)
 	}
@@ -167,3 +501,53 @@ func (s *RestaurantService) IsOwnerOfRestaurant(userID, restaurantID uint) (bool
 
 	return false, nil
 }
+
+// deleteRestaurant deletes the restaurant with its tables and reservations, the upcoming reservations are cancelled
+// by the owner first so their guests hear of it. It returns the number of reservations cancelled.
+func deleteRestaurant(ctx context.Context, repos Repositories, live *liveBatch, restaurant *models.Restaurant) (int, error) {
+	reservations, err := repos.Reservations.GetReservationsByRestaurantID(ctx, restaurant.ID)
+	if err != nil {
+		return 0, err
+	}
+	cancelled, err := cancelForDeletion(ctx, repos, live, reservations, restaurant.OwnerID, "the restaurant was deleted", false, 0)
+	if err != nil {
+		return cancelled, err
+	}
+	for _, reservation := range reservations {
+		if err := repos.Reservations.DeleteReservation(ctx, reservation.ID); err != nil {
+			return cancelled, err
+		}
+	}
+
+	if err := deleteCombinations(ctx, repos, restaurant.ID, 0); err != nil {
+		return cancelled, err
+	}
+	tables, err := repos.Tables.GetTablesByRestaurantID(ctx, restaurant.ID)
+	if err != nil {
+		return cancelled, err
+	}
+	for _, table := range tables {
+		if err := repos.Tables.DeleteTable(ctx, table.ID); err != nil {
+			return cancelled, err
+		}
+	}
+
+	return cancelled, repos.Restaurants.DeleteRestraunt(ctx, restaurant.ID)
+}
+
+// deleteCombinations deletes the combinations of the restaurant that join the table, or all of them for table 0
+func deleteCombinations(ctx context.Context, repos Repositories, restaurantID, tableID uint) error {
+	combinations, err := repos.Tables.GetTableCombinationsByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+	for _, combination := range combinations {
+		if tableID != 0 && !slices.Contains(combination.TableIDs, tableID) {
+			continue
+		}
+		if err := repos.Tables.DeleteTableCombination(ctx, combination.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+// OutboxRepository stores domain events until the outbox dispatcher delivers them.
+// Writing an event in the unit of work of its change makes the event exist exactly if the change does.
+type OutboxRepository interface {
+	AddEvent(ctx context.Context, event models.ReservationEvent) error
+}
+
+// Repositories are the repositories of a unit of work, all bound to its transaction
+type Repositories struct {
+	Users        UserRepository
+	Restaurants  RestaurantRepository
+	Tables       TableRepository
+	Reservations ReservationRepository
+	Holds        HoldRepository
+	Outbox       OutboxRepository
+	Waitlist     WaitlistRepository
+	WalkIns      WalkInRepository
+}
+
+// UnitOfWork runs fn in one transaction: everything fn does through repos is committed together
+// if it returns nil and rolled back otherwise. fn may be rerun after serialization failures,
+// so it must not have side effects outside the repositories: events go through repos.Outbox.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context, repos Repositories) error) error
+}
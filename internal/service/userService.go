@@ -1,32 +1,45 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/kourai55k/booking-service/internal/domain/models"
 )
 
 type UserRepository interface {
-	GetUsers() ([]*models.User, error)
-	GetUserByID(id uint) (*models.User, error)
-	GetUserByLogin(login string) (*models.User, error)
-	CreateUser(user *models.User) (uint, error)
-	UpdateUser(user *models.User) error
-	DeleteUser(id uint) error
+	// GetUsers returns the users ordered by ID, it fails with domain.ErrUsersNotFound if there are none
+	GetUsers(ctx context.Context) ([]*models.User, error)
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
+	GetUserByLogin(ctx context.Context, login string) (*models.User, error)
+	CreateUser(ctx context.Context, user *models.User) (uint, error)
+	UpdateUser(ctx context.Context, user *models.User) error
+	// DeleteUser fails with domain.ErrUserNotFound if there is no such user
+	DeleteUser(ctx context.Context, id uint) error
 }
 
 type UserService struct {
-	repo UserRepository
+	repo     UserRepository
+	uow      UnitOfWork
+	metrics  ReservationMetrics
+	live     LiveFeed
+	offerTTL time.Duration
 }
 
-func NewUserService(repo UserRepository) *UserService {
-	return &UserService{repo: repo}
+// NewUserService creates the service, the tables freed by the reservations of deleted users
+// are offered to waitlisted guests for offerTTL
+func NewUserService(repo UserRepository, uow UnitOfWork, metrics ReservationMetrics, live LiveFeed, offerTTL time.Duration) *UserService {
+	return &UserService{repo: repo, uow: uow, metrics: metrics, live: live, offerTTL: offerTTL}
 }
 
-func (s *UserService) GetUsers() ([]*models.User, error) {
+func (s *UserService) GetUsers(ctx context.Context) (_ []*models.User, err error) {
 	const op = "UserService.GetUsers"
 
-	users, err := s.repo.GetUsers()
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	users, err := s.repo.GetUsers(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -34,10 +47,13 @@ func (s *UserService) GetUsers() ([]*models.User, error) {
 	return users, nil
 }
 
-func (s *UserService) GetUserByID(id uint) (*models.User, error) {
+func (s *UserService) GetUserByID(ctx context.Context, id uint) (_ *models.User, err error) {
 	const op = "UserService.GetUserById"
 
-	user, err := s.repo.GetUserByID(id)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	user, err := s.repo.GetUserByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -45,10 +61,13 @@ func (s *UserService) GetUserByID(id uint) (*models.User, error) {
 	return user, nil
 }
 
-func (s *UserService) GetUserByLogin(login string) (*models.User, error) {
+func (s *UserService) GetUserByLogin(ctx context.Context, login string) (_ *models.User, err error) {
 	const op = "UserService.GetUserByLogin"
 
-	user, err := s.repo.GetUserByLogin(login)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	user, err := s.repo.GetUserByLogin(ctx, login)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -56,10 +75,13 @@ func (s *UserService) GetUserByLogin(login string) (*models.User, error) {
 	return user, nil
 }
 
-func (s *UserService) CreateUser(user *models.User) (uint, error) {
+func (s *UserService) CreateUser(ctx context.Context, user *models.User) (_ uint, err error) {
 	const op = "UserService.CreateUser"
 
-	id, err := s.repo.CreateUser(user)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	id, err := s.repo.CreateUser(ctx, user)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
 	}
@@ -67,10 +89,13 @@ func (s *UserService) CreateUser(user *models.User) (uint, error) {
 	return id, nil
 }
 
-func (s *UserService) UpdateUser(user *models.User) error {
+func (s *UserService) UpdateUser(ctx context.Context, user *models.User) (err error) {
 	const op = "UserService.UpdateUser"
 
-	err := s.repo.UpdateUser(user)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	err = s.repo.UpdateUser(ctx, user)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -78,13 +103,59 @@ func (s *UserService) UpdateUser(user *models.User) error {
 	return nil
 }
 
-func (s *UserService) DeleteUser(id uint) error {
+func (s *UserService) DeleteUser(ctx context.Context, id uint) (err error) {
 	const op = "UserService.DeleteUser"
 
-	err := s.repo.DeleteUser(id)
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	// the reservations of the user go with them, the upcoming ones are cancelled first
+	// so the restaurants hear of it and their tables are offered to the waitlist.
+	// The restaurants the user owns are deleted as the owner would delete them
+	var live liveBatch
+	cancelled := 0
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		live.reset()
+		cancelled = 0
+		restaurants, err := repos.Restaurants.GetRestaurants(ctx)
+		if err != nil {
+			return err
+		}
+		for _, restaurant := range restaurants {
+			if restaurant.OwnerID != id {
+				continue
+			}
+			n, err := deleteRestaurant(ctx, repos, &live, restaurant)
+			cancelled += n
+			if err != nil {
+				return err
+			}
+		}
+
+		reservations, err := repos.Reservations.GetReservationsByUserID(ctx, id)
+		if err != nil {
+			return err
+		}
+		n, err := cancelForDeletion(ctx, repos, &live, reservations, 0, "the guest's account was deleted", true, s.offerTTL)
+		cancelled += n
+		if err != nil {
+			return err
+		}
+		for _, reservation := range reservations {
+			if err := repos.Reservations.DeleteReservation(ctx, reservation.ID); err != nil {
+				return err
+			}
+		}
+
+		return repos.Users.DeleteUser(ctx, id)
+	})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	for range cancelled {
+		s.metrics.ObserveReservation(models.ReservationCancelled)
+	}
+	live.publish(s.live)
 
 	return nil
 }
@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/assignment"
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+type WaitlistRepository interface {
+	CreateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) (uint, error)
+	GetWaitlistEntryByID(ctx context.Context, id uint) (*models.WaitlistEntry, error)
+	// GetWaitlistEntryByOfferToken returns the entry last offered the hold of the token
+	GetWaitlistEntryByOfferToken(ctx context.Context, token string) (*models.WaitlistEntry, error)
+	// GetWaitingEntries returns the waiting entries of the restaurant whose window takes a seating starting at start,
+	// in the order they joined the waitlist
+	GetWaitingEntries(ctx context.Context, restaurantID uint, start time.Time) ([]*models.WaitlistEntry, error)
+	// GetExpiredOffers returns the offered entries whose offer expired by now
+	GetExpiredOffers(ctx context.Context, now time.Time) ([]*models.WaitlistEntry, error)
+	// UpdateWaitlistEntry sets the status and the offer of the entry
+	UpdateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) error
+}
+
+type WaitlistService struct {
+	waitlistRepo   WaitlistRepository
+	restaurantRepo RestaurantRepository
+	uow            UnitOfWork
+	offerTTL       time.Duration
+}
+
+// NewWaitlistService creates the service, offers of freed tables last for offerTTL
+func NewWaitlistService(
+	waitlistRepo WaitlistRepository,
+	restaurantRepo RestaurantRepository,
+	uow UnitOfWork,
+	offerTTL time.Duration,
+) *WaitlistService {
+	return &WaitlistService{
+		waitlistRepo:   waitlistRepo,
+		restaurantRepo: restaurantRepo,
+		uow:            uow,
+		offerTTL:       offerTTL,
+	}
+}
+
+// JoinWaitlist puts the guest on the waitlist of the restaurant, filling in the ID, status and creation time of the entry
+func (s *WaitlistService) JoinWaitlist(ctx context.Context, entry *models.WaitlistEntry) (_ uint, err error) {
+	const op = "WaitlistService.JoinWaitlist"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	if entry.PartySize == 0 || entry.RestaurantID == 0 || entry.UserID == 0 || entry.Contact == "" ||
+		entry.WindowStart.IsZero() || entry.WindowEnd.IsZero() {
+		return 0, fmt.Errorf("%s: %w: missing required fields", op, domain.ErrInvalidWaitlistEntry)
+	}
+	if entry.WindowEnd.Before(entry.WindowStart) {
+		return 0, fmt.Errorf("%s: %w: window ends before it starts", op, domain.ErrInvalidWaitlistEntry)
+	}
+	if !entry.WindowEnd.After(time.Now()) {
+		return 0, fmt.Errorf("%s: %w: window is over", op, domain.ErrInvalidWaitlistEntry)
+	}
+	if _, err := s.restaurantRepo.GetRestaurantByID(ctx, entry.RestaurantID); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	entry.Status = models.WaitlistStatusWaiting
+	entry.CreatedAt = time.Now()
+	entry.Offer = nil
+
+	id, err := s.waitlistRepo.CreateWaitlistEntry(ctx, entry)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	entry.ID = id
+
+	return id, nil
+}
+
+// GetWaitlistEntry returns the user's waitlist entry, entries of other users are reported as not found
+func (s *WaitlistService) GetWaitlistEntry(ctx context.Context, userID, id uint) (_ *models.WaitlistEntry, err error) {
+	const op = "WaitlistService.GetWaitlistEntry"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	entry, err := s.waitlistRepo.GetWaitlistEntryByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if entry.UserID != userID {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrWaitlistEntryNotFound)
+	}
+
+	return entry, nil
+}
+
+// LeaveWaitlist takes the user's entry off the waitlist. A table offered to them is released
+// and offered to the next waiting guest. Entries of other users are reported as not found.
+func (s *WaitlistService) LeaveWaitlist(ctx context.Context, userID, id uint) (err error) {
+	const op = "WaitlistService.LeaveWaitlist"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		entry, err := repos.Waitlist.GetWaitlistEntryByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if entry.UserID != userID {
+			return domain.ErrWaitlistEntryNotFound
+		}
+		if entry.Status != models.WaitlistStatusWaiting && entry.Status != models.WaitlistStatusOffered {
+			return fmt.Errorf("%w: it's %s", domain.ErrWaitlistEntryClosed, entry.Status)
+		}
+
+		offered := entry.Status == models.WaitlistStatusOffered
+		entry.Status = models.WaitlistStatusLeft
+		if err := repos.Waitlist.UpdateWaitlistEntry(ctx, entry); err != nil {
+			return err
+		}
+		if !offered {
+			return nil
+		}
+
+		hold, err := repos.Holds.GetHoldByToken(ctx, entry.Offer.Token)
+		if errors.Is(err, domain.ErrHoldNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := repos.Holds.DeleteHold(ctx, hold.ID); err != nil {
+			return err
+		}
+		return offerFreedTables(ctx, repos, entry.RestaurantID, offerSlot(entry.Offer), s.offerTTL)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ExpireOffers marks the offers that ran out as expired and offers their tables to the next waiting guests.
+// It returns the number of expired offers.
+func (s *WaitlistService) ExpireOffers(ctx context.Context) (_ int, err error) {
+	const op = "WaitlistService.ExpireOffers"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	var expired int
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		entries, err := repos.Waitlist.GetExpiredOffers(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		expired = len(entries)
+
+		for _, entry := range entries {
+			entry.Status = models.WaitlistStatusExpired
+			if err := repos.Waitlist.UpdateWaitlistEntry(ctx, entry); err != nil {
+				return err
+			}
+		}
+		// the guests whose offers ran out are no longer waiting, the tables go to the next ones
+		for _, entry := range entries {
+			if err := offerFreedTables(ctx, repos, entry.RestaurantID, offerSlot(entry.Offer), s.offerTTL); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return expired, nil
+}
+
+// freedSlot is the tables a reservation no longer takes from start.
+// Without a default seating duration for the party, a seating offered in it ends at end.
+type freedSlot struct {
+	tableIDs   []uint
+	start, end time.Time
+}
+
+func offerSlot(offer *models.WaitlistOffer) freedSlot {
+	return freedSlot{tableIDs: []uint{offer.TableID}, start: offer.StartTime, end: offer.EndTime}
+}
+
+// freedSlots returns what the change of the reservation from old to updated frees:
+// the whole time of the tables it left, and the time after the new end on the tables it kept
+func freedSlots(old, updated *models.Reservation) []freedSlot {
+	var slots []freedSlot
+
+	var left, kept []uint
+	for _, id := range old.TableIDs() {
+		if slices.Contains(updated.TableIDs(), id) {
+			kept = append(kept, id)
+		} else {
+			left = append(left, id)
+		}
+	}
+	if len(left) > 0 {
+		slots = append(slots, freedSlot{tableIDs: left, start: old.StartTime, end: old.EndTime})
+	}
+	if newEnd := updated.EndTime.Add(updated.Buffer); len(kept) > 0 && newEnd.Before(old.EndTime) {
+		slots = append(slots, freedSlot{tableIDs: kept, start: newEnd, end: old.EndTime})
+	}
+
+	return slots
+}
+
+// offerFreedTables offers each freed table to the first waiting guest it seats, in the order they joined
+// the waitlist, for a seating starting when it was freed. The offer is a hold of the table for offerTTL
+// sent to the guest through the outbox, they claim the table by converting the hold.
+// Guests the booking rules, pacing or other bookings of the table don't let sit are skipped.
+func offerFreedTables(ctx context.Context, repos Repositories, restaurantID uint, slot freedSlot, offerTTL time.Duration) error {
+	if !slot.start.After(time.Now()) {
+		return nil
+	}
+	entries, err := repos.Waitlist.GetWaitingEntries(ctx, restaurantID, slot.start)
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+	restaurant, err := repos.Restaurants.GetRestaurantByID(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+
+	for _, tableID := range slot.tableIDs {
+		table, err := repos.Tables.GetTableByID(ctx, tableID)
+		if errors.Is(err, domain.ErrTableNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		for i, entry := range entries {
+			if !assignment.Eligible(table, assignment.Request{PartySize: entry.PartySize}) {
+				continue
+			}
+			offered, err := offerTable(ctx, repos, restaurant, table, entry, slot, offerTTL)
+			if err != nil {
+				return err
+			}
+			if offered {
+				entries = slices.Delete(entries, i, i+1)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// offerTable holds the table for the guest of the entry and sends them the offer,
+// it reports false if the seating can't be offered to them
+func offerTable(
+	ctx context.Context,
+	repos Repositories,
+	restaurant *models.Restaurant,
+	table *models.Table,
+	entry *models.WaitlistEntry,
+	slot freedSlot,
+	offerTTL time.Duration,
+) (bool, error) {
+	now := time.Now()
+	seating := &models.Reservation{
+		PartySize:    entry.PartySize,
+		StartTime:    slot.start,
+		EndTime:      slot.end,
+		RestaurantID: restaurant.ID,
+		TableID:      table.ID,
+		UserID:       entry.UserID,
+	}
+	if duration, ok := restaurant.BookingRules.Duration(entry.PartySize); ok {
+		seating.EndTime = slot.start.Add(duration)
+	}
+	if !seating.EndTime.After(seating.StartTime) {
+		return false, nil
+	}
+	if err := applyBookingRules(restaurant, seating, now); err != nil {
+		return false, nil
+	}
+	err := checkPacing(ctx, repos.Reservations, restaurant.BookingRules, seating)
+	if errors.Is(err, domain.ErrPacingExceeded) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	token, err := newHoldToken()
+	if err != nil {
+		return false, err
+	}
+	hold := &models.Hold{
+		Token:        token,
+		PartySize:    seating.PartySize,
+		StartTime:    seating.StartTime,
+		EndTime:      seating.EndTime,
+		ExpiresAt:    now.Add(offerTTL),
+		RestaurantID: seating.RestaurantID,
+		TableID:      seating.TableID,
+		UserID:       seating.UserID,
+		Buffer:       seating.Buffer,
+	}
+	_, err = repos.Holds.CreateHold(ctx, hold)
+	if errors.Is(err, domain.ErrTableAlreadyReserved) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	entry.Status = models.WaitlistStatusOffered
+	entry.Offer = &models.WaitlistOffer{
+		Token:     hold.Token,
+		TableID:   hold.TableID,
+		StartTime: hold.StartTime,
+		EndTime:   hold.EndTime,
+		ExpiresAt: hold.ExpiresAt,
+	}
+	if err := repos.Waitlist.UpdateWaitlistEntry(ctx, entry); err != nil {
+		return false, err
+	}
+
+	err = repos.Outbox.AddEvent(ctx, models.ReservationEvent{
+		Type:        models.WaitlistOffered,
+		Reservation: *seating,
+		OwnerID:     restaurant.OwnerID,
+		OccurredAt:  now,
+		Waitlist:    entry,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// claimOffer marks the entry offered the hold of the token as claimed, holds that weren't offered are left alone
+func claimOffer(ctx context.Context, repos Repositories, token string) error {
+	entry, err := repos.Waitlist.GetWaitlistEntryByOfferToken(ctx, token)
+	if errors.Is(err, domain.ErrWaitlistEntryNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if entry.Status != models.WaitlistStatusOffered {
+		return nil
+	}
+
+	entry.Status = models.WaitlistStatusClaimed
+	return repos.Waitlist.UpdateWaitlistEntry(ctx, entry)
+}
@@ -0,0 +1,642 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/assignment"
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	jwthelper "github.com/kourai55k/booking-service/pkg/jwtHelper"
+)
+
+// defaultTurnTime is how long a party is expected to sit at a table
+// when the booking rules of the restaurant have no seating duration for it
+const defaultTurnTime = 90 * time.Minute
+
+// liveTicketTTL is how long a stream ticket can be used to open the live stream,
+// the stream itself stays open for as long as the client keeps it
+const liveTicketTTL = time.Minute
+
+type WalkInRepository interface {
+	CreateWalkIn(ctx context.Context, walkIn *models.WalkIn) (uint, error)
+	GetWalkInByID(ctx context.Context, id uint) (*models.WalkIn, error)
+	// GetWalkInQueue returns the waiting and notified walk-ins of the restaurant in the order they joined the queue
+	GetWalkInQueue(ctx context.Context, restaurantID uint) ([]*models.WalkIn, error)
+	// UpdateWalkIn sets the status, the notification and seating times and the table of the walk-in
+	UpdateWalkIn(ctx context.Context, walkIn *models.WalkIn) error
+}
+
+// WalkInService runs the host stand of a restaurant: the queue of walk-ins and which tables are occupied.
+// Every method is for the hosts of the restaurant only, its owner and admins.
+type WalkInService struct {
+	walkInRepo      WalkInRepository
+	tableRepo       TableRepository
+	restaurantRepo  RestaurantRepository
+	reservationRepo ReservationRepository
+	uow             UnitOfWork
+	live            LiveFeed
+}
+
+func NewWalkInService(
+	walkInRepo WalkInRepository,
+	tableRepo TableRepository,
+	restaurantRepo RestaurantRepository,
+	reservationRepo ReservationRepository,
+	uow UnitOfWork,
+	live LiveFeed,
+) *WalkInService {
+	return &WalkInService{
+		walkInRepo:      walkInRepo,
+		tableRepo:       tableRepo,
+		restaurantRepo:  restaurantRepo,
+		reservationRepo: reservationRepo,
+		uow:             uow,
+		live:            live,
+	}
+}
+
+// QuoteWait estimates the wait of a party of partySize joining the end of the queue of the restaurant.
+// It fails with domain.ErrNoTableAvailable if no table of the restaurant seats the party.
+func (s *WalkInService) QuoteWait(ctx context.Context, userID uint, role string, restaurantID, partySize uint) (_ time.Duration, err error) {
+	const op = "WalkInService.QuoteWait"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	if partySize == 0 {
+		return 0, fmt.Errorf("%s: %w: party size is required", op, domain.ErrInvalidWalkIn)
+	}
+	restaurant, err := checkHost(ctx, s.restaurantRepo, userID, role, restaurantID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	wait, err := s.quote(ctx, restaurant, partySize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return wait, nil
+}
+
+// JoinWalkInQueue puts the party at the end of the queue of the restaurant, filling in the ID, status,
+// join time and the wait quoted to the party
+func (s *WalkInService) JoinWalkInQueue(ctx context.Context, userID uint, role string, walkIn *models.WalkIn) (_ uint, err error) {
+	const op = "WalkInService.JoinWalkInQueue"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	if walkIn.Name == "" || walkIn.PartySize == 0 || walkIn.RestaurantID == 0 {
+		return 0, fmt.Errorf("%s: %w: missing required fields", op, domain.ErrInvalidWalkIn)
+	}
+	restaurant, err := checkHost(ctx, s.restaurantRepo, userID, role, walkIn.RestaurantID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	wait, err := s.quote(ctx, restaurant, walkIn.PartySize)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	walkIn.Status = models.WalkInStatusWaiting
+	walkIn.QuotedWait = wait
+	walkIn.EstimatedWait = wait
+	walkIn.JoinedAt = time.Now()
+	walkIn.NotifiedAt, walkIn.SeatedAt, walkIn.TableID = time.Time{}, time.Time{}, 0
+
+	id, err := s.walkInRepo.CreateWalkIn(ctx, walkIn)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	walkIn.ID = id
+
+	var live liveBatch
+	live.walkIn(walkIn)
+	live.publish(s.live)
+
+	return id, nil
+}
+
+// GetWalkInQueue returns the queue of the restaurant in order, each walk-in with its wait estimated now
+func (s *WalkInService) GetWalkInQueue(ctx context.Context, userID uint, role string, restaurantID uint) (_ []*models.WalkIn, err error) {
+	const op = "WalkInService.GetWalkInQueue"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	restaurant, err := checkHost(ctx, s.restaurantRepo, userID, role, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	queue, err := s.walkInRepo.GetWalkInQueue(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	waits, _, err := s.estimate(ctx, restaurant, queue)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	for i, walkIn := range queue {
+		walkIn.EstimatedWait = waits[i]
+	}
+
+	return queue, nil
+}
+
+// NotifyWalkIn tells the walk-in their table is ready, through the outbox if they left a contact.
+// The table must be free now and seat the party; it's kept for them in the wait estimates until they're seated.
+func (s *WalkInService) NotifyWalkIn(ctx context.Context, userID uint, role string, id, tableID uint) (err error) {
+	const op = "WalkInService.NotifyWalkIn"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	var live liveBatch
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		live.reset()
+		walkIn, restaurant, err := queuedWalkIn(ctx, repos, userID, role, id)
+		if err != nil {
+			return err
+		}
+		chosen := tableID
+		if chosen == 0 {
+			chosen = walkIn.TableID
+		}
+		now := time.Now()
+		table, err := readyTable(ctx, repos, restaurant, walkIn, chosen, now)
+		if err != nil {
+			return err
+		}
+
+		walkIn.Status = models.WalkInStatusNotified
+		walkIn.NotifiedAt = now
+		walkIn.TableID = table.ID
+		if err := repos.WalkIns.UpdateWalkIn(ctx, walkIn); err != nil {
+			return err
+		}
+		live.walkIn(walkIn)
+		if walkIn.Contact == "" {
+			return nil
+		}
+
+		return repos.Outbox.AddEvent(ctx, models.ReservationEvent{
+			Type: models.WalkInNotified,
+			Reservation: models.Reservation{
+				PartySize:    walkIn.PartySize,
+				StartTime:    now,
+				RestaurantID: restaurant.ID,
+				TableID:      table.ID,
+			},
+			OwnerID:    restaurant.OwnerID,
+			OccurredAt: now,
+			WalkIn:     walkIn,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	live.publish(s.live)
+
+	return nil
+}
+
+// SeatWalkIn seats the walk-in at the table, the one they were notified about if tableID is 0,
+// and marks it occupied. The table must be free now and seat the party, and not be reserved
+// for the turn of the party.
+func (s *WalkInService) SeatWalkIn(ctx context.Context, userID uint, role string, id, tableID uint) (err error) {
+	const op = "WalkInService.SeatWalkIn"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	var live liveBatch
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		live.reset()
+		walkIn, restaurant, err := queuedWalkIn(ctx, repos, userID, role, id)
+		if err != nil {
+			return err
+		}
+		chosen := tableID
+		if chosen == 0 {
+			chosen = walkIn.TableID
+		}
+		now := time.Now()
+		table, err := readyTable(ctx, repos, restaurant, walkIn, chosen, now)
+		if err != nil {
+			return err
+		}
+		until := turnEnd(restaurant.BookingRules, now, walkIn.PartySize)
+		if err := repos.Tables.SetTableOccupancy(ctx, table.ID, now, until, walkIn.PartySize); err != nil {
+			return err
+		}
+		table.OccupiedSince, table.OccupiedUntil, table.OccupiedPartySize = now, until, walkIn.PartySize
+		live.table(table)
+
+		walkIn.Status = models.WalkInStatusSeated
+		walkIn.SeatedAt = now
+		walkIn.TableID = table.ID
+		live.walkIn(walkIn)
+		return repos.WalkIns.UpdateWalkIn(ctx, walkIn)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	live.publish(s.live)
+
+	return nil
+}
+
+// LeaveWalkInQueue takes the walk-in off the queue without seating them
+func (s *WalkInService) LeaveWalkInQueue(ctx context.Context, userID uint, role string, id uint) (err error) {
+	const op = "WalkInService.LeaveWalkInQueue"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	var live liveBatch
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		live.reset()
+		walkIn, _, err := queuedWalkIn(ctx, repos, userID, role, id)
+		if err != nil {
+			return err
+		}
+
+		walkIn.Status = models.WalkInStatusLeft
+		live.walkIn(walkIn)
+		return repos.WalkIns.UpdateWalkIn(ctx, walkIn)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	live.publish(s.live)
+
+	return nil
+}
+
+// GetFloor returns the tables of the restaurant with who sits at them, in order of their numbers
+func (s *WalkInService) GetFloor(ctx context.Context, userID uint, role string, restaurantID uint) (_ []*models.Table, err error) {
+	const op = "WalkInService.GetFloor"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	if _, err := checkHost(ctx, s.restaurantRepo, userID, role, restaurantID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tables, err := s.tableRepo.GetTablesByRestaurantID(ctx, restaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tables, nil
+}
+
+// OccupyTable marks the table occupied by a party of partySize from now, for parties seated
+// outside the queue. It fails with domain.ErrTableOccupied if a party already sits there.
+func (s *WalkInService) OccupyTable(ctx context.Context, userID uint, role string, restaurantID, tableID, partySize uint) (err error) {
+	const op = "WalkInService.OccupyTable"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	if partySize == 0 {
+		return fmt.Errorf("%s: %w: party size is required", op, domain.ErrInvalidWalkIn)
+	}
+
+	var live liveBatch
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		live.reset()
+		restaurant, table, err := hostTable(ctx, repos, userID, role, restaurantID, tableID)
+		if err != nil {
+			return err
+		}
+		if table.Occupied() {
+			return domain.ErrTableOccupied
+		}
+		now := time.Now()
+		return occupyTables(ctx, repos, &live, []uint{table.ID}, now, turnEnd(restaurant.BookingRules, now, partySize), partySize)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	live.publish(s.live)
+
+	return nil
+}
+
+// FreeTable marks the table free once its party left, freeing a free table does nothing
+func (s *WalkInService) FreeTable(ctx context.Context, userID uint, role string, restaurantID, tableID uint) (err error) {
+	const op = "WalkInService.FreeTable"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	var live liveBatch
+	err = s.uow.Do(ctx, func(ctx context.Context, repos Repositories) error {
+		live.reset()
+		_, table, err := hostTable(ctx, repos, userID, role, restaurantID, tableID)
+		if err != nil {
+			return err
+		}
+		if !table.Occupied() {
+			return nil
+		}
+		return occupyTables(ctx, repos, &live, []uint{table.ID}, time.Time{}, time.Time{}, 0)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	live.publish(s.live)
+
+	return nil
+}
+
+// CheckHost fails with domain.ErrHostOnly unless the user works the host stand of the restaurant
+func (s *WalkInService) CheckHost(ctx context.Context, userID uint, role string, restaurantID uint) (err error) {
+	const op = "WalkInService.CheckHost"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	if _, err := checkHost(ctx, s.restaurantRepo, userID, role, restaurantID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// IssueLiveTicket returns a short-lived ticket a host opens the live stream of the restaurant with from a browser,
+// and when it expires
+func (s *WalkInService) IssueLiveTicket(ctx context.Context, userID uint, role string, restaurantID uint) (_ string, _ time.Time, err error) {
+	const op = "WalkInService.IssueLiveTicket"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer endSpan(span, &err)
+
+	if _, err := checkHost(ctx, s.restaurantRepo, userID, role, restaurantID); err != nil {
+		return "", time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+	ticket, expiresAt, err := jwthelper.GenerateStreamTicket(userID, role, restaurantID, liveTicketTTL)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return ticket, expiresAt, nil
+}
+
+// quote estimates the wait of a party joining the end of the queue of the restaurant
+func (s *WalkInService) quote(ctx context.Context, restaurant *models.Restaurant, partySize uint) (time.Duration, error) {
+	queue, err := s.walkInRepo.GetWalkInQueue(ctx, restaurant.ID)
+	if err != nil {
+		return 0, err
+	}
+	queue = append(queue, &models.WalkIn{PartySize: partySize, RestaurantID: restaurant.ID})
+
+	waits, seated, err := s.estimate(ctx, restaurant, queue)
+	if err != nil {
+		return 0, err
+	}
+	if !seated[len(queue)-1] {
+		return 0, domain.ErrNoTableAvailable
+	}
+
+	return waits[len(queue)-1], nil
+}
+
+// estimate loads the tables and the reservations of the restaurant and estimates the waits of the queue, see estimateWaits
+func (s *WalkInService) estimate(ctx context.Context, restaurant *models.Restaurant, queue []*models.WalkIn) ([]time.Duration, []bool, error) {
+	tables, err := s.tableRepo.GetAvailableTablesByRestaurantID(ctx, restaurant.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	reservations, err := s.reservationRepo.GetReservationsByRestaurantID(ctx, restaurant.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	waits, seated := estimateWaits(tables, reservations, restaurant.BookingRules, queue, time.Now())
+	return waits, seated, nil
+}
+
+// estimateWaits plays the queue through in order: each party takes the table seating it that frees up first,
+// the one they were notified about if any, and keeps it for their turn and the buffer after it.
+// A table frees up when its party's turn is over, and can't be taken for a turn that runs into
+// an active reservation of it. It returns the wait of each party, and whether any table seats it at all.
+func estimateWaits(
+	tables []*models.Table,
+	reservations []*models.Reservation,
+	rules models.BookingRules,
+	queue []*models.WalkIn,
+	now time.Time,
+) ([]time.Duration, []bool) {
+	freeAt := make(map[uint]time.Time, len(tables))
+	for _, table := range tables {
+		freeAt[table.ID] = expectedFree(table, now)
+	}
+	byTable := make(map[uint][]*models.Reservation)
+	for _, reservation := range reservations {
+		if !reservation.Status.Active() || !reservation.EndTime.Add(reservation.Buffer).After(now) {
+			continue
+		}
+		for _, id := range reservation.TableIDs() {
+			byTable[id] = append(byTable[id], reservation)
+		}
+	}
+
+	waits := make([]time.Duration, len(queue))
+	seated := make([]bool, len(queue))
+	for i, walkIn := range queue {
+		turn := turnTime(rules, walkIn.PartySize) + rules.Buffer
+
+		var best *models.Table
+		var bestAt time.Time
+		for _, table := range tables {
+			if walkIn.TableID != 0 && table.ID != walkIn.TableID {
+				continue
+			}
+			if !assignment.Eligible(table, assignment.Request{PartySize: walkIn.PartySize}) {
+				continue
+			}
+			at := clearOf(byTable[table.ID], freeAt[table.ID], turn)
+			if best == nil || at.Before(bestAt) || (at.Equal(bestAt) && table.Capacity < best.Capacity) {
+				best, bestAt = table, at
+			}
+		}
+		if best == nil {
+			continue
+		}
+
+		waits[i] = bestAt.Sub(now)
+		seated[i] = true
+		freeAt[best.ID] = bestAt.Add(turn)
+	}
+
+	return waits, seated
+}
+
+// clearOf returns the first time from at the table is free of the reservations for turn
+func clearOf(reservations []*models.Reservation, at time.Time, turn time.Duration) time.Time {
+	for moved := true; moved; {
+		moved = false
+		for _, reservation := range reservations {
+			if reservation.Overlaps(at, at.Add(turn)) {
+				at = reservation.EndTime.Add(reservation.Buffer)
+				moved = true
+			}
+		}
+	}
+	return at
+}
+
+// turnTime is how long a party of partySize is expected to sit at its table, the buffer not included
+func turnTime(rules models.BookingRules, partySize uint) time.Duration {
+	if duration, ok := rules.Duration(partySize); ok {
+		return duration
+	}
+	return defaultTurnTime
+}
+
+// expectedFree returns when the party at the table is expected to leave and the table to be cleaned,
+// now for free tables and parties that stay longer than expected
+func expectedFree(table *models.Table, now time.Time) time.Time {
+	if !table.Occupied() || table.OccupiedUntil.Before(now) {
+		return now
+	}
+	return table.OccupiedUntil
+}
+
+// turnEnd returns when a party of partySize seated at since is expected to leave the table cleaned
+func turnEnd(rules models.BookingRules, since time.Time, partySize uint) time.Time {
+	return since.Add(turnTime(rules, partySize) + rules.Buffer)
+}
+
+// occupyTables sets the occupancy of the tables and adds their new state to the live batch,
+// tables deleted since are skipped
+func occupyTables(
+	ctx context.Context,
+	repos Repositories,
+	live *liveBatch,
+	tableIDs []uint,
+	since, until time.Time,
+	partySize uint,
+) error {
+	for _, id := range tableIDs {
+		table, err := repos.Tables.GetTableByID(ctx, id)
+		if errors.Is(err, domain.ErrTableNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := repos.Tables.SetTableOccupancy(ctx, id, since, until, partySize); err != nil {
+			return err
+		}
+		table.OccupiedSince, table.OccupiedUntil, table.OccupiedPartySize = since, until, partySize
+		if since.IsZero() {
+			table.OccupiedUntil, table.OccupiedPartySize = time.Time{}, 0
+		}
+		live.table(table)
+	}
+	return nil
+}
+
+// checkHost checks that the user works the host stand of the restaurant, as its owner or an admin,
+// and returns the restaurant
+func checkHost(ctx context.Context, restaurants RestaurantRepository, userID uint, role string, restaurantID uint) (*models.Restaurant, error) {
+	restaurant, err := restaurants.GetRestaurantByID(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if role != "admin" && restaurant.OwnerID != userID {
+		return nil, domain.ErrHostOnly
+	}
+	return restaurant, nil
+}
+
+// queuedWalkIn returns the walk-in still in the queue and its restaurant, checking the user is one of its hosts
+func queuedWalkIn(ctx context.Context, repos Repositories, userID uint, role string, id uint) (*models.WalkIn, *models.Restaurant, error) {
+	walkIn, err := repos.WalkIns.GetWalkInByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	restaurant, err := checkHost(ctx, repos.Restaurants, userID, role, walkIn.RestaurantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !walkIn.Status.Queued() {
+		return nil, nil, fmt.Errorf("%w: it's %s", domain.ErrWalkInClosed, walkIn.Status)
+	}
+	return walkIn, restaurant, nil
+}
+
+// hostTable returns the table of the restaurant, checking the user is one of its hosts
+func hostTable(
+	ctx context.Context,
+	repos Repositories,
+	userID uint,
+	role string,
+	restaurantID, tableID uint,
+) (*models.Restaurant, *models.Table, error) {
+	restaurant, err := checkHost(ctx, repos.Restaurants, userID, role, restaurantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	table, err := repos.Tables.GetTableByID(ctx, tableID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if table.RestaurantID != restaurantID {
+		return nil, nil, domain.ErrTableNotFound
+	}
+	return restaurant, table, nil
+}
+
+// readyTable returns the table if the walk-in can sit there now: it's in service at the restaurant, seats the party,
+// no one sits there, and no reservation or hold takes it during the turn of the party
+func readyTable(
+	ctx context.Context,
+	repos Repositories,
+	restaurant *models.Restaurant,
+	walkIn *models.WalkIn,
+	tableID uint,
+	now time.Time,
+) (*models.Table, error) {
+	if tableID == 0 {
+		return nil, fmt.Errorf("%w: table is required", domain.ErrInvalidWalkIn)
+	}
+	table, err := repos.Tables.GetTableByID(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	if table.RestaurantID != restaurant.ID {
+		return nil, domain.ErrTableNotFound
+	}
+	if !table.IsAvailable {
+		return nil, fmt.Errorf("%w: table is out of service", domain.ErrInvalidWalkIn)
+	}
+	if !assignment.Eligible(table, assignment.Request{PartySize: walkIn.PartySize}) {
+		return nil, fmt.Errorf("%w: table doesn't seat a party of %d", domain.ErrInvalidWalkIn, walkIn.PartySize)
+	}
+	if table.Occupied() {
+		return nil, domain.ErrTableOccupied
+	}
+
+	end := now.Add(turnTime(restaurant.BookingRules, walkIn.PartySize) + restaurant.BookingRules.Buffer)
+	reserved, err := repos.Reservations.GetOccupiedTableIDs(ctx, restaurant.ID, now, end)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(reserved, table.ID) {
+		return nil, fmt.Errorf("%w: during the turn of the party", domain.ErrTableAlreadyReserved)
+	}
+
+	return table, nil
+}
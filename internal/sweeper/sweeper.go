@@ -0,0 +1,50 @@
+// Package sweeper periodically cleans up records that have run out, like expired holds.
+package sweeper
+
+import (
+	"context"
+	"time"
+)
+
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Sweeper calls sweep every interval, sweep returns the number of records it removed
+type Sweeper struct {
+	name     string
+	interval time.Duration
+	sweep    func(ctx context.Context) (int, error)
+	log      Logger
+}
+
+// New creates a sweeper, name identifies it in the logs
+func New(name string, interval time.Duration, sweep func(ctx context.Context) (int, error), log Logger) *Sweeper {
+	return &Sweeper{name: name, interval: interval, sweep: sweep, log: log}
+}
+
+// Run sweeps until ctx is done
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		swept, err := s.sweep(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.log.Error("sweep failed", "sweeper", s.name, "err", err.Error())
+			}
+			continue
+		}
+		if swept > 0 {
+			s.log.Debug("swept", "sweeper", s.name, "count", swept)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package grpcHandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	bookingv1 "github.com/kourai55k/booking-service/pkg/api/booking/v1"
+	"github.com/kourai55k/booking-service/pkg/hashing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AuthServer struct {
+	bookingv1.UnimplementedAuthServiceServer
+	authService AuthService
+	logger      Logger
+}
+
+func NewAuthServer(authService AuthService, logger Logger) *AuthServer {
+	return &AuthServer{authService: authService, logger: logger}
+}
+
+func (s *AuthServer) Register(ctx context.Context, req *bookingv1.RegisterRequest) (*bookingv1.RegisterResponse, error) {
+	const op = "grpc.AuthServer.Register"
+
+	log := s.logger
+
+	if req.GetName() == "" || req.GetLogin() == "" || req.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if len(req.GetPassword()) < domain.MinPasswordLength {
+		return nil, status.Error(codes.InvalidArgument, "password must be at least 8 characters long")
+	}
+
+	hashPass, err := hashing.HashPassword(req.GetPassword())
+	if err != nil {
+		log.Error("failed to hash password", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	user := &models.User{
+		Name:     req.GetName(),
+		Login:    req.GetLogin(),
+		HashPass: hashPass,
+		Role:     "user",
+	}
+
+	id, err := s.authService.Register(ctx, user)
+	if err != nil {
+		log.Error("failed to register user", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.RegisterResponse{Id: uint64(id)}, nil
+}
+
+func (s *AuthServer) Login(ctx context.Context, req *bookingv1.LoginRequest) (*bookingv1.LoginResponse, error) {
+	const op = "grpc.AuthServer.Login"
+
+	log := s.logger
+
+	if req.GetLogin() == "" || req.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	token, err := s.authService.Login(ctx, req.GetLogin(), req.GetPassword())
+	if err != nil {
+		log.Error("failed to login user", "error", fmt.Errorf("%s: %w", op, err).Error())
+		// an unknown login fails like a wrong password, so callers can't find out which logins exist
+		if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrWrongPassword) {
+			return nil, status.Error(codes.Unauthenticated, "invalid login or password")
+		}
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.LoginResponse{Token: token}, nil
+}
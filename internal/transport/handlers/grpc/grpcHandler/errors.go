@@ -0,0 +1,49 @@
+package grpcHandler
+
+import (
+	"errors"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus maps domain errors to gRPC status codes.
+// Unknown errors are reported as Internal without leaking their details.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrUsersNotFound),
+		errors.Is(err, domain.ErrRestaurantNotFound),
+		errors.Is(err, domain.ErrTableNotFound),
+		errors.Is(err, domain.ErrTableCombinationNotFound):
+		return status.Error(codes.NotFound, errorMessage(err))
+	case errors.Is(err, domain.ErrUserAlreadyExists),
+		errors.Is(err, domain.ErrTableAlreadyExists),
+		errors.Is(err, domain.ErrTableCombinationAlreadyExists):
+		return status.Error(codes.AlreadyExists, errorMessage(err))
+	case errors.Is(err, domain.ErrInvalidRestaurant),
+		errors.Is(err, domain.ErrInvalidTable):
+		return status.Error(codes.InvalidArgument, errorMessage(err))
+	case errors.Is(err, domain.ErrWrongPassword):
+		return status.Error(codes.Unauthenticated, errorMessage(err))
+	case errors.Is(err, domain.ErrRegistrationDisabled):
+		return status.Error(codes.PermissionDenied, errorMessage(err))
+	case errors.Is(err, domain.ErrUserOwnsRestaurants):
+		return status.Error(codes.FailedPrecondition, errorMessage(err))
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
+
+// errorMessage returns the message of the innermost wrapped error,
+// so internal op names don't reach the client
+func errorMessage(err error) string {
+	for {
+		inner := errors.Unwrap(err)
+		if inner == nil {
+			return err.Error()
+		}
+		err = inner
+	}
+}
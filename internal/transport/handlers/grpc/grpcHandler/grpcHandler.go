@@ -0,0 +1,115 @@
+package grpcHandler
+
+import (
+	"context"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/internal/transport/handlers/grpc/interceptors"
+	"github.com/kourai55k/booking-service/internal/transport/handlers/http/middleware"
+	bookingv1 "github.com/kourai55k/booking-service/pkg/api/booking/v1"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+type UserService interface {
+	GetUsers(ctx context.Context) ([]*models.User, error)
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
+	GetUserByLogin(ctx context.Context, login string) (*models.User, error)
+	CreateUser(ctx context.Context, user *models.User) (uint, error)
+	UpdateUser(ctx context.Context, user *models.User) error
+	DeleteUser(ctx context.Context, id uint) error
+}
+
+type AuthService interface {
+	Register(ctx context.Context, user *models.User) (uint, error)
+	Login(ctx context.Context, login, password string) (string, error)
+}
+
+type RestaurantService interface {
+	CreateRestaurant(context.Context, *models.Restaurant) (uint, error)
+	GetRestaurants(context.Context) ([]*models.Restaurant, error)
+	GetRestaurantByID(context.Context, uint) (*models.Restaurant, error)
+	UpdateRestraunt(context.Context, *models.Restaurant) error
+	DeleteRestraunt(context.Context, uint) error
+	UpdateBookingRules(ctx context.Context, restaurantID uint, rules models.BookingRules) error
+
+	CreateTable(context.Context, *models.Table) (uint, error)
+	GetTablesByRestaurantID(context.Context, uint) ([]*models.Table, error)
+	GetAvailableTablesByRestaurantID(context.Context, uint) ([]*models.Table, error)
+	GetTableByID(context.Context, uint) (*models.Table, error)
+	UpdateTable(context.Context, *models.Table, *bool) error
+	DeleteTable(context.Context, uint) error
+
+	CreateTableCombination(context.Context, *models.TableCombination) (uint, error)
+	GetTableCombinationsByRestaurantID(context.Context, uint) ([]*models.TableCombination, error)
+	DeleteTableCombination(ctx context.Context, restaurantID, id uint) error
+
+	IsOwnerOfRestaurant(context.Context, uint, uint) (bool, error)
+}
+
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// MethodAccess is the access required by every gRPC method
+var MethodAccess = map[string]interceptors.Access{
+	bookingv1.AuthService_Register_FullMethodName: interceptors.Public,
+	bookingv1.AuthService_Login_FullMethodName:    interceptors.Public,
+
+	bookingv1.UserService_GetUsers_FullMethodName:       interceptors.Admin,
+	bookingv1.UserService_GetUserByID_FullMethodName:    interceptors.Admin,
+	bookingv1.UserService_GetUserByLogin_FullMethodName: interceptors.Admin,
+	bookingv1.UserService_CreateUser_FullMethodName:     interceptors.Admin,
+	bookingv1.UserService_UpdateUser_FullMethodName:     interceptors.Admin,
+	bookingv1.UserService_DeleteUser_FullMethodName:     interceptors.Admin,
+
+	bookingv1.RestaurantService_GetRestaurants_FullMethodName:     interceptors.Public,
+	bookingv1.RestaurantService_GetRestaurant_FullMethodName:      interceptors.Public,
+	bookingv1.RestaurantService_GetTables_FullMethodName:          interceptors.Public,
+	bookingv1.RestaurantService_GetTable_FullMethodName:           interceptors.Public,
+	bookingv1.RestaurantService_CreateRestaurant_FullMethodName:   interceptors.Owner,
+	bookingv1.RestaurantService_UpdateRestaurant_FullMethodName:   interceptors.Owner,
+	bookingv1.RestaurantService_DeleteRestaurant_FullMethodName:   interceptors.Owner,
+	bookingv1.RestaurantService_UpdateBookingRules_FullMethodName: interceptors.Owner,
+	bookingv1.RestaurantService_CreateTable_FullMethodName:        interceptors.Owner,
+	bookingv1.RestaurantService_UpdateTable_FullMethodName:        interceptors.Owner,
+	bookingv1.RestaurantService_DeleteTable_FullMethodName:        interceptors.Owner,
+
+	bookingv1.RestaurantService_GetTableCombinations_FullMethodName:   interceptors.Public,
+	bookingv1.RestaurantService_CreateTableCombination_FullMethodName: interceptors.Owner,
+	bookingv1.RestaurantService_DeleteTableCombination_FullMethodName: interceptors.Owner,
+}
+
+// MethodRateLimits are the rate limit policies of the methods limited per client IP,
+// they share the buckets of the HTTP routes with the same policy
+var MethodRateLimits = map[string]string{
+	bookingv1.AuthService_Register_FullMethodName: middleware.PolicyAuth,
+	bookingv1.AuthService_Login_FullMethodName:    middleware.PolicyAuth,
+}
+
+// NewServer creates a gRPC server with all booking services registered, limiter may be nil to disable rate limiting
+func NewServer(
+	userService UserService,
+	authService AuthService,
+	restaurantService RestaurantService,
+	limiter *middleware.RateLimiter,
+	logger Logger,
+) *grpc.Server {
+	s := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			interceptors.RecoveryInterceptor(logger),
+			interceptors.RateLimitInterceptor(limiter, MethodRateLimits),
+			interceptors.AuthInterceptor(MethodAccess),
+		),
+	)
+
+	bookingv1.RegisterUserServiceServer(s, NewUserServer(userService, logger))
+	bookingv1.RegisterAuthServiceServer(s, NewAuthServer(authService, logger))
+	bookingv1.RegisterRestaurantServiceServer(s, NewRestaurantServer(restaurantService, logger))
+
+	return s
+}
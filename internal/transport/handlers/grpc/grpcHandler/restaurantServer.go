@@ -0,0 +1,509 @@
+package grpcHandler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	bookingv1 "github.com/kourai55k/booking-service/pkg/api/booking/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RestaurantServer struct {
+	bookingv1.UnimplementedRestaurantServiceServer
+	restaurantService RestaurantService
+	logger            Logger
+}
+
+func NewRestaurantServer(restaurantService RestaurantService, logger Logger) *RestaurantServer {
+	return &RestaurantServer{restaurantService: restaurantService, logger: logger}
+}
+
+// Restaurants management
+func (s *RestaurantServer) CreateRestaurant(ctx context.Context, req *bookingv1.CreateRestaurantRequest) (*bookingv1.CreateRestaurantResponse, error) {
+	const op = "grpc.RestaurantServer.CreateRestaurant"
+
+	if req.GetName() == "" || req.GetAddress() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	userID, ok := ctx.Value(domain.UserIDKey).(uint)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user ID not found in context")
+	}
+
+	id, err := s.restaurantService.CreateRestaurant(ctx, &models.Restaurant{
+		Name:            req.GetName(),
+		Description:     req.GetDescription(),
+		Address:         req.GetAddress(),
+		OpeningHours:    fromProtoOpeningHours(req.GetOpeningHours()),
+		TimeZone:        req.GetTimeZone(),
+		TableAssignment: models.TableAssignment(req.GetTableAssignment()),
+		BookingRules:    fromProtoBookingRules(req.GetBookingRules()),
+		OwnerID:         userID,
+	})
+	if err != nil {
+		s.logger.Error("failed to create restaurant", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.CreateRestaurantResponse{Id: uint64(id)}, nil
+}
+
+func (s *RestaurantServer) GetRestaurants(ctx context.Context, _ *bookingv1.GetRestaurantsRequest) (*bookingv1.GetRestaurantsResponse, error) {
+	const op = "grpc.RestaurantServer.GetRestaurants"
+
+	restaurants, err := s.restaurantService.GetRestaurants(ctx)
+	if err != nil {
+		s.logger.Error("failed to get restaurants", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	res := &bookingv1.GetRestaurantsResponse{Restaurants: make([]*bookingv1.Restaurant, 0, len(restaurants))}
+	for _, r := range restaurants {
+		res.Restaurants = append(res.Restaurants, toProtoRestaurant(r))
+	}
+
+	return res, nil
+}
+
+func (s *RestaurantServer) GetRestaurant(ctx context.Context, req *bookingv1.GetRestaurantRequest) (*bookingv1.GetRestaurantResponse, error) {
+	const op = "grpc.RestaurantServer.GetRestaurant"
+
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	restaurant, err := s.restaurantService.GetRestaurantByID(ctx, uint(req.GetId()))
+	if err != nil {
+		s.logger.Error("failed to get restaurant", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.GetRestaurantResponse{Restaurant: toProtoRestaurant(restaurant)}, nil
+}
+
+func (s *RestaurantServer) UpdateRestaurant(ctx context.Context, req *bookingv1.UpdateRestaurantRequest) (*bookingv1.UpdateRestaurantResponse, error) {
+	const op = "grpc.RestaurantServer.UpdateRestaurant"
+
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if err := s.checkOwner(ctx, op, uint(req.GetId())); err != nil {
+		return nil, err
+	}
+
+	err := s.restaurantService.UpdateRestraunt(ctx, &models.Restaurant{
+		ID:              uint(req.GetId()),
+		Name:            req.GetName(),
+		Description:     req.GetDescription(),
+		Address:         req.GetAddress(),
+		OpeningHours:    fromProtoOpeningHours(req.GetOpeningHours()),
+		TimeZone:        req.GetTimeZone(),
+		TableAssignment: models.TableAssignment(req.GetTableAssignment()),
+	})
+	if err != nil {
+		s.logger.Error("failed to update restaurant", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.UpdateRestaurantResponse{}, nil
+}
+
+func (s *RestaurantServer) DeleteRestaurant(ctx context.Context, req *bookingv1.DeleteRestaurantRequest) (*bookingv1.DeleteRestaurantResponse, error) {
+	const op = "grpc.RestaurantServer.DeleteRestaurant"
+
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if err := s.checkOwner(ctx, op, uint(req.GetId())); err != nil {
+		return nil, err
+	}
+
+	if err := s.restaurantService.DeleteRestraunt(ctx, uint(req.GetId())); err != nil {
+		s.logger.Error("failed to delete restaurant", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.DeleteRestaurantResponse{}, nil
+}
+
+func (s *RestaurantServer) UpdateBookingRules(
+	ctx context.Context,
+	req *bookingv1.UpdateBookingRulesRequest,
+) (*bookingv1.UpdateBookingRulesResponse, error) {
+	const op = "grpc.RestaurantServer.UpdateBookingRules"
+
+	if req.GetRestaurantId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "restaurant_id is required")
+	}
+
+	if err := s.checkOwner(ctx, op, uint(req.GetRestaurantId())); err != nil {
+		return nil, err
+	}
+
+	err := s.restaurantService.UpdateBookingRules(ctx, uint(req.GetRestaurantId()), fromProtoBookingRules(req.GetBookingRules()))
+	if err != nil {
+		s.logger.Error("failed to update booking rules", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.UpdateBookingRulesResponse{}, nil
+}
+
+// Tables management
+func (s *RestaurantServer) CreateTable(ctx context.Context, req *bookingv1.CreateTableRequest) (*bookingv1.CreateTableResponse, error) {
+	const op = "grpc.RestaurantServer.CreateTable"
+
+	if req.GetRestaurantId() == 0 || req.GetNumber() == 0 || req.GetCapacity() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	if err := s.checkOwner(ctx, op, uint(req.GetRestaurantId())); err != nil {
+		return nil, err
+	}
+
+	id, err := s.restaurantService.CreateTable(ctx, &models.Table{
+		Number:       uint(req.GetNumber()),
+		Capacity:     uint(req.GetCapacity()),
+		IsAvailable:  true,
+		MinPartySize: uint(req.GetMinPartySize()),
+		Attributes:   req.GetAttributes(),
+		RestaurantID: uint(req.GetRestaurantId()),
+	})
+	if err != nil {
+		s.logger.Error("failed to create table", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.CreateTableResponse{Id: uint64(id)}, nil
+}
+
+func (s *RestaurantServer) GetTables(ctx context.Context, req *bookingv1.GetTablesRequest) (*bookingv1.GetTablesResponse, error) {
+	const op = "grpc.RestaurantServer.GetTables"
+
+	if req.GetRestaurantId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "restaurant_id is required")
+	}
+
+	var tables []*models.Table
+	var err error
+	if req.GetOnlyAvailable() {
+		tables, err = s.restaurantService.GetAvailableTablesByRestaurantID(ctx, uint(req.GetRestaurantId()))
+	} else {
+		tables, err = s.restaurantService.GetTablesByRestaurantID(ctx, uint(req.GetRestaurantId()))
+	}
+	if err != nil {
+		s.logger.Error("failed to get tables", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	res := &bookingv1.GetTablesResponse{Tables: make([]*bookingv1.Table, 0, len(tables))}
+	for _, t := range tables {
+		res.Tables = append(res.Tables, toProtoTable(t))
+	}
+
+	return res, nil
+}
+
+func (s *RestaurantServer) GetTable(ctx context.Context, req *bookingv1.GetTableRequest) (*bookingv1.GetTableResponse, error) {
+	const op = "grpc.RestaurantServer.GetTable"
+
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	table, err := s.restaurantService.GetTableByID(ctx, uint(req.GetId()))
+	if err != nil {
+		s.logger.Error("failed to get table", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.GetTableResponse{Table: toProtoTable(table)}, nil
+}
+
+func (s *RestaurantServer) UpdateTable(ctx context.Context, req *bookingv1.UpdateTableRequest) (*bookingv1.UpdateTableResponse, error) {
+	const op = "grpc.RestaurantServer.UpdateTable"
+
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if err := s.checkTableOwner(ctx, op, uint(req.GetId())); err != nil {
+		return nil, err
+	}
+
+	table := &models.Table{
+		ID:           uint(req.GetId()),
+		Number:       uint(req.GetNumber()),
+		Capacity:     uint(req.GetCapacity()),
+		MinPartySize: uint(req.GetMinPartySize()),
+	}
+	if req.GetAttributes() != nil {
+		table.Attributes = append([]string{}, req.GetAttributes().GetValues()...)
+	}
+
+	err := s.restaurantService.UpdateTable(ctx, table, req.IsAvailable)
+	if err != nil {
+		s.logger.Error("failed to update table", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.UpdateTableResponse{}, nil
+}
+
+func (s *RestaurantServer) DeleteTable(ctx context.Context, req *bookingv1.DeleteTableRequest) (*bookingv1.DeleteTableResponse, error) {
+	const op = "grpc.RestaurantServer.DeleteTable"
+
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if err := s.checkTableOwner(ctx, op, uint(req.GetId())); err != nil {
+		return nil, err
+	}
+
+	if err := s.restaurantService.DeleteTable(ctx, uint(req.GetId())); err != nil {
+		s.logger.Error("failed to delete table", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.DeleteTableResponse{}, nil
+}
+
+// Table combinations management
+func (s *RestaurantServer) CreateTableCombination(
+	ctx context.Context,
+	req *bookingv1.CreateTableCombinationRequest,
+) (*bookingv1.CreateTableCombinationResponse, error) {
+	const op = "grpc.RestaurantServer.CreateTableCombination"
+
+	if req.GetRestaurantId() == 0 || len(req.GetTableIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+
+	if err := s.checkOwner(ctx, op, uint(req.GetRestaurantId())); err != nil {
+		return nil, err
+	}
+
+	combination := &models.TableCombination{
+		TableIDs:     make([]uint, 0, len(req.GetTableIds())),
+		Capacity:     uint(req.GetCapacity()),
+		RestaurantID: uint(req.GetRestaurantId()),
+	}
+	for _, id := range req.GetTableIds() {
+		combination.TableIDs = append(combination.TableIDs, uint(id))
+	}
+
+	id, err := s.restaurantService.CreateTableCombination(ctx, combination)
+	if err != nil {
+		s.logger.Error("failed to create table combination", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.CreateTableCombinationResponse{Id: uint64(id)}, nil
+}
+
+func (s *RestaurantServer) GetTableCombinations(
+	ctx context.Context,
+	req *bookingv1.GetTableCombinationsRequest,
+) (*bookingv1.GetTableCombinationsResponse, error) {
+	const op = "grpc.RestaurantServer.GetTableCombinations"
+
+	if req.GetRestaurantId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "restaurant_id is required")
+	}
+
+	combinations, err := s.restaurantService.GetTableCombinationsByRestaurantID(ctx, uint(req.GetRestaurantId()))
+	if err != nil {
+		s.logger.Error("failed to get table combinations", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	res := &bookingv1.GetTableCombinationsResponse{Combinations: make([]*bookingv1.TableCombination, 0, len(combinations))}
+	for _, c := range combinations {
+		res.Combinations = append(res.Combinations, toProtoTableCombination(c))
+	}
+
+	return res, nil
+}
+
+func (s *RestaurantServer) DeleteTableCombination(
+	ctx context.Context,
+	req *bookingv1.DeleteTableCombinationRequest,
+) (*bookingv1.DeleteTableCombinationResponse, error) {
+	const op = "grpc.RestaurantServer.DeleteTableCombination"
+
+	if req.GetRestaurantId() == 0 || req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "restaurant_id and id are required")
+	}
+
+	if err := s.checkOwner(ctx, op, uint(req.GetRestaurantId())); err != nil {
+		return nil, err
+	}
+
+	if err := s.restaurantService.DeleteTableCombination(ctx, uint(req.GetRestaurantId()), uint(req.GetId())); err != nil {
+		s.logger.Error("failed to delete table combination", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.DeleteTableCombinationResponse{}, nil
+}
+
+// checkOwner returns a status error unless the caller owns the restaurant or is an admin
+func (s *RestaurantServer) checkOwner(ctx context.Context, op string, restaurantID uint) error {
+	if role, _ := ctx.Value(domain.RoleKey).(string); role == "admin" {
+		return nil
+	}
+
+	userID, ok := ctx.Value(domain.UserIDKey).(uint)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "user ID not found in context")
+	}
+
+	isOwner, err := s.restaurantService.IsOwnerOfRestaurant(ctx, userID, restaurantID)
+	if err != nil {
+		s.logger.Error("failed to verify if user is owner", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return toStatus(err)
+	}
+	if !isOwner {
+		return status.Error(codes.PermissionDenied, "user is not the owner of the restaurant")
+	}
+
+	return nil
+}
+
+// checkTableOwner is checkOwner for the restaurant the table belongs to
+func (s *RestaurantServer) checkTableOwner(ctx context.Context, op string, tableID uint) error {
+	table, err := s.restaurantService.GetTableByID(ctx, tableID)
+	if err != nil {
+		s.logger.Error("failed to get table", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return toStatus(err)
+	}
+
+	return s.checkOwner(ctx, op, table.RestaurantID)
+}
+
+func toProtoRestaurant(r *models.Restaurant) *bookingv1.Restaurant {
+	res := &bookingv1.Restaurant{
+		Id:              uint64(r.ID),
+		Name:            r.Name,
+		Description:     r.Description,
+		Address:         r.Address,
+		OwnerId:         uint64(r.OwnerID),
+		OpeningHours:    make([]*bookingv1.OpeningHours, 0, len(r.OpeningHours)),
+		TimeZone:        r.TimeZone,
+		TableAssignment: string(r.TableAssignment),
+		BookingRules:    toProtoBookingRules(r.BookingRules),
+	}
+	for _, oh := range r.OpeningHours {
+		res.OpeningHours = append(res.OpeningHours, &bookingv1.OpeningHours{
+			DayOfWeek: oh.DayOfWeek,
+			OpenTime:  oh.OpenTime,
+			CloseTime: oh.CloseTime,
+		})
+	}
+	return res
+}
+
+func fromProtoOpeningHours(hours []*bookingv1.OpeningHours) []models.OpeningHours {
+	res := make([]models.OpeningHours, 0, len(hours))
+	for _, oh := range hours {
+		res = append(res, models.OpeningHours{
+			DayOfWeek: oh.GetDayOfWeek(),
+			OpenTime:  oh.GetOpenTime(),
+			CloseTime: oh.GetCloseTime(),
+		})
+	}
+	return res
+}
+
+func toProtoTable(t *models.Table) *bookingv1.Table {
+	return &bookingv1.Table{
+		Id:           uint64(t.ID),
+		Number:       uint64(t.Number),
+		Capacity:     uint64(t.Capacity),
+		IsAvailable:  t.IsAvailable,
+		RestaurantId: uint64(t.RestaurantID),
+		MinPartySize: uint64(t.MinPartySize),
+		Attributes:   t.Attributes,
+	}
+}
+
+func toProtoTableCombination(c *models.TableCombination) *bookingv1.TableCombination {
+	res := &bookingv1.TableCombination{
+		Id:           uint64(c.ID),
+		TableIds:     make([]uint64, 0, len(c.TableIDs)),
+		Capacity:     uint64(c.Capacity),
+		RestaurantId: uint64(c.RestaurantID),
+	}
+	for _, id := range c.TableIDs {
+		res.TableIds = append(res.TableIds, uint64(id))
+	}
+	return res
+}
+
+func toProtoBookingRules(r models.BookingRules) *bookingv1.BookingRules {
+	res := &bookingv1.BookingRules{
+		Durations:             make([]*bookingv1.SeatingDuration, 0, len(r.Durations)),
+		BufferMinutes:         uint64(r.Buffer / time.Minute),
+		MinLeadMinutes:        uint64(r.MinLeadTime / time.Minute),
+		MaxDaysAhead:          uint64(r.MaxDaysAhead),
+		LastSeatingMinutes:    uint64(r.LastSeating / time.Minute),
+		MinPartySize:          uint64(r.MinPartySize),
+		MaxPartySize:          uint64(r.MaxPartySize),
+		MaxCoversPerInterval:  uint64(r.MaxCoversPerInterval),
+		MaxPartiesPerInterval: uint64(r.MaxPartiesPerInterval),
+		ChangePolicy: &bookingv1.ChangePolicy{
+			CancellationCutoffMinutes: uint64(r.ChangePolicy.CancellationCutoff / time.Minute),
+			CancellationFee:           uint64(r.ChangePolicy.CancellationFee),
+			ModificationCutoffMinutes: uint64(r.ChangePolicy.ModificationCutoff / time.Minute),
+			ModificationFee:           uint64(r.ChangePolicy.ModificationFee),
+			MaxModifications:          uint64(r.ChangePolicy.MaxModifications),
+		},
+	}
+	for _, d := range r.Durations {
+		res.Durations = append(res.Durations, &bookingv1.SeatingDuration{
+			MaxPartySize: uint64(d.MaxPartySize),
+			Minutes:      uint64(d.Duration / time.Minute),
+		})
+	}
+	return res
+}
+
+// fromProtoBookingRules converts the rules, nil ones are no rules
+func fromProtoBookingRules(r *bookingv1.BookingRules) models.BookingRules {
+	res := models.BookingRules{
+		Buffer:                minutes(r.GetBufferMinutes()),
+		MinLeadTime:           minutes(r.GetMinLeadMinutes()),
+		MaxDaysAhead:          uint(r.GetMaxDaysAhead()),
+		LastSeating:           minutes(r.GetLastSeatingMinutes()),
+		MinPartySize:          uint(r.GetMinPartySize()),
+		MaxPartySize:          uint(r.GetMaxPartySize()),
+		MaxCoversPerInterval:  uint(r.GetMaxCoversPerInterval()),
+		MaxPartiesPerInterval: uint(r.GetMaxPartiesPerInterval()),
+		ChangePolicy: models.ChangePolicy{
+			CancellationCutoff: minutes(r.GetChangePolicy().GetCancellationCutoffMinutes()),
+			CancellationFee:    uint(r.GetChangePolicy().GetCancellationFee()),
+			ModificationCutoff: minutes(r.GetChangePolicy().GetModificationCutoffMinutes()),
+			ModificationFee:    uint(r.GetChangePolicy().GetModificationFee()),
+			MaxModifications:   uint(r.GetChangePolicy().GetMaxModifications()),
+		},
+	}
+	for _, d := range r.GetDurations() {
+		res.Durations = append(res.Durations, models.SeatingDuration{
+			MaxPartySize: uint(d.GetMaxPartySize()),
+			Duration:     minutes(d.GetMinutes()),
+		})
+	}
+	return res
+}
+
+func minutes(n uint64) time.Duration {
+	return time.Duration(n) * time.Minute
+}
@@ -0,0 +1,164 @@
+package grpcHandler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	bookingv1 "github.com/kourai55k/booking-service/pkg/api/booking/v1"
+	"github.com/kourai55k/booking-service/pkg/hashing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type UserServer struct {
+	bookingv1.UnimplementedUserServiceServer
+	userService UserService
+	logger      Logger
+}
+
+func NewUserServer(userService UserService, logger Logger) *UserServer {
+	return &UserServer{userService: userService, logger: logger}
+}
+
+func (s *UserServer) GetUsers(ctx context.Context, _ *bookingv1.GetUsersRequest) (*bookingv1.GetUsersResponse, error) {
+	const op = "grpc.UserServer.GetUsers"
+
+	users, err := s.userService.GetUsers(ctx)
+	if err != nil {
+		s.logger.Error("failed to get users", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	res := &bookingv1.GetUsersResponse{Users: make([]*bookingv1.User, 0, len(users))}
+	for _, u := range users {
+		res.Users = append(res.Users, toProtoUser(u))
+	}
+
+	return res, nil
+}
+
+func (s *UserServer) GetUserByID(ctx context.Context, req *bookingv1.GetUserByIDRequest) (*bookingv1.GetUserResponse, error) {
+	const op = "grpc.UserServer.GetUserByID"
+
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	user, err := s.userService.GetUserByID(ctx, uint(req.GetId()))
+	if err != nil {
+		s.logger.Error("failed to get user by id", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.GetUserResponse{User: toProtoUser(user)}, nil
+}
+
+func (s *UserServer) GetUserByLogin(ctx context.Context, req *bookingv1.GetUserByLoginRequest) (*bookingv1.GetUserResponse, error) {
+	const op = "grpc.UserServer.GetUserByLogin"
+
+	if req.GetLogin() == "" {
+		return nil, status.Error(codes.InvalidArgument, "login is required")
+	}
+
+	user, err := s.userService.GetUserByLogin(ctx, req.GetLogin())
+	if err != nil {
+		s.logger.Error("failed to get user by login", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.GetUserResponse{User: toProtoUser(user)}, nil
+}
+
+func (s *UserServer) CreateUser(ctx context.Context, req *bookingv1.CreateUserRequest) (*bookingv1.CreateUserResponse, error) {
+	const op = "grpc.UserServer.CreateUser"
+
+	if req.GetName() == "" || req.GetLogin() == "" || req.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing required fields")
+	}
+	if len(req.GetPassword()) < domain.MinPasswordLength {
+		return nil, status.Error(codes.InvalidArgument, "password must be at least 8 characters long")
+	}
+
+	hashPass, err := hashing.HashPassword(req.GetPassword())
+	if err != nil {
+		s.logger.Error("failed to hash password", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	id, err := s.userService.CreateUser(ctx, &models.User{
+		Name:     req.GetName(),
+		Login:    req.GetLogin(),
+		HashPass: hashPass,
+		Role:     req.GetRole(),
+	})
+	if err != nil {
+		s.logger.Error("failed to create user", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.CreateUserResponse{Id: uint64(id)}, nil
+}
+
+func (s *UserServer) UpdateUser(ctx context.Context, req *bookingv1.UpdateUserRequest) (*bookingv1.UpdateUserResponse, error) {
+	const op = "grpc.UserServer.UpdateUser"
+
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.GetName() == "" && req.GetLogin() == "" && req.GetPassword() == "" && req.GetRole() == "" {
+		return nil, status.Error(codes.InvalidArgument, "at least one field is required")
+	}
+
+	user := &models.User{
+		ID:    uint(req.GetId()),
+		Name:  req.GetName(),
+		Login: req.GetLogin(),
+		Role:  req.GetRole(),
+	}
+
+	// Only hash the password when it's being changed, an empty HashPass keeps the old one
+	if req.GetPassword() != "" {
+		if len(req.GetPassword()) < domain.MinPasswordLength {
+			return nil, status.Error(codes.InvalidArgument, "password must be at least 8 characters long")
+		}
+		hashPass, err := hashing.HashPassword(req.GetPassword())
+		if err != nil {
+			s.logger.Error("failed to hash password", "error", fmt.Errorf("%s: %w", op, err).Error())
+			return nil, status.Error(codes.Internal, "internal server error")
+		}
+		user.HashPass = hashPass
+	}
+
+	if err := s.userService.UpdateUser(ctx, user); err != nil {
+		s.logger.Error("failed to update user", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.UpdateUserResponse{}, nil
+}
+
+func (s *UserServer) DeleteUser(ctx context.Context, req *bookingv1.DeleteUserRequest) (*bookingv1.DeleteUserResponse, error) {
+	const op = "grpc.UserServer.DeleteUser"
+
+	if req.GetId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if err := s.userService.DeleteUser(ctx, uint(req.GetId())); err != nil {
+		s.logger.Error("failed to delete user", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return nil, toStatus(err)
+	}
+
+	return &bookingv1.DeleteUserResponse{}, nil
+}
+
+func toProtoUser(u *models.User) *bookingv1.User {
+	return &bookingv1.User{
+		Id:    uint64(u.ID),
+		Name:  u.Name,
+		Login: u.Login,
+		Role:  u.Role,
+	}
+}
@@ -0,0 +1,77 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	jwthelper "github.com/kourai55k/booking-service/pkg/jwtHelper"
+	"github.com/kourai55k/booking-service/pkg/logctx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Access is the level of access required to call a method
+type Access int
+
+const (
+	// Public methods can be called without a token
+	Public Access = iota
+	// Authenticated methods require any valid token
+	Authenticated
+	// Owner methods require an "owner" or "admin" token
+	Owner
+	// Admin methods require an "admin" token
+	Admin
+)
+
+// AuthInterceptor checks the JWT from the "authorization" metadata against the access
+// required by the called method. Methods missing from the policy require an admin token.
+// On success the userID and role are added to the context, like AuthMiddleware does for HTTP.
+func AuthInterceptor(policy map[string]Access) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		access, ok := policy[info.FullMethod]
+		if !ok {
+			access = Admin
+		}
+		if access == Public {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "missing authorization metadata")
+		}
+
+		// Expected format: "Bearer <token>"
+		parts := strings.Split(values[0], " ")
+		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+		}
+
+		claims, err := jwthelper.ParseToken(parts[1])
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		switch access {
+		case Owner:
+			if claims.Role != "owner" && claims.Role != "admin" {
+				return nil, status.Error(codes.PermissionDenied, "owner access required")
+			}
+		case Admin:
+			if claims.Role != "admin" {
+				return nil, status.Error(codes.PermissionDenied, "admin access required")
+			}
+		}
+
+		ctx = context.WithValue(ctx, domain.UserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, domain.RoleKey, claims.Role)
+		ctx = logctx.WithUserID(ctx, claims.UserID)
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,52 @@
+package interceptors
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimiter takes a token of the named policy for a client IP, ok is false if the call must be rejected
+type RateLimiter interface {
+	Allow(ctx context.Context, policy, ip string) (retryAfter time.Duration, ok bool)
+}
+
+// RateLimitInterceptor limits the calls of the methods in policies per peer IP, with the policy named for the method.
+// Rejected calls fail with ResourceExhausted and a "retry-after" header in seconds, other methods aren't limited.
+func RateLimitInterceptor(limiter RateLimiter, policies map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		policy, ok := policies[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		retryAfter, ok := limiter.Allow(ctx, policy, peerIP(ctx))
+		if !ok {
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))))
+			return nil, status.Error(codes.ResourceExhausted, "too many requests")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// peerIP returns the IP of the peer of the call, its whole address if it has no port
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -1,10 +1,16 @@
 package authHandler
 
-import "github.com/kourai55k/booking-service/internal/domain/models"
+import (
+	"context"
+	"net/http"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/pkg/logctx"
+)
 
 type AuthService interface {
-	Register(user *models.User) (uint, error)
-	Login(login, password string) (string, error)
+	Register(ctx context.Context, user *models.User) (uint, error)
+	Login(ctx context.Context, login, password string) (string, error)
 }
 
 type Logger interface {
@@ -22,3 +28,11 @@ type AuthHandler struct {
 func NewAuthHandler(authService AuthService, logger Logger) *AuthHandler {
 	return &AuthHandler{authService: authService, logger: logger}
 }
+
+// loggerFor returns the request-scoped logger set by RequestIDMiddleware, or the handler's own logger
+func (h *AuthHandler) loggerFor(r *http.Request) Logger {
+	if log := logctx.FromContext(r.Context()); log != nil {
+		return log
+	}
+	return h.logger
+}
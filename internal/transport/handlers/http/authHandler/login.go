@@ -28,9 +28,7 @@ func (r loginRequest) Validate() error {
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	const op = "http.AuthHandler.Login"
 
-	log := h.logger
-
-	log.Debug("request received", "method", r.Method, "path", r.URL.Path)
+	log := h.loggerFor(r)
 
 	var req loginRequest
 	decoder := json.NewDecoder(r.Body)
@@ -49,16 +47,12 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.authService.Login(req.Login, req.Password)
+	token, err := h.authService.Login(r.Context(), req.Login, req.Password)
 	if err != nil {
-		if errors.Is(err, domain.ErrUserNotFound) {
-			http.Error(w, "there is no user with this login", http.StatusUnauthorized)
-			log.Error("user not found", "err", fmt.Errorf("%s: %w", op, err).Error())
-			return
-		}
-		if errors.Is(err, domain.ErrWrongPassword) {
-			http.Error(w, "wrong password", http.StatusUnauthorized)
-			log.Error("wrong password", "err", fmt.Errorf("%s: %w", op, err).Error())
+		// an unknown login fails like a wrong password, so callers can't find out which logins exist
+		if errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrWrongPassword) {
+			http.Error(w, "invalid login or password", http.StatusUnauthorized)
+			log.Error("invalid credentials", "err", fmt.Errorf("%s: %w", op, err).Error())
 			return
 		}
 		http.Error(w, "failed to login user: internal server error", http.StatusInternalServerError)
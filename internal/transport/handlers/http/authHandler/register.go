@@ -34,9 +34,7 @@ type registerResponse struct {
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	const op = "http.AuthHandler.Register"
 
-	log := h.logger
-
-	log.Debug("request received", "method", r.Method, "path", r.URL.Path)
+	log := h.loggerFor(r)
 
 	var req registerRequest
 	decoder := json.NewDecoder(r.Body)
@@ -69,13 +67,18 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		Role:     "user",
 	}
 
-	id, err := h.authService.Register(user)
+	id, err := h.authService.Register(r.Context(), user)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserAlreadyExists) {
 			http.Error(w, "user already exists", http.StatusConflict)
 			log.Error("user already exists", "error", fmt.Errorf("%s: %w", op, err).Error())
 			return
 		}
+		if errors.Is(err, domain.ErrRegistrationDisabled) {
+			http.Error(w, "registration is disabled", http.StatusForbidden)
+			log.Warn("registration is disabled", "error", fmt.Errorf("%s: %w", op, err).Error())
+			return
+		}
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 		log.Error("failed to create user", "error", fmt.Errorf("%s:%w", op, err).Error())
 		return
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/kourai55k/booking-service/pkg/logctx"
+)
+
+// AccessLogMiddleware logs every request with its status, response size and duration.
+// It must run after RequestIDMiddleware to log the request ID.
+func AccessLogMiddleware(log *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := newResponseRecorder(w)
+
+		next.ServeHTTP(rw, r)
+
+		reqLog := logctx.FromContext(r.Context())
+		if reqLog == nil {
+			reqLog = log
+		}
+
+		level := slog.LevelInfo
+		if rw.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		reqLog.Log(r.Context(), level, "request completed",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rw.status,
+			"bytes", rw.bytes,
+			"duration", time.Since(start).String(),
+			"remote_addr", r.RemoteAddr,
+			"user_agent", r.UserAgent(),
+		)
+	})
+}
@@ -1,11 +1,9 @@
 package middleware
 
 import (
-	"context"
 	"net/http"
 	"strings"
 
-	"github.com/kourai55k/booking-service/internal/domain"
 	jwthelper "github.com/kourai55k/booking-service/pkg/jwtHelper"
 )
 
@@ -42,8 +40,7 @@ func AdminMiddleware(next http.Handler) http.Handler {
 		}
 
 		// Add userID and role to context
-		ctx := context.WithValue(r.Context(), domain.UserIDKey, claims.UserID)
-		ctx = context.WithValue(ctx, domain.RoleKey, claims.Role)
+		ctx := withUser(r.Context(), claims)
 
 		// Continue request with updated context
 		next.ServeHTTP(w, r.WithContext(ctx))
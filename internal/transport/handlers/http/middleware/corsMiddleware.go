@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"sync/atomic"
+)
+
+const (
+	corsAllowMethods  = "GET, POST, PATCH, DELETE"
+	corsAllowHeaders  = "Authorization, Content-Type, " + RequestIDHeader
+	corsExposeHeaders = RequestIDHeader + ", RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, Retry-After"
+	corsMaxAge        = "600"
+)
+
+// CORS lets browsers on the allowed origins call the API.
+// The origins can be changed while the service runs.
+type CORS struct {
+	allowedOrigins atomic.Pointer[[]string]
+}
+
+// NewCORS creates the CORS middleware, "*" in allowedOrigins allows any origin
+func NewCORS(allowedOrigins []string) *CORS {
+	c := &CORS{}
+	c.SetAllowedOrigins(allowedOrigins)
+	return c
+}
+
+// SetAllowedOrigins replaces the allowed origins
+func (c *CORS) SetAllowedOrigins(allowedOrigins []string) {
+	origins := slices.Clone(allowedOrigins)
+	c.allowedOrigins.Store(&origins)
+}
+
+// Middleware adds the CORS headers for allowed origins and answers preflight requests
+func (c *CORS) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		allowed := c.Allowed(origin)
+		if allowed {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Expose-Headers", corsExposeHeaders)
+		}
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Methods", corsAllowMethods)
+				w.Header().Set("Access-Control-Allow-Headers", corsAllowHeaders)
+				w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Allowed reports whether origin is one of the allowed origins
+func (c *CORS) Allowed(origin string) bool {
+	origins := *c.allowedOrigins.Load()
+	return slices.Contains(origins, "*") || slices.Contains(origins, origin)
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import "net/http"
+
+// MaxBodyMiddleware rejects requests with a body larger than limit bytes.
+// Bodies of unknown length are cut off at the limit, so decoding them fails.
+func MaxBodyMiddleware(limit int64, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > limit {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, limit)
+		next.ServeHTTP(w, r)
+	})
+}
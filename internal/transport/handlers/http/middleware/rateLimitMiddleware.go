@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/ratelimit"
+)
+
+// rate limit policy names
+const (
+	// PolicyDefault applies to every request, per client IP
+	PolicyDefault = "default"
+	// PolicyAuth applies to login and registration, per client IP
+	PolicyAuth = "auth"
+	// PolicyUser applies to authenticated routes, per user
+	PolicyUser = "user"
+)
+
+type Logger interface {
+	Error(msg string, args ...interface{})
+}
+
+// RateLimiter limits requests with token buckets kept in a ratelimit.Store.
+// A nil *RateLimiter doesn't limit anything.
+type RateLimiter struct {
+	store          ratelimit.Store
+	policies       atomic.Pointer[map[string]ratelimit.Policy]
+	trustedProxies []netip.Prefix
+	log            Logger
+}
+
+// NewRateLimiter creates a rate limiter with the named policies.
+// X-Forwarded-For is only trusted for requests coming from trustedProxies.
+func NewRateLimiter(
+	store ratelimit.Store,
+	policies map[string]ratelimit.Policy,
+	trustedProxies []netip.Prefix,
+	log Logger,
+) *RateLimiter {
+	l := &RateLimiter{store: store, trustedProxies: trustedProxies, log: log}
+	l.policies.Store(&policies)
+	return l
+}
+
+// SetPolicies replaces the policies, requests already being limited keep the old ones
+func (l *RateLimiter) SetPolicies(policies map[string]ratelimit.Policy) {
+	if l == nil {
+		return
+	}
+	l.policies.Store(&policies)
+}
+
+// LimitByIP limits requests to next per client IP
+func (l *RateLimiter) LimitByIP(policy string, next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.limit(w, r, next, policy, "ip:"+ClientIP(r, l.trustedProxies))
+	})
+}
+
+// LimitByUser limits requests to next per authenticated user, it must run after an auth middleware.
+// Anonymous requests are limited per client IP.
+func (l *RateLimiter) LimitByUser(policy string, next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := "ip:" + ClientIP(r, l.trustedProxies)
+		if userID, ok := r.Context().Value(domain.UserIDKey).(uint); ok {
+			key = "user:" + strconv.FormatUint(uint64(userID), 10)
+		}
+		l.limit(w, r, next, policy, key)
+	})
+}
+
+func (l *RateLimiter) limit(w http.ResponseWriter, r *http.Request, next http.Handler, policyName, key string) {
+	res, limited := l.take(r.Context(), policyName, key)
+	if !limited {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+
+	if !res.Allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+		http.Error(w, "too many requests", http.StatusTooManyRequests)
+		return
+	}
+
+	next.ServeHTTP(w, r)
+}
+
+// Allow takes a token of the named policy for the client IP, for callers other than HTTP handlers like gRPC.
+// The buckets are the ones of LimitByIP, so a client shares them across both. It returns how long to wait
+// before retrying if the request must be rejected.
+func (l *RateLimiter) Allow(ctx context.Context, policyName, ip string) (retryAfter time.Duration, ok bool) {
+	if l == nil {
+		return 0, true
+	}
+
+	res, limited := l.take(ctx, policyName, "ip:"+ip)
+	if !limited || res.Allowed {
+		return 0, true
+	}
+	return res.RetryAfter, false
+}
+
+// take takes a token of the named policy for key, limited is false if the policy is off or the store failed
+func (l *RateLimiter) take(ctx context.Context, policyName, key string) (_ ratelimit.Result, limited bool) {
+	policy, ok := (*l.policies.Load())[policyName]
+	if !ok || !policy.Enabled() {
+		return ratelimit.Result{}, false
+	}
+
+	res, err := l.store.Take(ctx, policyName+":"+key, policy, time.Now())
+	if err != nil {
+		// Fail open: an unavailable store mustn't take the whole API down
+		l.log.Error("rate limit store error", "policy", policyName, "err", err.Error())
+		return ratelimit.Result{}, false
+	}
+	return res, true
+}
+
+// ClientIP returns the IP of the client that made the request.
+// If the request came from a trusted proxy, X-Forwarded-For is walked from the right
+// and the first address that isn't a trusted proxy is the client.
+func ClientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	remote, err := netip.ParseAddr(host)
+	if err != nil || !isTrusted(remote, trustedProxies) {
+		return host
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	client := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		addr, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		client = addr
+		if !isTrusted(addr, trustedProxies) {
+			break
+		}
+	}
+
+	return client.Unmap().String()
+}
+
+func isTrusted(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, p := range trustedProxies {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/kourai55k/booking-service/pkg/logctx"
+)
+
+// RecoveryMiddleware turns a panic in a handler into a 500 response and logs it with the stack
+func RecoveryMiddleware(log *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := newResponseRecorder(w)
+
+		defer func() {
+			p := recover()
+			if p == nil {
+				return
+			}
+			// Let the server abort the response as it normally would
+			if p == http.ErrAbortHandler {
+				panic(p)
+			}
+
+			reqLog := logctx.FromContext(r.Context())
+			if reqLog == nil {
+				reqLog = log
+			}
+			reqLog.Error("panic in http handler",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"panic", p,
+				"stack", string(debug.Stack()),
+			)
+
+			// Too late to change the status if the handler already started the response
+			if !rw.wroteHeader {
+				http.Error(rw, "internal server error", http.StatusInternalServerError)
+			}
+		}()
+
+		next.ServeHTTP(rw, r)
+	})
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	jwthelper "github.com/kourai55k/booking-service/pkg/jwtHelper"
+)
+
+// StreamTicketQuery is the query parameter browsers pass the stream ticket of a live stream in
+const StreamTicketQuery = "ticket"
+
+// StreamTicketMiddleware authorizes a live stream by the stream ticket in the query, for browsers that can't send
+// the Authorization header, and falls back to OwnerMiddleware without one. The ticket must be for the restaurant
+// in the "id" path value.
+func StreamTicketMiddleware(next http.Handler) http.Handler {
+	owner := OwnerMiddleware(next)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ticket := r.URL.Query().Get(StreamTicketQuery)
+		if ticket == "" {
+			owner.ServeHTTP(w, r)
+			return
+		}
+
+		restaurantID, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid restaurant ID", http.StatusBadRequest)
+			return
+		}
+		claims, err := jwthelper.ParseStreamTicket(ticket, uint(restaurantID))
+		if err != nil {
+			http.Error(w, "invalid ticket", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(withUser(r.Context(), claims)))
+	})
+}
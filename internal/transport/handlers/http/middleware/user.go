@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	jwthelper "github.com/kourai55k/booking-service/pkg/jwtHelper"
+	"github.com/kourai55k/booking-service/pkg/logctx"
+)
+
+// withUser adds the user ID and role of claims to ctx, and the user ID to the request logger
+func withUser(ctx context.Context, claims *jwthelper.CustomClaims) context.Context {
+	ctx = context.WithValue(ctx, domain.UserIDKey, claims.UserID)
+	ctx = context.WithValue(ctx, domain.RoleKey, claims.Role)
+	ctx = logctx.WithUserID(ctx, claims.UserID)
+	if log := logctx.FromContext(ctx); log != nil {
+		ctx = logctx.WithLogger(ctx, log.With("user_id", claims.UserID))
+	}
+	return ctx
+}
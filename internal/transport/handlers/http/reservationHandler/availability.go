@@ -0,0 +1,100 @@
+package reservationHandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type availableTableResponse struct {
+	ID         uint     `json:"id"`
+	Number     uint     `json:"number"`
+	Capacity   uint     `json:"capacity"`
+	Attributes []string `json:"attributes"`
+}
+
+type availableCombinationResponse struct {
+	ID       uint   `json:"id"`
+	TableIDs []uint `json:"tableIDs"`
+	Capacity uint   `json:"capacity"`
+}
+
+type getAvailabilityResponse struct {
+	Tables       []availableTableResponse       `json:"tables"`
+	Combinations []availableCombinationResponse `json:"combinations"`
+}
+
+// GetAvailability returns the tables and table combinations of the restaurant free for the party from the query:
+// ?startTime=...&endTime=...&partySize=..., with the times in RFC 3339. Without endTime the party
+// stays for the default seating duration of the restaurant.
+func (h *ReservationHandler) GetAvailability(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.GetAvailability"
+
+	log := h.loggerFor(r)
+
+	idStr := r.PathValue("id")
+	restaurantID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || idStr == "" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("bad request", "err", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	query := r.URL.Query()
+	start, startErr := time.Parse(time.RFC3339, query.Get("startTime"))
+	partySize, sizeErr := strconv.ParseUint(query.Get("partySize"), 10, 32)
+	var end time.Time
+	var endErr error
+	if query.Has("endTime") {
+		end, endErr = time.Parse(time.RFC3339, query.Get("endTime"))
+	}
+	if startErr != nil || endErr != nil || sizeErr != nil {
+		http.Error(w, "bad request: startTime and partySize are required, with the times in RFC 3339", http.StatusBadRequest)
+		log.Error("bad request", "err", fmt.Errorf("%s: bad query", op).Error())
+		return
+	}
+
+	tables, err := h.reservationService.GetAvailableTables(r.Context(), uint(restaurantID), start, end, uint(partySize))
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to get availability", "err", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	combinations, err := h.reservationService.GetAvailableCombinations(r.Context(), uint(restaurantID), start, end, uint(partySize))
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to get availability", "err", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	res := getAvailabilityResponse{
+		Tables:       make([]availableTableResponse, 0, len(tables)),
+		Combinations: make([]availableCombinationResponse, 0, len(combinations)),
+	}
+	for _, table := range tables {
+		res.Tables = append(res.Tables, availableTableResponse{
+			ID:         table.ID,
+			Number:     table.Number,
+			Capacity:   table.Capacity,
+			Attributes: append([]string{}, table.Attributes...),
+		})
+	}
+	for _, combination := range combinations {
+		res.Combinations = append(res.Combinations, availableCombinationResponse{
+			ID:       combination.ID,
+			TableIDs: append([]uint{}, combination.TableIDs...),
+			Capacity: combination.Capacity,
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		log.Error("failed to encode response", "err", fmt.Errorf("%s: failed to encode response", op).Error())
+	}
+}
@@ -0,0 +1,95 @@
+package reservationHandler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+// policyOutcomeResponse is what a change of the reservation cost under the change policy, fees in minor currency units
+type policyOutcomeResponse struct {
+	Action    string    `json:"action"`
+	ActorRole string    `json:"actorRole"`
+	Fee       uint      `json:"fee"`
+	Reason    string    `json:"reason"`
+	At        time.Time `json:"at"`
+}
+
+type changePolicyResponse struct {
+	CancellationCutoffMinutes uint `json:"cancellationCutoffMinutes"`
+	CancellationFee           uint `json:"cancellationFee"`
+	ModificationCutoffMinutes uint `json:"modificationCutoffMinutes"`
+	ModificationFee           uint `json:"modificationFee"`
+	MaxModifications          uint `json:"maxModifications"`
+}
+
+// changeQuoteResponse leaves out the outcome of an action the reservation can't take anymore
+type changeQuoteResponse struct {
+	Policy       changePolicyResponse   `json:"policy"`
+	Modification *policyOutcomeResponse `json:"modification,omitempty"`
+	Cancellation *policyOutcomeResponse `json:"cancellation,omitempty"`
+}
+
+func toPolicyOutcomeResponse(o models.PolicyOutcome) policyOutcomeResponse {
+	return policyOutcomeResponse{
+		Action:    string(o.Action),
+		ActorRole: o.ActorRole,
+		Fee:       o.Fee,
+		Reason:    o.Reason,
+		At:        o.At,
+	}
+}
+
+func toPolicyOutcomeResponses(outcomes []models.PolicyOutcome) []policyOutcomeResponse {
+	res := make([]policyOutcomeResponse, 0, len(outcomes))
+	for _, o := range outcomes {
+		res = append(res, toPolicyOutcomeResponse(o))
+	}
+	return res
+}
+
+// GetChangeQuote returns the change policy of the restaurant of the reservation
+// and what changing or cancelling it would cost the user now
+func (h *ReservationHandler) GetChangeQuote(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.GetChangeQuote"
+
+	log := h.loggerFor(r)
+
+	id, ok := h.pathID(w, r, op, "id")
+	if !ok {
+		return
+	}
+	userID, role, ok := h.host(w, r, op)
+	if !ok {
+		return
+	}
+
+	quote, err := h.reservationService.QuoteChanges(r.Context(), userID, role, id)
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to quote reservation changes", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	res := changeQuoteResponse{
+		Policy: changePolicyResponse{
+			CancellationCutoffMinutes: uint(quote.Policy.CancellationCutoff / time.Minute),
+			CancellationFee:           quote.Policy.CancellationFee,
+			ModificationCutoffMinutes: uint(quote.Policy.ModificationCutoff / time.Minute),
+			ModificationFee:           quote.Policy.ModificationFee,
+			MaxModifications:          quote.Policy.MaxModifications,
+		},
+	}
+	if quote.Modification != nil {
+		modification := toPolicyOutcomeResponse(*quote.Modification)
+		res.Modification = &modification
+	}
+	if quote.Cancellation != nil {
+		cancellation := toPolicyOutcomeResponse(*quote.Cancellation)
+		res.Cancellation = &cancellation
+	}
+	h.writeJSON(w, r, op, http.StatusOK, res)
+}
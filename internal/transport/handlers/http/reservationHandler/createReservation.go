@@ -0,0 +1,111 @@
+package reservationHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+// createReservationRequest leaves out the table to have one assigned, attributes are only used then.
+// TableIDs books the tables of a combination instead of a single table.
+// Without an end time the reservation lasts the default seating duration of the restaurant for the party.
+type createReservationRequest struct {
+	RestaurantID uint      `json:"restaurantID"`
+	TableID      uint      `json:"tableID"`
+	TableIDs     []uint    `json:"tableIDs"`
+	PartySize    uint      `json:"partySize"`
+	StartTime    time.Time `json:"startTime"`
+	EndTime      time.Time `json:"endTime"`
+	Attributes   []string  `json:"attributes"`
+}
+
+type createReservationResponse struct {
+	ID       uint      `json:"id"`
+	TableID  uint      `json:"tableID"`
+	TableIDs []uint    `json:"tableIDs"`
+	EndTime  time.Time `json:"endTime"`
+}
+
+func (r *createReservationRequest) validate() error {
+	if r.RestaurantID == 0 || r.PartySize == 0 || r.StartTime.IsZero() {
+		return errors.New("missing required fields")
+	}
+	if r.TableID != 0 && len(r.TableIDs) > 0 {
+		return errors.New("tableID and tableIDs are mutually exclusive")
+	}
+	if len(r.TableIDs) == 1 {
+		return errors.New("tableIDs must name at least two tables")
+	}
+	if (r.TableID != 0 || len(r.TableIDs) > 0) && len(r.Attributes) > 0 {
+		return errors.New("attributes are only used when the table is assigned")
+	}
+	if !r.EndTime.IsZero() && !r.EndTime.After(r.StartTime) {
+		return errors.New("endTime must be after startTime")
+	}
+	return nil
+}
+
+func (h *ReservationHandler) CreateReservation(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.CreateReservation"
+
+	log := h.loggerFor(r)
+
+	var req createReservationRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	defer r.Body.Close()
+
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("failed to decode request body", "error", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		http.Error(w, fmt.Sprintf("bad request: %v", err), http.StatusBadRequest)
+		log.Error("bad request", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	userID, ok := r.Context().Value(domain.UserIDKey).(uint)
+	if !ok {
+		http.Error(w, "user ID not found in context", http.StatusUnauthorized)
+		log.Error("user ID not found in context", "error", fmt.Errorf("%s: user ID missing", op).Error())
+		return
+	}
+
+	reservation := &models.Reservation{
+		RestaurantID: req.RestaurantID,
+		TableID:      req.TableID,
+		UserID:       userID,
+		PartySize:    req.PartySize,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+	}
+	if len(req.TableIDs) > 0 {
+		tableIDs := slices.Sorted(slices.Values(req.TableIDs))
+		reservation.TableID, reservation.JoinedTableIDs = tableIDs[0], tableIDs[1:]
+	}
+
+	id, err := h.reservationService.CreateReservation(r.Context(), reservation, req.Attributes)
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to create reservation", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	res := createReservationResponse{ID: id, TableID: reservation.TableID, TableIDs: reservation.TableIDs(), EndTime: reservation.EndTime}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		log.Error("failed to encode response", "error", fmt.Errorf("%s: failed to encode response", op).Error())
+	}
+}
@@ -0,0 +1,85 @@
+package reservationHandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+)
+
+type getReservationResponse struct {
+	Reservation reservationResponse `json:"reservation"`
+}
+
+type getReservationsResponse struct {
+	Reservations []reservationResponse `json:"reservations"`
+}
+
+func (h *ReservationHandler) GetReservationByID(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.GetReservationByID"
+
+	log := h.loggerFor(r)
+
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || idStr == "" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("bad request", "err", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	if !h.authorize(w, r, op, uint(id)) {
+		return
+	}
+
+	reservation, err := h.reservationService.GetReservationByID(r.Context(), uint(id))
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to get reservation", "err", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	res := getReservationResponse{Reservation: toReservationResponse(reservation)}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		log.Error("failed to encode response", "err", fmt.Errorf("%s: failed to encode response", op).Error())
+	}
+}
+
+// GetMyReservations returns the reservations made by the authenticated user
+func (h *ReservationHandler) GetMyReservations(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.GetMyReservations"
+
+	log := h.loggerFor(r)
+
+	userID, ok := r.Context().Value(domain.UserIDKey).(uint)
+	if !ok {
+		http.Error(w, "user ID not found in context", http.StatusUnauthorized)
+		log.Error("user ID not found in context", "error", fmt.Errorf("%s: user ID missing", op).Error())
+		return
+	}
+
+	reservations, err := h.reservationService.GetReservationsByUserID(r.Context(), userID)
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to get reservations", "err", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	res := getReservationsResponse{Reservations: make([]reservationResponse, 0, len(reservations))}
+	for _, reservation := range reservations {
+		res.Reservations = append(res.Reservations, toReservationResponse(reservation))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		log.Error("failed to encode response", "err", fmt.Errorf("%s: failed to encode response", op).Error())
+	}
+}
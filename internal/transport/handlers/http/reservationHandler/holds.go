@@ -0,0 +1,165 @@
+package reservationHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+// holdTableRequest leaves out the end time for the default seating duration of the restaurant
+type holdTableRequest struct {
+	TableID   uint      `json:"tableID"`
+	PartySize uint      `json:"partySize"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+func (r *holdTableRequest) validate() error {
+	if r.TableID == 0 || r.PartySize == 0 || r.StartTime.IsZero() {
+		return errors.New("missing required fields")
+	}
+	if !r.EndTime.IsZero() && !r.EndTime.After(r.StartTime) {
+		return errors.New("endTime must be after startTime")
+	}
+	return nil
+}
+
+type holdResponse struct {
+	Token        string    `json:"token"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	RestaurantID uint      `json:"restaurantID"`
+	TableID      uint      `json:"tableID"`
+	PartySize    uint      `json:"partySize"`
+	StartTime    time.Time `json:"startTime"`
+	EndTime      time.Time `json:"endTime"`
+}
+
+// HoldTable keeps a table of the restaurant free for the authenticated user while they complete the booking
+func (h *ReservationHandler) HoldTable(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.HoldTable"
+
+	log := h.loggerFor(r)
+
+	var req holdTableRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	defer r.Body.Close()
+
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("failed to decode request body", "error", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	idStr := r.PathValue("id")
+	restaurantID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || idStr == "" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("bad request", "err", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		http.Error(w, fmt.Sprintf("bad request: %v", err), http.StatusBadRequest)
+		log.Error("bad request", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	userID, ok := r.Context().Value(domain.UserIDKey).(uint)
+	if !ok {
+		http.Error(w, "user ID not found in context", http.StatusUnauthorized)
+		log.Error("user ID not found in context", "error", fmt.Errorf("%s: user ID missing", op).Error())
+		return
+	}
+
+	hold := &models.Hold{
+		PartySize:    req.PartySize,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		RestaurantID: uint(restaurantID),
+		TableID:      req.TableID,
+		UserID:       userID,
+	}
+
+	if err := h.reservationService.HoldTable(r.Context(), hold); err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to hold table", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	res := holdResponse{
+		Token:        hold.Token,
+		ExpiresAt:    hold.ExpiresAt,
+		RestaurantID: hold.RestaurantID,
+		TableID:      hold.TableID,
+		PartySize:    hold.PartySize,
+		StartTime:    hold.StartTime,
+		EndTime:      hold.EndTime,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		log.Error("failed to encode response", "error", fmt.Errorf("%s: failed to encode response", op).Error())
+	}
+}
+
+// ConvertHold turns the authenticated user's hold into a confirmed reservation
+func (h *ReservationHandler) ConvertHold(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.ConvertHold"
+
+	log := h.loggerFor(r)
+
+	userID, ok := r.Context().Value(domain.UserIDKey).(uint)
+	if !ok {
+		http.Error(w, "user ID not found in context", http.StatusUnauthorized)
+		log.Error("user ID not found in context", "error", fmt.Errorf("%s: user ID missing", op).Error())
+		return
+	}
+
+	id, err := h.reservationService.ConvertHold(r.Context(), userID, r.PathValue("token"))
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to convert hold", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	res := createReservationResponse{ID: id}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		log.Error("failed to encode response", "error", fmt.Errorf("%s: failed to encode response", op).Error())
+	}
+}
+
+// ReleaseHold frees the table of the authenticated user's hold before it expires
+func (h *ReservationHandler) ReleaseHold(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.ReleaseHold"
+
+	log := h.loggerFor(r)
+
+	userID, ok := r.Context().Value(domain.UserIDKey).(uint)
+	if !ok {
+		http.Error(w, "user ID not found in context", http.StatusUnauthorized)
+		log.Error("user ID not found in context", "error", fmt.Errorf("%s: user ID missing", op).Error())
+		return
+	}
+
+	if err := h.reservationService.ReleaseHold(r.Context(), userID, r.PathValue("token")); err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to release hold", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,305 @@
+package reservationHandler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/internal/live"
+	"golang.org/x/net/websocket"
+)
+
+// liveWriteTimeout is how long a write to a live stream may take, a client that doesn't read for longer is cut off
+const liveWriteTimeout = 10 * time.Second
+
+// The events of a live stream besides the changes of the restaurant, they carry the ID the stream is at
+const (
+	// liveReady follows the events missed since the ID the client resumed from, the stream is up to date
+	liveReady = "ready"
+	// liveReset tells the client the events it missed are lost, it has to reload the floor, queue and reservations
+	liveReset = "reset"
+	// liveHeartbeat is sent over WebSocket every heartbeat interval, SSE sends a comment instead
+	liveHeartbeat = "heartbeat"
+)
+
+// liveEventResponse is the data of an event of the live stream, only the field of its type is set
+type liveEventResponse struct {
+	Table       *floorTableResponse  `json:"table,omitempty"`
+	Reservation *reservationResponse `json:"reservation,omitempty"`
+	WalkIn      *walkInResponse      `json:"walkIn,omitempty"`
+	OccurredAt  *time.Time           `json:"occurredAt,omitempty"`
+}
+
+// liveTicketResponse is a stream ticket, passed as the ticket query parameter of the live stream
+type liveTicketResponse struct {
+	Ticket    string    `json:"ticket"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// liveMessage is an event of the WebSocket stream, SSE sends the same fields as its own
+type liveMessage struct {
+	ID    string             `json:"id,omitempty"`
+	Event string             `json:"event"`
+	Data  *liveEventResponse `json:"data,omitempty"`
+}
+
+func toLiveEventResponse(e models.LiveEvent) *liveEventResponse {
+	res := &liveEventResponse{OccurredAt: &e.OccurredAt}
+	if e.Table != nil {
+		table := toFloorTableResponse(e.Table)
+		res.Table = &table
+	}
+	if e.Reservation != nil {
+		reservation := toReservationResponse(e.Reservation)
+		res.Reservation = &reservation
+	}
+	if e.WalkIn != nil {
+		walkIn := toWalkInResponse(e.WalkIn)
+		res.WalkIn = &walkIn
+	}
+	return res
+}
+
+// liveWriter writes the events of a live stream in the format of its transport
+type liveWriter interface {
+	event(msg liveMessage) error
+	heartbeat() error
+}
+
+// StreamLive streams the changes of the tables, reservations and walk-ins of the restaurant to its hosts,
+// as Server-Sent Events or over WebSocket if the client asks to upgrade. A client resumes after the ID
+// of the last event it got, from the Last-Event-ID header or the lastEventId query parameter.
+func (h *ReservationHandler) StreamLive(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.StreamLive"
+
+	log := h.loggerFor(r)
+
+	restaurantID, ok := h.pathID(w, r, op, "id")
+	if !ok {
+		return
+	}
+	userID, role, ok := h.host(w, r, op)
+	if !ok {
+		return
+	}
+
+	if err := h.walkInService.CheckHost(r.Context(), userID, role, restaurantID); err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to check host", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+	sub, err := h.liveHub.Subscribe(restaurantID, lastEventID)
+	if err != nil {
+		if errors.Is(err, live.ErrClosed) {
+			http.Error(w, "service is shutting down", http.StatusServiceUnavailable)
+		} else {
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}
+		log.Error("failed to subscribe to live events", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+	defer sub.Close()
+
+	log.Debug("live stream started", "restaurant_id", restaurantID, "last_event_id", lastEventID, "reset", sub.Reset)
+	if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		err = h.streamWebSocket(w, r, sub)
+	} else {
+		err = h.streamSSE(w, r, sub)
+	}
+	if err != nil {
+		log.Warn("live stream broken", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+	log.Debug("live stream ended", "restaurant_id", restaurantID)
+}
+
+// IssueLiveTicket returns a short-lived stream ticket for the live stream of the restaurant,
+// for browsers that can't send the Authorization header with EventSource and WebSocket
+func (h *ReservationHandler) IssueLiveTicket(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.IssueLiveTicket"
+
+	restaurantID, ok := h.pathID(w, r, op, "id")
+	if !ok {
+		return
+	}
+	userID, role, ok := h.host(w, r, op)
+	if !ok {
+		return
+	}
+
+	ticket, expiresAt, err := h.walkInService.IssueLiveTicket(r.Context(), userID, role, restaurantID)
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		h.loggerFor(r).Error("failed to issue live ticket", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	h.writeJSON(w, r, op, http.StatusCreated, liveTicketResponse{Ticket: ticket, ExpiresAt: expiresAt})
+}
+
+// stream sends the backlog of the subscription, then its events and heartbeats until ctx is done or the events end.
+// The events end when the client falls behind or the service shuts down, the client resumes with the ID of its last one.
+func (h *ReservationHandler) stream(ctx context.Context, sub *live.Subscription, lw liveWriter) error {
+	if sub.Reset {
+		if err := lw.event(liveMessage{ID: sub.Position, Event: liveReset}); err != nil {
+			return err
+		}
+	} else {
+		for _, event := range sub.Backlog {
+			if err := lw.event(toLiveMessage(event)); err != nil {
+				return err
+			}
+		}
+		if err := lw.event(liveMessage{ID: sub.Position, Event: liveReady}); err != nil {
+			return err
+		}
+	}
+
+	heartbeat := time.NewTicker(h.liveHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				return nil
+			}
+			if err := lw.event(toLiveMessage(event)); err != nil {
+				return err
+			}
+		case <-heartbeat.C:
+			if err := lw.heartbeat(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func toLiveMessage(e models.LiveEvent) liveMessage {
+	return liveMessage{ID: e.ID, Event: string(e.Type), Data: toLiveEventResponse(e)}
+}
+
+func (h *ReservationHandler) streamSSE(w http.ResponseWriter, r *http.Request, sub *live.Subscription) error {
+	rc := http.NewResponseController(w)
+	// the stream outlives the read timeout of the server, and every write gets its own deadline
+	if err := rc.SetReadDeadline(time.Time{}); err != nil {
+		return fmt.Errorf("clearing read deadline: %w", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// proxies mustn't buffer the events
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	return h.stream(r.Context(), sub, &sseWriter{w: w, rc: rc})
+}
+
+type sseWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func (s *sseWriter) event(msg liveMessage) error {
+	data := []byte("{}")
+	if msg.Data != nil {
+		var err error
+		if data, err = json.Marshal(msg.Data); err != nil {
+			return err
+		}
+	}
+	return s.write(fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", msg.ID, msg.Event, data))
+}
+
+// heartbeat is a comment, clients ignore it but it keeps proxies from closing the idle connection
+func (s *sseWriter) heartbeat() error {
+	return s.write(": heartbeat\n\n")
+}
+
+func (s *sseWriter) write(frame string) error {
+	if err := s.rc.SetWriteDeadline(time.Now().Add(liveWriteTimeout)); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(s.w, frame); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+func (h *ReservationHandler) streamWebSocket(w http.ResponseWriter, r *http.Request, sub *live.Subscription) error {
+	var streamErr error
+	server := websocket.Server{
+		// browsers send their origin, only the ones allowed by CORS may open the stream,
+		// other clients send none
+		Handshake: func(_ *websocket.Config, r *http.Request) error {
+			if origin := r.Header.Get("Origin"); origin != "" && !h.origins.Allowed(origin) {
+				return fmt.Errorf("origin %q isn't allowed", origin)
+			}
+			return nil
+		},
+		Handler: func(conn *websocket.Conn) {
+			defer conn.Close()
+
+			// the deadlines of the request stay on the hijacked connection
+			if err := conn.SetDeadline(time.Time{}); err != nil {
+				streamErr = fmt.Errorf("clearing deadlines: %w", err)
+				return
+			}
+
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+			// clients send nothing, reading only notices when they close the stream
+			go func() {
+				_, _ = io.Copy(io.Discard, conn)
+				cancel()
+			}()
+
+			streamErr = h.stream(ctx, sub, &wsWriter{conn: conn})
+		},
+	}
+	server.ServeHTTP(hijacker{w}, r)
+
+	return streamErr
+}
+
+type wsWriter struct {
+	conn *websocket.Conn
+}
+
+func (s *wsWriter) event(msg liveMessage) error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout)); err != nil {
+		return err
+	}
+	return websocket.JSON.Send(s.conn, msg)
+}
+
+func (s *wsWriter) heartbeat() error {
+	return s.event(liveMessage{Event: liveHeartbeat})
+}
+
+// hijacker lets x/net/websocket take over the connection from behind the middlewares,
+// their writers only unwrap to the one of the server
+type hijacker struct {
+	http.ResponseWriter
+}
+
+func (h hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(h.ResponseWriter).Hijack()
+}
@@ -0,0 +1,214 @@
+package reservationHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/internal/live"
+	"github.com/kourai55k/booking-service/pkg/logctx"
+)
+
+type ReservationService interface {
+	CreateReservation(ctx context.Context, reservation *models.Reservation, attributes []string) (uint, error)
+	GetReservationByID(context.Context, uint) (*models.Reservation, error)
+	GetReservationsByUserID(context.Context, uint) ([]*models.Reservation, error)
+	UpdateReservation(ctx context.Context, userID uint, role string, reservation *models.Reservation) (*models.Reservation, error)
+	QuoteChanges(ctx context.Context, userID uint, role string, id uint) (*models.ChangeQuote, error)
+	TransitionReservation(ctx context.Context, userID uint, role string, id uint, to models.ReservationStatus, reason string) error
+	GetReservationTransitions(context.Context, uint) ([]*models.ReservationTransition, error)
+
+	GetAvailableTables(ctx context.Context, restaurantID uint, start, end time.Time, partySize uint) ([]*models.Table, error)
+	GetAvailableCombinations(ctx context.Context, restaurantID uint, start, end time.Time, partySize uint) ([]*models.TableCombination, error)
+	HoldTable(context.Context, *models.Hold) error
+	ConvertHold(ctx context.Context, userID uint, token string) (uint, error)
+	ReleaseHold(ctx context.Context, userID uint, token string) error
+
+	CanManageReservation(ctx context.Context, userID uint, role string, reservationID uint) (bool, error)
+}
+
+type WaitlistService interface {
+	JoinWaitlist(context.Context, *models.WaitlistEntry) (uint, error)
+	GetWaitlistEntry(ctx context.Context, userID, id uint) (*models.WaitlistEntry, error)
+	LeaveWaitlist(ctx context.Context, userID, id uint) error
+}
+
+type WalkInService interface {
+	QuoteWait(ctx context.Context, userID uint, role string, restaurantID, partySize uint) (time.Duration, error)
+	JoinWalkInQueue(ctx context.Context, userID uint, role string, walkIn *models.WalkIn) (uint, error)
+	GetWalkInQueue(ctx context.Context, userID uint, role string, restaurantID uint) ([]*models.WalkIn, error)
+	NotifyWalkIn(ctx context.Context, userID uint, role string, id, tableID uint) error
+	SeatWalkIn(ctx context.Context, userID uint, role string, id, tableID uint) error
+	LeaveWalkInQueue(ctx context.Context, userID uint, role string, id uint) error
+
+	GetFloor(ctx context.Context, userID uint, role string, restaurantID uint) ([]*models.Table, error)
+	OccupyTable(ctx context.Context, userID uint, role string, restaurantID, tableID, partySize uint) error
+	FreeTable(ctx context.Context, userID uint, role string, restaurantID, tableID uint) error
+
+	CheckHost(ctx context.Context, userID uint, role string, restaurantID uint) error
+	IssueLiveTicket(ctx context.Context, userID uint, role string, restaurantID uint) (string, time.Time, error)
+}
+
+// LiveHub fans the committed changes of restaurants out to their live streams
+type LiveHub interface {
+	// Subscribe fails with live.ErrClosed once the service is shutting down
+	Subscribe(restaurantID uint, lastEventID string) (*live.Subscription, error)
+}
+
+// OriginPolicy tells which origins browsers may open live streams over WebSocket from, the CORS allowlist
+type OriginPolicy interface {
+	Allowed(origin string) bool
+}
+
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+type ReservationHandler struct {
+	reservationService ReservationService
+	waitlistService    WaitlistService
+	walkInService      WalkInService
+	liveHub            LiveHub
+	liveHeartbeat      time.Duration
+	origins            OriginPolicy
+	logger             Logger
+}
+
+func NewReservationHandler(
+	reservationService ReservationService,
+	waitlistService WaitlistService,
+	walkInService WalkInService,
+	liveHub LiveHub,
+	liveHeartbeat time.Duration,
+	origins OriginPolicy,
+	logger Logger,
+) *ReservationHandler {
+	return &ReservationHandler{
+		reservationService: reservationService,
+		waitlistService:    waitlistService,
+		walkInService:      walkInService,
+		liveHub:            liveHub,
+		liveHeartbeat:      liveHeartbeat,
+		origins:            origins,
+		logger:             logger,
+	}
+}
+
+// loggerFor returns the request-scoped logger set by RequestIDMiddleware, or the handler's own logger
+func (h *ReservationHandler) loggerFor(r *http.Request) Logger {
+	if log := logctx.FromContext(r.Context()); log != nil {
+		return log
+	}
+	return h.logger
+}
+
+type reservationResponse struct {
+	ID           uint      `json:"id"`
+	RestaurantID uint      `json:"restaurantID"`
+	TableID      uint      `json:"tableID"`
+	TableIDs     []uint    `json:"tableIDs"`
+	UserID       uint      `json:"userID"`
+	PartySize    uint      `json:"partySize"`
+	StartTime    time.Time `json:"startTime"`
+	EndTime      time.Time `json:"endTime"`
+	Status       string    `json:"status"`
+	// Modifications is the number of changes the guest made, Fees is the sum charged for late changes
+	Modifications  uint                    `json:"modifications"`
+	Fees           uint                    `json:"fees"`
+	PolicyOutcomes []policyOutcomeResponse `json:"policyOutcomes"`
+}
+
+func toReservationResponse(r *models.Reservation) reservationResponse {
+	return reservationResponse{
+		ID:           r.ID,
+		RestaurantID: r.RestaurantID,
+		TableID:      r.TableID,
+		TableIDs:     r.TableIDs(),
+		UserID:       r.UserID,
+		PartySize:    r.PartySize,
+		StartTime:    r.StartTime,
+		EndTime:      r.EndTime,
+		Status:       string(r.Status),
+
+		Modifications:  r.Modifications(),
+		Fees:           r.Fees(),
+		PolicyOutcomes: toPolicyOutcomeResponses(r.PolicyOutcomes),
+	}
+}
+
+// errorStatus maps reservation service errors to an HTTP status and a message for the client
+func errorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrReservationNotFound):
+		return http.StatusNotFound, "reservation not found"
+	case errors.Is(err, domain.ErrHoldNotFound):
+		return http.StatusNotFound, "hold not found"
+	case errors.Is(err, domain.ErrHoldExpired):
+		return http.StatusGone, "hold has expired"
+	case errors.Is(err, domain.ErrWaitlistEntryNotFound):
+		return http.StatusNotFound, "waitlist entry not found"
+	case errors.Is(err, domain.ErrWaitlistEntryClosed):
+		return http.StatusConflict, "conflict: " + err.Error()
+	case errors.Is(err, domain.ErrInvalidWaitlistEntry):
+		return http.StatusBadRequest, "bad request: " + err.Error()
+	case errors.Is(err, domain.ErrWalkInNotFound):
+		return http.StatusNotFound, "walk-in not found"
+	case errors.Is(err, domain.ErrWalkInClosed), errors.Is(err, domain.ErrTableOccupied):
+		return http.StatusConflict, "conflict: " + err.Error()
+	case errors.Is(err, domain.ErrInvalidWalkIn):
+		return http.StatusBadRequest, "bad request: " + err.Error()
+	case errors.Is(err, domain.ErrHostOnly):
+		return http.StatusForbidden, "forbidden: " + err.Error()
+	case errors.Is(err, domain.ErrTableNotFound):
+		return http.StatusNotFound, "table not found"
+	case errors.Is(err, domain.ErrRestaurantNotFound):
+		return http.StatusNotFound, "restaurant not found"
+	case errors.Is(err, domain.ErrTableAlreadyReserved):
+		return http.StatusConflict, "table is already reserved for this time"
+	case errors.Is(err, domain.ErrNoTableAvailable):
+		return http.StatusConflict, "no table is available for the party at this time"
+	case errors.Is(err, domain.ErrIllegalTransition), errors.Is(err, domain.ErrReservationClosed),
+		errors.Is(err, domain.ErrPacingExceeded), errors.Is(err, domain.ErrModificationLimit):
+		return http.StatusConflict, "conflict: " + err.Error()
+	case errors.Is(err, domain.ErrTransitionForbidden):
+		return http.StatusForbidden, "forbidden: " + err.Error()
+	case errors.Is(err, domain.ErrTableTooSmall), errors.Is(err, domain.ErrInvalidReservation):
+		return http.StatusBadRequest, "bad request: " + err.Error()
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
+
+// authorize checks that the user from the context may manage the reservation and writes the error response if not
+func (h *ReservationHandler) authorize(w http.ResponseWriter, r *http.Request, op string, reservationID uint) bool {
+	log := h.loggerFor(r)
+
+	userID, ok := r.Context().Value(domain.UserIDKey).(uint)
+	if !ok {
+		http.Error(w, "user ID not found in context", http.StatusUnauthorized)
+		log.Error("user ID not found in context", "error", op+": user ID missing")
+		return false
+	}
+	role, _ := r.Context().Value(domain.RoleKey).(string)
+
+	canManage, err := h.reservationService.CanManageReservation(r.Context(), userID, role, reservationID)
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to check reservation access", "error", op+": "+err.Error())
+		return false
+	}
+	if !canManage {
+		http.Error(w, "forbidden: reservation belongs to another user", http.StatusForbidden)
+		log.Error("user can't manage reservation", "error", op+": forbidden")
+		return false
+	}
+
+	return true
+}
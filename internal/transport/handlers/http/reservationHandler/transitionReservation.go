@@ -0,0 +1,150 @@
+package reservationHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+const maxReasonLength = 500
+
+// transitionRequest is the optional body of the transition endpoints
+type transitionRequest struct {
+	Reason string `json:"reason"`
+}
+
+type transitionResponse struct {
+	ID        uint      `json:"id"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	ActorID   uint      `json:"actorID"`
+	ActorRole string    `json:"actorRole"`
+	Reason    string    `json:"reason"`
+	At        time.Time `json:"at"`
+}
+
+type getTransitionsResponse struct {
+	Transitions []transitionResponse `json:"transitions"`
+}
+
+// ConfirmReservation confirms a pending reservation, for staff
+func (h *ReservationHandler) ConfirmReservation(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, "http.ReservationHandler.ConfirmReservation", models.ReservationStatusConfirmed)
+}
+
+// SeatReservation marks the guests of a confirmed reservation as seated, for staff
+func (h *ReservationHandler) SeatReservation(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, "http.ReservationHandler.SeatReservation", models.ReservationStatusSeated)
+}
+
+// CompleteReservation marks a seated reservation as completed, for staff
+func (h *ReservationHandler) CompleteReservation(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, "http.ReservationHandler.CompleteReservation", models.ReservationStatusCompleted)
+}
+
+// MarkNoShow marks a confirmed reservation whose guests didn't come, for staff
+func (h *ReservationHandler) MarkNoShow(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, "http.ReservationHandler.MarkNoShow", models.ReservationStatusNoShow)
+}
+
+// CancelReservation cancels a pending or confirmed reservation, for the guest and staff
+func (h *ReservationHandler) CancelReservation(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, "http.ReservationHandler.CancelReservation", models.ReservationStatusCancelled)
+}
+
+// transition moves the reservation from the path to the status, with the reason from the optional body
+func (h *ReservationHandler) transition(w http.ResponseWriter, r *http.Request, op string, to models.ReservationStatus) {
+	log := h.loggerFor(r)
+
+	var req transitionRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	defer r.Body.Close()
+
+	if err := decoder.Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("failed to decode request body", "error", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+	if len(req.Reason) > maxReasonLength {
+		http.Error(w, fmt.Sprintf("bad request: reason is longer than %d characters", maxReasonLength), http.StatusBadRequest)
+		log.Error("bad request", "error", fmt.Errorf("%s: reason too long", op).Error())
+		return
+	}
+
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || idStr == "" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("bad request", "err", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	if !h.authorize(w, r, op, uint(id)) {
+		return
+	}
+	userID, _ := r.Context().Value(domain.UserIDKey).(uint)
+	role, _ := r.Context().Value(domain.RoleKey).(string)
+
+	if err := h.reservationService.TransitionReservation(r.Context(), userID, role, uint(id), to, req.Reason); err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to change reservation status", "to", to, "err", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetReservationTransitions returns the status transitions of a reservation, oldest first
+func (h *ReservationHandler) GetReservationTransitions(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.GetReservationTransitions"
+
+	log := h.loggerFor(r)
+
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || idStr == "" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("bad request", "err", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	if !h.authorize(w, r, op, uint(id)) {
+		return
+	}
+
+	transitions, err := h.reservationService.GetReservationTransitions(r.Context(), uint(id))
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to get reservation transitions", "err", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	res := getTransitionsResponse{Transitions: make([]transitionResponse, 0, len(transitions))}
+	for _, t := range transitions {
+		res.Transitions = append(res.Transitions, transitionResponse{
+			ID:        t.ID,
+			From:      string(t.From),
+			To:        string(t.To),
+			ActorID:   t.ActorID,
+			ActorRole: t.ActorRole,
+			Reason:    t.Reason,
+			At:        t.At,
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		log.Error("failed to encode response", "err", fmt.Errorf("%s: failed to encode response", op).Error())
+	}
+}
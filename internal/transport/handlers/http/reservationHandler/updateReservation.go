@@ -0,0 +1,99 @@
+package reservationHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+// updateReservationRequest moves the reservation to a single table with TableID or to a combination with TableIDs
+type updateReservationRequest struct {
+	TableID   uint      `json:"tableID"`
+	TableIDs  []uint    `json:"tableIDs"`
+	PartySize uint      `json:"partySize"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// validate checks that at least one field is provided to update
+func (r *updateReservationRequest) validate() error {
+	if r.TableID == 0 && len(r.TableIDs) == 0 && r.PartySize == 0 && r.StartTime.IsZero() && r.EndTime.IsZero() {
+		return errors.New("at least one field is required")
+	}
+	if r.TableID != 0 && len(r.TableIDs) > 0 {
+		return errors.New("tableID and tableIDs are mutually exclusive")
+	}
+	if len(r.TableIDs) == 1 {
+		return errors.New("tableIDs must name at least two tables")
+	}
+	return nil
+}
+
+// UpdateReservation changes the tables, time or party size of a reservation under the change policy of its restaurant
+// and returns the changed reservation with the outcome of the policy
+func (h *ReservationHandler) UpdateReservation(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.UpdateReservation"
+
+	log := h.loggerFor(r)
+
+	var req updateReservationRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	defer r.Body.Close()
+
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("failed to decode request body", "error", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || idStr == "" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("bad request", "err", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		http.Error(w, fmt.Sprintf("bad request: %v", err), http.StatusBadRequest)
+		log.Error("bad request", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	if !h.authorize(w, r, op, uint(id)) {
+		return
+	}
+
+	reservation := &models.Reservation{
+		ID:        uint(id),
+		TableID:   req.TableID,
+		PartySize: req.PartySize,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	}
+	if len(req.TableIDs) > 0 {
+		tableIDs := slices.Sorted(slices.Values(req.TableIDs))
+		reservation.TableID, reservation.JoinedTableIDs = tableIDs[0], tableIDs[1:]
+	}
+
+	userID, _ := r.Context().Value(domain.UserIDKey).(uint)
+	role, _ := r.Context().Value(domain.RoleKey).(string)
+
+	updated, err := h.reservationService.UpdateReservation(r.Context(), userID, role, reservation)
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to update reservation", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	h.writeJSON(w, r, op, http.StatusOK, toReservationResponse(updated))
+}
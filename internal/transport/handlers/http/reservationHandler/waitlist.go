@@ -0,0 +1,205 @@
+package reservationHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+// joinWaitlistRequest asks for a table for a seating starting between windowStart and windowEnd
+type joinWaitlistRequest struct {
+	PartySize   uint      `json:"partySize"`
+	WindowStart time.Time `json:"windowStart"`
+	WindowEnd   time.Time `json:"windowEnd"`
+	Contact     string    `json:"contact"`
+}
+
+func (r *joinWaitlistRequest) validate() error {
+	if r.PartySize == 0 || r.WindowStart.IsZero() || r.WindowEnd.IsZero() || r.Contact == "" {
+		return errors.New("missing required fields")
+	}
+	if r.WindowEnd.Before(r.WindowStart) {
+		return errors.New("windowEnd must not be before windowStart")
+	}
+	return nil
+}
+
+type waitlistOfferResponse struct {
+	// Token is the hold to claim the table with, POST /holds/{token}/reservation
+	Token     string    `json:"token"`
+	TableID   uint      `json:"tableID"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type waitlistEntryResponse struct {
+	ID           uint                   `json:"id"`
+	RestaurantID uint                   `json:"restaurantID"`
+	PartySize    uint                   `json:"partySize"`
+	WindowStart  time.Time              `json:"windowStart"`
+	WindowEnd    time.Time              `json:"windowEnd"`
+	Contact      string                 `json:"contact"`
+	Status       string                 `json:"status"`
+	CreatedAt    time.Time              `json:"createdAt"`
+	Offer        *waitlistOfferResponse `json:"offer,omitempty"`
+}
+
+func toWaitlistEntryResponse(e *models.WaitlistEntry) waitlistEntryResponse {
+	res := waitlistEntryResponse{
+		ID:           e.ID,
+		RestaurantID: e.RestaurantID,
+		PartySize:    e.PartySize,
+		WindowStart:  e.WindowStart,
+		WindowEnd:    e.WindowEnd,
+		Contact:      e.Contact,
+		Status:       string(e.Status),
+		CreatedAt:    e.CreatedAt,
+	}
+	if e.Offer != nil {
+		res.Offer = &waitlistOfferResponse{
+			Token:     e.Offer.Token,
+			TableID:   e.Offer.TableID,
+			StartTime: e.Offer.StartTime,
+			EndTime:   e.Offer.EndTime,
+			ExpiresAt: e.Offer.ExpiresAt,
+		}
+	}
+	return res
+}
+
+// JoinWaitlist puts the authenticated user on the waitlist of the restaurant
+func (h *ReservationHandler) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.JoinWaitlist"
+
+	log := h.loggerFor(r)
+
+	var req joinWaitlistRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	defer r.Body.Close()
+
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("failed to decode request body", "error", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	idStr := r.PathValue("id")
+	restaurantID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || idStr == "" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("bad request", "err", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		http.Error(w, fmt.Sprintf("bad request: %v", err), http.StatusBadRequest)
+		log.Error("bad request", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	userID, ok := r.Context().Value(domain.UserIDKey).(uint)
+	if !ok {
+		http.Error(w, "user ID not found in context", http.StatusUnauthorized)
+		log.Error("user ID not found in context", "error", fmt.Errorf("%s: user ID missing", op).Error())
+		return
+	}
+
+	entry := &models.WaitlistEntry{
+		PartySize:    req.PartySize,
+		WindowStart:  req.WindowStart,
+		WindowEnd:    req.WindowEnd,
+		Contact:      req.Contact,
+		RestaurantID: uint(restaurantID),
+		UserID:       userID,
+	}
+
+	if _, err := h.waitlistService.JoinWaitlist(r.Context(), entry); err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to join waitlist", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(toWaitlistEntryResponse(entry)); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		log.Error("failed to encode response", "error", fmt.Errorf("%s: failed to encode response", op).Error())
+	}
+}
+
+// GetWaitlistEntry returns the authenticated user's waitlist entry with the table offered to them, if any
+func (h *ReservationHandler) GetWaitlistEntry(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.GetWaitlistEntry"
+
+	log := h.loggerFor(r)
+
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || idStr == "" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("bad request", "err", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	userID, ok := r.Context().Value(domain.UserIDKey).(uint)
+	if !ok {
+		http.Error(w, "user ID not found in context", http.StatusUnauthorized)
+		log.Error("user ID not found in context", "error", fmt.Errorf("%s: user ID missing", op).Error())
+		return
+	}
+
+	entry, err := h.waitlistService.GetWaitlistEntry(r.Context(), userID, uint(id))
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to get waitlist entry", "err", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(toWaitlistEntryResponse(entry)); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		log.Error("failed to encode response", "err", fmt.Errorf("%s: failed to encode response", op).Error())
+	}
+}
+
+// LeaveWaitlist takes the authenticated user's entry off the waitlist
+func (h *ReservationHandler) LeaveWaitlist(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.LeaveWaitlist"
+
+	log := h.loggerFor(r)
+
+	idStr := r.PathValue("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || idStr == "" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("bad request", "err", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	userID, ok := r.Context().Value(domain.UserIDKey).(uint)
+	if !ok {
+		http.Error(w, "user ID not found in context", http.StatusUnauthorized)
+		log.Error("user ID not found in context", "error", fmt.Errorf("%s: user ID missing", op).Error())
+		return
+	}
+
+	if err := h.waitlistService.LeaveWaitlist(r.Context(), userID, uint(id)); err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to leave waitlist", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
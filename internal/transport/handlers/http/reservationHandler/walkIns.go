@@ -0,0 +1,428 @@
+package reservationHandler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kourai55k/booking-service/internal/domain"
+	"github.com/kourai55k/booking-service/internal/domain/models"
+)
+
+// joinWalkInQueueRequest puts a party at the end of the queue, the contact is where they're told their table is ready
+type joinWalkInQueueRequest struct {
+	Name      string `json:"name"`
+	PartySize uint   `json:"partySize"`
+	Contact   string `json:"contact"`
+}
+
+// walkInTableRequest is the optional body of notify and seat, without a table the one the party was notified about is used
+type walkInTableRequest struct {
+	TableID uint `json:"tableID"`
+}
+
+type occupyTableRequest struct {
+	PartySize uint `json:"partySize"`
+}
+
+type quoteResponse struct {
+	PartySize   uint `json:"partySize"`
+	WaitMinutes int  `json:"waitMinutes"`
+}
+
+type walkInResponse struct {
+	ID                   uint       `json:"id"`
+	RestaurantID         uint       `json:"restaurantID"`
+	Name                 string     `json:"name"`
+	PartySize            uint       `json:"partySize"`
+	Contact              string     `json:"contact,omitempty"`
+	Status               string     `json:"status"`
+	QuotedWaitMinutes    int        `json:"quotedWaitMinutes"`
+	EstimatedWaitMinutes int        `json:"estimatedWaitMinutes"`
+	JoinedAt             time.Time  `json:"joinedAt"`
+	NotifiedAt           *time.Time `json:"notifiedAt,omitempty"`
+	SeatedAt             *time.Time `json:"seatedAt,omitempty"`
+	TableID              uint       `json:"tableID,omitempty"`
+}
+
+type getWalkInQueueResponse struct {
+	WalkIns []walkInResponse `json:"walkIns"`
+}
+
+type floorTableResponse struct {
+	ID          uint `json:"id"`
+	Number      uint `json:"number"`
+	Capacity    uint `json:"capacity"`
+	IsAvailable bool `json:"isAvailable"`
+	Occupied    bool `json:"occupied"`
+	// OccupiedSince and PartySize are set while a party sits at the table
+	OccupiedSince *time.Time `json:"occupiedSince,omitempty"`
+	PartySize     uint       `json:"partySize,omitempty"`
+}
+
+type getFloorResponse struct {
+	Tables []floorTableResponse `json:"tables"`
+}
+
+// waitMinutes rounds a wait up to whole minutes, the way it's quoted to guests
+func waitMinutes(d time.Duration) int {
+	return int(math.Ceil(d.Minutes()))
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func toWalkInResponse(w *models.WalkIn) walkInResponse {
+	return walkInResponse{
+		ID:                   w.ID,
+		RestaurantID:         w.RestaurantID,
+		Name:                 w.Name,
+		PartySize:            w.PartySize,
+		Contact:              w.Contact,
+		Status:               string(w.Status),
+		QuotedWaitMinutes:    waitMinutes(w.QuotedWait),
+		EstimatedWaitMinutes: waitMinutes(w.EstimatedWait),
+		JoinedAt:             w.JoinedAt,
+		NotifiedAt:           optionalTime(w.NotifiedAt),
+		SeatedAt:             optionalTime(w.SeatedAt),
+		TableID:              w.TableID,
+	}
+}
+
+func toFloorTableResponse(t *models.Table) floorTableResponse {
+	return floorTableResponse{
+		ID:            t.ID,
+		Number:        t.Number,
+		Capacity:      t.Capacity,
+		IsAvailable:   t.IsAvailable,
+		Occupied:      t.Occupied(),
+		OccupiedSince: optionalTime(t.OccupiedSince),
+		PartySize:     t.OccupiedPartySize,
+	}
+}
+
+// QuoteWalkInWait estimates the wait of a party of partySize joining the queue now, for the hosts of the restaurant
+func (h *ReservationHandler) QuoteWalkInWait(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.QuoteWalkInWait"
+
+	log := h.loggerFor(r)
+
+	restaurantID, ok := h.pathID(w, r, op, "id")
+	if !ok {
+		return
+	}
+	partySize, err := strconv.ParseUint(r.URL.Query().Get("partySize"), 10, 32)
+	if err != nil || partySize == 0 {
+		http.Error(w, "bad request: partySize is required", http.StatusBadRequest)
+		log.Error("bad request", "error", fmt.Errorf("%s: bad party size", op).Error())
+		return
+	}
+	userID, role, ok := h.host(w, r, op)
+	if !ok {
+		return
+	}
+
+	wait, err := h.walkInService.QuoteWait(r.Context(), userID, role, restaurantID, uint(partySize))
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to quote wait", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	h.writeJSON(w, r, op, http.StatusOK, quoteResponse{PartySize: uint(partySize), WaitMinutes: waitMinutes(wait)})
+}
+
+// JoinWalkInQueue puts a walk-in party at the end of the queue of the restaurant with the wait quoted to them
+func (h *ReservationHandler) JoinWalkInQueue(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.JoinWalkInQueue"
+
+	log := h.loggerFor(r)
+
+	var req joinWalkInQueueRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	defer r.Body.Close()
+
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("failed to decode request body", "error", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+	if req.Name == "" || req.PartySize == 0 {
+		http.Error(w, "bad request: missing required fields", http.StatusBadRequest)
+		log.Error("bad request", "error", fmt.Errorf("%s: missing required fields", op).Error())
+		return
+	}
+
+	restaurantID, ok := h.pathID(w, r, op, "id")
+	if !ok {
+		return
+	}
+	userID, role, ok := h.host(w, r, op)
+	if !ok {
+		return
+	}
+
+	walkIn := &models.WalkIn{
+		Name:         req.Name,
+		PartySize:    req.PartySize,
+		Contact:      req.Contact,
+		RestaurantID: restaurantID,
+	}
+	if _, err := h.walkInService.JoinWalkInQueue(r.Context(), userID, role, walkIn); err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to join walk-in queue", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	h.writeJSON(w, r, op, http.StatusCreated, toWalkInResponse(walkIn))
+}
+
+// GetWalkInQueue returns the queue of the restaurant in order with the waits estimated now
+func (h *ReservationHandler) GetWalkInQueue(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.GetWalkInQueue"
+
+	log := h.loggerFor(r)
+
+	restaurantID, ok := h.pathID(w, r, op, "id")
+	if !ok {
+		return
+	}
+	userID, role, ok := h.host(w, r, op)
+	if !ok {
+		return
+	}
+
+	queue, err := h.walkInService.GetWalkInQueue(r.Context(), userID, role, restaurantID)
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to get walk-in queue", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	res := getWalkInQueueResponse{WalkIns: make([]walkInResponse, 0, len(queue))}
+	for _, walkIn := range queue {
+		res.WalkIns = append(res.WalkIns, toWalkInResponse(walkIn))
+	}
+	h.writeJSON(w, r, op, http.StatusOK, res)
+}
+
+// NotifyWalkIn tells the walk-in their table is ready
+func (h *ReservationHandler) NotifyWalkIn(w http.ResponseWriter, r *http.Request) {
+	h.walkInTable(w, r, "http.ReservationHandler.NotifyWalkIn", h.walkInService.NotifyWalkIn)
+}
+
+// SeatWalkIn seats the walk-in and marks their table occupied
+func (h *ReservationHandler) SeatWalkIn(w http.ResponseWriter, r *http.Request) {
+	h.walkInTable(w, r, "http.ReservationHandler.SeatWalkIn", h.walkInService.SeatWalkIn)
+}
+
+// LeaveWalkInQueue takes the walk-in off the queue without seating them
+func (h *ReservationHandler) LeaveWalkInQueue(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.LeaveWalkInQueue"
+
+	log := h.loggerFor(r)
+
+	id, ok := h.pathID(w, r, op, "id")
+	if !ok {
+		return
+	}
+	userID, role, ok := h.host(w, r, op)
+	if !ok {
+		return
+	}
+
+	if err := h.walkInService.LeaveWalkInQueue(r.Context(), userID, role, id); err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to leave walk-in queue", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetFloor returns the tables of the restaurant with the parties sitting at them
+func (h *ReservationHandler) GetFloor(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.GetFloor"
+
+	log := h.loggerFor(r)
+
+	restaurantID, ok := h.pathID(w, r, op, "id")
+	if !ok {
+		return
+	}
+	userID, role, ok := h.host(w, r, op)
+	if !ok {
+		return
+	}
+
+	tables, err := h.walkInService.GetFloor(r.Context(), userID, role, restaurantID)
+	if err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to get floor", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	res := getFloorResponse{Tables: make([]floorTableResponse, 0, len(tables))}
+	for _, table := range tables {
+		res.Tables = append(res.Tables, toFloorTableResponse(table))
+	}
+	h.writeJSON(w, r, op, http.StatusOK, res)
+}
+
+// OccupyTable marks a table of the restaurant occupied by a party seated outside the queue
+func (h *ReservationHandler) OccupyTable(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.OccupyTable"
+
+	log := h.loggerFor(r)
+
+	var req occupyTableRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	defer r.Body.Close()
+
+	if err := decoder.Decode(&req); err != nil || req.PartySize == 0 {
+		http.Error(w, "bad request: partySize is required", http.StatusBadRequest)
+		log.Error("failed to decode request body", "error", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	restaurantID, ok := h.pathID(w, r, op, "id")
+	if !ok {
+		return
+	}
+	tableID, ok := h.pathID(w, r, op, "tableID")
+	if !ok {
+		return
+	}
+	userID, role, ok := h.host(w, r, op)
+	if !ok {
+		return
+	}
+
+	if err := h.walkInService.OccupyTable(r.Context(), userID, role, restaurantID, tableID, req.PartySize); err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to occupy table", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// FreeTable marks a table of the restaurant free once its party left
+func (h *ReservationHandler) FreeTable(w http.ResponseWriter, r *http.Request) {
+	const op = "http.ReservationHandler.FreeTable"
+
+	log := h.loggerFor(r)
+
+	restaurantID, ok := h.pathID(w, r, op, "id")
+	if !ok {
+		return
+	}
+	tableID, ok := h.pathID(w, r, op, "tableID")
+	if !ok {
+		return
+	}
+	userID, role, ok := h.host(w, r, op)
+	if !ok {
+		return
+	}
+
+	if err := h.walkInService.FreeTable(r.Context(), userID, role, restaurantID, tableID); err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to free table", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// walkInTable runs a change of the walk-in from the path at the table from the optional body
+func (h *ReservationHandler) walkInTable(
+	w http.ResponseWriter,
+	r *http.Request,
+	op string,
+	change func(ctx context.Context, userID uint, role string, id, tableID uint) error,
+) {
+	log := h.loggerFor(r)
+
+	var req walkInTableRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	defer r.Body.Close()
+
+	if err := decoder.Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		log.Error("failed to decode request body", "error", fmt.Errorf("%s: bad request", op).Error())
+		return
+	}
+
+	id, ok := h.pathID(w, r, op, "id")
+	if !ok {
+		return
+	}
+	userID, role, ok := h.host(w, r, op)
+	if !ok {
+		return
+	}
+
+	if err := change(r.Context(), userID, role, id, req.TableID); err != nil {
+		status, msg := errorStatus(err)
+		http.Error(w, msg, status)
+		log.Error("failed to change walk-in", "error", fmt.Errorf("%s: %w", op, err).Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pathID parses the ID path value of the name and writes the error response if it's not one
+func (h *ReservationHandler) pathID(w http.ResponseWriter, r *http.Request, op, name string) (uint, bool) {
+	idStr := r.PathValue(name)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || idStr == "" {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		h.loggerFor(r).Error("bad request", "error", fmt.Errorf("%s: bad %s", op, name).Error())
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// host returns the user and role from the context, the service checks what they may do
+func (h *ReservationHandler) host(w http.ResponseWriter, r *http.Request, op string) (uint, string, bool) {
+	userID, ok := r.Context().Value(domain.UserIDKey).(uint)
+	if !ok {
+		http.Error(w, "user ID not found in context", http.StatusUnauthorized)
+		h.loggerFor(r).Error("user ID not found in context", "error", fmt.Errorf("%s: user ID missing", op).Error())
+		return 0, "", false
+	}
+	role, _ := r.Context().Value(domain.RoleKey).(string)
+	return userID, role, true
+}
+
+// writeJSON writes the response with the status
+func (h *ReservationHandler) writeJSON(w http.ResponseWriter, r *http.Request, op string, status int, res any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		h.loggerFor(r).Error("failed to encode response", "error", fmt.Errorf("%s: failed to encode response", op).Error())
+	}
+}
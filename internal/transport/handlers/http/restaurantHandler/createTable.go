@@ -31,9 +31,7 @@ func (r *createTableRequest) validate() error {
 func (h *RestraurantHandler) CreateTable(w http.ResponseWriter, r *http.Request) {
 	const op = "http.RestaurantHanlder.CreateTabler"
 
-	log := h.logger
-
-	log.Debug("request received", "method", r.Method, "path", r.URL.Path)
+	log := h.loggerFor(r)
 
 	var req createTableRequest
 	decoder := json.NewDecoder(r.Body)
@@ -61,7 +59,7 @@ func (h *RestraurantHandler) CreateTable(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Check if the user is the owner of the restaurant
-	isOwner, err := h.restaurantService.IsOwnerOfRestaurant(userID, req.RestaurantID)
+	isOwner, err := h.restaurantService.IsOwnerOfRestaurant(r.Context(), userID, req.RestaurantID)
 	if err != nil {
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 		log.Error("failed to verify if user is owner", "error", fmt.Errorf("%s: %w", op, err).Error())
@@ -79,7 +77,7 @@ func (h *RestraurantHandler) CreateTable(w http.ResponseWriter, r *http.Request)
 		RestaurantID: req.RestaurantID,
 	}
 
-	id, err := h.restaurantService.CreateTable(table)
+	id, err := h.restaurantService.CreateTable(r.Context(), table)
 	if err != nil {
 		if errors.Is(err, domain.ErrTableAlreadyExists) {
 			http.Error(w, "table already exists", http.StatusConflict)
@@ -1,22 +1,28 @@
 package restauranthandler
 
-import "github.com/kourai55k/booking-service/internal/domain/models"
+import (
+	"context"
+	"net/http"
+
+	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/pkg/logctx"
+)
 
 type RestaurantService interface {
-	CreateRestaurant(*models.Restaurant) (uint, error)
-	GetRestaurants() ([]*models.Restaurant, error)
-	GetRestaurantByID(uint) (*models.Restaurant, error)
-	UpdateRestraunt(*models.Restaurant) error
-	DeleteRestraunt(uint) error
-
-	CreateTable(*models.Table) (uint, error)
-	GetTablesByRestaurantID(uint) ([]*models.Table, error)
-	GetAvailableTablesByRestaurantID(uint) ([]*models.Table, error)
-	GetTableByID(uint) (*models.Table, error)
-	UpdateTable(*models.Table) error
-	DeleteTable(uint) error
-
-	IsOwnerOfRestaurant(uint, uint) (bool, error)
+	CreateRestaurant(context.Context, *models.Restaurant) (uint, error)
+	GetRestaurants(context.Context) ([]*models.Restaurant, error)
+	GetRestaurantByID(context.Context, uint) (*models.Restaurant, error)
+	UpdateRestraunt(context.Context, *models.Restaurant) error
+	DeleteRestraunt(context.Context, uint) error
+
+	CreateTable(context.Context, *models.Table) (uint, error)
+	GetTablesByRestaurantID(context.Context, uint) ([]*models.Table, error)
+	GetAvailableTablesByRestaurantID(context.Context, uint) ([]*models.Table, error)
+	GetTableByID(context.Context, uint) (*models.Table, error)
+	UpdateTable(context.Context, *models.Table, *bool) error
+	DeleteTable(context.Context, uint) error
+
+	IsOwnerOfRestaurant(context.Context, uint, uint) (bool, error)
 }
 
 type Logger interface {
@@ -34,3 +40,11 @@ type RestraurantHandler struct {
 func NewRestaurantHandler(restaurantService RestaurantService, logger Logger) *RestraurantHandler {
 	return &RestraurantHandler{restaurantService: restaurantService, logger: logger}
 }
+
+// loggerFor returns the request-scoped logger set by RequestIDMiddleware, or the handler's own logger
+func (h *RestraurantHandler) loggerFor(r *http.Request) Logger {
+	if log := logctx.FromContext(r.Context()); log != nil {
+		return log
+	}
+	return h.logger
+}
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"log/slog"
 	"net/http"
 
 	"github.com/kourai55k/booking-service/internal/transport/handlers/http/middleware"
@@ -21,47 +22,174 @@ type AuthHandler interface {
 	Login(w http.ResponseWriter, r *http.Request)
 }
 
+type ReservationHandler interface {
+	CreateReservation(w http.ResponseWriter, r *http.Request)
+	GetReservationByID(w http.ResponseWriter, r *http.Request)
+	GetMyReservations(w http.ResponseWriter, r *http.Request)
+	UpdateReservation(w http.ResponseWriter, r *http.Request)
+	CancelReservation(w http.ResponseWriter, r *http.Request)
+	ConfirmReservation(w http.ResponseWriter, r *http.Request)
+	SeatReservation(w http.ResponseWriter, r *http.Request)
+	CompleteReservation(w http.ResponseWriter, r *http.Request)
+	MarkNoShow(w http.ResponseWriter, r *http.Request)
+	GetReservationTransitions(w http.ResponseWriter, r *http.Request)
+	GetChangeQuote(w http.ResponseWriter, r *http.Request)
+	GetAvailability(w http.ResponseWriter, r *http.Request)
+	HoldTable(w http.ResponseWriter, r *http.Request)
+	ConvertHold(w http.ResponseWriter, r *http.Request)
+	ReleaseHold(w http.ResponseWriter, r *http.Request)
+	JoinWaitlist(w http.ResponseWriter, r *http.Request)
+	GetWaitlistEntry(w http.ResponseWriter, r *http.Request)
+	LeaveWaitlist(w http.ResponseWriter, r *http.Request)
+	QuoteWalkInWait(w http.ResponseWriter, r *http.Request)
+	JoinWalkInQueue(w http.ResponseWriter, r *http.Request)
+	GetWalkInQueue(w http.ResponseWriter, r *http.Request)
+	NotifyWalkIn(w http.ResponseWriter, r *http.Request)
+	SeatWalkIn(w http.ResponseWriter, r *http.Request)
+	LeaveWalkInQueue(w http.ResponseWriter, r *http.Request)
+	GetFloor(w http.ResponseWriter, r *http.Request)
+	OccupyTable(w http.ResponseWriter, r *http.Request)
+	FreeTable(w http.ResponseWriter, r *http.Request)
+	StreamLive(w http.ResponseWriter, r *http.Request)
+	IssueLiveTicket(w http.ResponseWriter, r *http.Request)
+}
+
+// Metrics instruments the routes, the metrics are served on the admin listener rather than by the router
+type Metrics interface {
+	InstrumentHandler(route string, next http.Handler) http.Handler
+}
+
+type Health interface {
+	LivenessHandler() http.Handler
+	ReadinessHandler() http.Handler
+}
+
 type Router struct {
-	mux         *http.ServeMux
-	userHandler UserHandler
-	authHandler AuthHandler
+	mux                *http.ServeMux
+	userHandler        UserHandler
+	authHandler        AuthHandler
+	reservationHandler ReservationHandler
+	limiter            *middleware.RateLimiter
+	cors               *middleware.CORS
+	metrics            Metrics
+	health             Health
+	handler            http.Handler
 }
 
-func NewRouter(userHandler UserHandler, authHandler AuthHandler) *Router {
+// NewRouter creates the router, limiter may be nil to disable rate limiting
+func NewRouter(
+	userHandler UserHandler,
+	authHandler AuthHandler,
+	reservationHandler ReservationHandler,
+	limiter *middleware.RateLimiter,
+	cors *middleware.CORS,
+	metrics Metrics,
+	health Health,
+	log *slog.Logger,
+) *Router {
 	r := &Router{
-		mux:         http.NewServeMux(),
-		userHandler: userHandler,
-		authHandler: authHandler,
+		mux:                http.NewServeMux(),
+		userHandler:        userHandler,
+		authHandler:        authHandler,
+		reservationHandler: reservationHandler,
+		limiter:            limiter,
+		cors:               cors,
+		metrics:            metrics,
+		health:             health,
 	}
 	r.RegisterRoutes()
+
+	// Every request gets a span first, then an ID and a request-scoped logger, so everything after can log both
+	// CORS goes before the limiter, so preflight requests don't use up the client's tokens
+	var h http.Handler = limiter.LimitByIP(middleware.PolicyDefault, r.mux)
+	h = cors.Middleware(h)
+	h = middleware.RecoveryMiddleware(log, h)
+	h = middleware.AccessLogMiddleware(log, h)
+	h = middleware.RequestIDMiddleware(log, h)
+	h = middleware.TracingMiddleware(h)
+
+	// Probes skip the middlewares: they mustn't be rate limited, and would only flood the logs and traces
+	root := http.NewServeMux()
+	root.Handle("GET /healthz", health.LivenessHandler())
+	root.Handle("GET /readyz", health.ReadinessHandler())
+	root.Handle("/", h)
+	r.handler = root
+
 	return r
 }
 
 func (r *Router) RegisterRoutes() *http.ServeMux {
 	// TODO: add middlewares to user routes
 	// users routes
-	r.mux.HandleFunc("GET /user/{id}", r.userHandler.GetUserByID)
-	r.mux.HandleFunc("GET /user", r.userHandler.GetUserByLogin)
-	r.mux.HandleFunc("GET /users", r.userHandler.GetUsers)
-	r.mux.HandleFunc("POST /user", r.userHandler.CreateUser)
-	r.mux.HandleFunc("PATCH /user/{id}", r.userHandler.UpdateUser)
-	r.mux.HandleFunc("DELETE /user/{id}", r.userHandler.DeleteUser)
+	r.handle("GET /user/{id}", http.HandlerFunc(r.userHandler.GetUserByID))
+	r.handle("GET /user", http.HandlerFunc(r.userHandler.GetUserByLogin))
+	r.handle("GET /users", http.HandlerFunc(r.userHandler.GetUsers))
+	r.handle("POST /user", http.HandlerFunc(r.userHandler.CreateUser))
+	r.handle("PATCH /user/{id}", http.HandlerFunc(r.userHandler.UpdateUser))
+	// deleting a user cancels their reservations, so it's for admins only like over gRPC
+	r.handle("DELETE /user/{id}", middleware.AdminMiddleware(http.HandlerFunc(r.userHandler.DeleteUser)))
 
 	// auth routes
-	r.mux.HandleFunc("/auth/register", r.authHandler.Register)
-	r.mux.HandleFunc("/auth/login", r.authHandler.Login)
+	r.handle("/auth/register", r.limiter.LimitByIP(middleware.PolicyAuth, http.HandlerFunc(r.authHandler.Register)))
+	r.handle("/auth/login", r.limiter.LimitByIP(middleware.PolicyAuth, http.HandlerFunc(r.authHandler.Login)))
 
 	// test route for testing middleware
-	r.mux.Handle("/protected/hello", middleware.AuthMiddleware(http.HandlerFunc(r.userHandler.ProtectedHello)))
-	r.mux.Handle("/admin/hello", middleware.AdminMiddleware(http.HandlerFunc(r.userHandler.ProtectedHello)))
+	r.handle("/protected/hello", middleware.AuthMiddleware(http.HandlerFunc(r.userHandler.ProtectedHello)))
+	r.handle("/admin/hello", middleware.AdminMiddleware(http.HandlerFunc(r.userHandler.ProtectedHello)))
 
 	// restrants routes
 
 	// bookings routes
+	r.handle("POST /reservations", r.authenticated(r.reservationHandler.CreateReservation))
+	r.handle("GET /reservations", r.authenticated(r.reservationHandler.GetMyReservations))
+	r.handle("GET /reservations/{id}", r.authenticated(r.reservationHandler.GetReservationByID))
+	r.handle("PATCH /reservations/{id}", r.authenticated(r.reservationHandler.UpdateReservation))
+	r.handle("DELETE /reservations/{id}", r.authenticated(r.reservationHandler.CancelReservation))
+	r.handle("GET /reservations/{id}/transitions", r.authenticated(r.reservationHandler.GetReservationTransitions))
+	r.handle("GET /reservations/{id}/policy", r.authenticated(r.reservationHandler.GetChangeQuote))
+	r.handle("POST /reservations/{id}/cancel", r.authenticated(r.reservationHandler.CancelReservation))
+	r.handle("POST /reservations/{id}/confirm", r.authenticated(r.reservationHandler.ConfirmReservation))
+	r.handle("POST /reservations/{id}/seat", r.authenticated(r.reservationHandler.SeatReservation))
+	r.handle("POST /reservations/{id}/complete", r.authenticated(r.reservationHandler.CompleteReservation))
+	r.handle("POST /reservations/{id}/no-show", r.authenticated(r.reservationHandler.MarkNoShow))
+	r.handle("GET /restaurants/{id}/availability", http.HandlerFunc(r.reservationHandler.GetAvailability))
+	r.handle("POST /restaurants/{id}/holds", r.authenticated(r.reservationHandler.HoldTable))
+	r.handle("POST /holds/{token}/reservation", r.authenticated(r.reservationHandler.ConvertHold))
+	r.handle("DELETE /holds/{token}", r.authenticated(r.reservationHandler.ReleaseHold))
+	r.handle("POST /restaurants/{id}/waitlist", r.authenticated(r.reservationHandler.JoinWaitlist))
+	r.handle("GET /waitlist/{id}", r.authenticated(r.reservationHandler.GetWaitlistEntry))
+	r.handle("DELETE /waitlist/{id}", r.authenticated(r.reservationHandler.LeaveWaitlist))
+
+	// host stand routes, for the owner of the restaurant and admins
+	r.handle("GET /restaurants/{id}/walk-ins/quote", r.authenticated(r.reservationHandler.QuoteWalkInWait))
+	r.handle("POST /restaurants/{id}/walk-ins", r.authenticated(r.reservationHandler.JoinWalkInQueue))
+	r.handle("GET /restaurants/{id}/walk-ins", r.authenticated(r.reservationHandler.GetWalkInQueue))
+	r.handle("POST /walk-ins/{id}/notify", r.authenticated(r.reservationHandler.NotifyWalkIn))
+	r.handle("POST /walk-ins/{id}/seat", r.authenticated(r.reservationHandler.SeatWalkIn))
+	r.handle("DELETE /walk-ins/{id}", r.authenticated(r.reservationHandler.LeaveWalkInQueue))
+	r.handle("GET /restaurants/{id}/floor", r.authenticated(r.reservationHandler.GetFloor))
+	r.handle("POST /restaurants/{id}/tables/{tableID}/occupy", r.authenticated(r.reservationHandler.OccupyTable))
+	r.handle("POST /restaurants/{id}/tables/{tableID}/free", r.authenticated(r.reservationHandler.FreeTable))
+	// live table and reservation events as SSE, or over WebSocket when upgraded, for owners and admins.
+	// Browsers open them with a stream ticket in the query instead of the Authorization header.
+	r.handle("POST /restaurants/{id}/live/ticket", r.authenticated(r.reservationHandler.IssueLiveTicket))
+	r.handle("GET /restaurants/{id}/live", middleware.StreamTicketMiddleware(
+		r.limiter.LimitByUser(middleware.PolicyUser, http.HandlerFunc(r.reservationHandler.StreamLive)),
+	))
 
 	return r.mux
 }
 
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	r.mux.ServeHTTP(w, req)
+	r.handler.ServeHTTP(w, req)
+}
+
+// handle registers the handler, instrumented with the route pattern as its metrics label and span name
+func (r *Router) handle(pattern string, h http.Handler) {
+	r.mux.Handle(pattern, middleware.TraceRoute(pattern, r.metrics.InstrumentHandler(pattern, h)))
+}
+
+// authenticated wraps a handler with AuthMiddleware and the per-user rate limit
+func (r *Router) authenticated(h http.HandlerFunc) http.Handler {
+	return middleware.AuthMiddleware(r.limiter.LimitByUser(middleware.PolicyUser, h))
 }
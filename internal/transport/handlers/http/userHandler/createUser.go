@@ -35,9 +35,7 @@ type createUserResponse struct {
 func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	const op = "http.UserHandler.CreateUser"
 
-	log := h.logger
-
-	log.Debug("request received", "method", r.Method, "path", r.URL.Path)
+	log := h.loggerFor(r)
 
 	var req createUserRequest
 	decoder := json.NewDecoder(r.Body)
@@ -70,7 +68,7 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		Role:     req.Role,
 	}
 
-	id, err := h.userService.CreateUser(user)
+	id, err := h.userService.CreateUser(r.Context(), user)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserAlreadyExists) {
 			http.Error(w, "user already exists", http.StatusConflict)
@@ -12,9 +12,7 @@ import (
 func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	const op = "http.userHandler.DeleteUser"
 
-	log := h.logger
-
-	log.Debug("request received", "method", r.Method, "path", r.URL.Path)
+	log := h.loggerFor(r)
 
 	// Extract the 'id' path parameter using Go 1.22's PathValue
 	idStr := r.PathValue("id")
@@ -27,13 +25,18 @@ func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.userService.DeleteUser(uint(id))
+	err = h.userService.DeleteUser(r.Context(), uint(id))
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
 			http.Error(w, "user not found", http.StatusNotFound)
 			log.Error("user not found", "err", fmt.Errorf("%s: %w", op, err).Error())
 			return
 		}
+		if errors.Is(err, domain.ErrUserOwnsRestaurants) {
+			http.Error(w, "user still owns restaurants", http.StatusConflict)
+			log.Error("user owns restaurants", "err", fmt.Errorf("%s: %w", op, err).Error())
+			return
+		}
 		http.Error(w, "failed to delete user", http.StatusInternalServerError)
 		log.Error("failed to delete user", "err", err.Error())
 		return
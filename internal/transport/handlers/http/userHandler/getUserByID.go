@@ -17,9 +17,7 @@ type getUserByIDResponse struct {
 
 func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
 	const op = "http.userHandler.GetUserByID"
-	log := h.logger
-
-	log.Debug("request received", "method", r.Method, "path", r.URL.Path)
+	log := h.loggerFor(r)
 
 	// Extract the 'id' path parameter using Go 1.22's PathValue
 	idStr := r.PathValue("id")
@@ -32,7 +30,7 @@ func (h *UserHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.userService.GetUserByID(uint(id))
+	user, err := h.userService.GetUserByID(r.Context(), uint(id))
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
 			http.Error(w, "user not found", http.StatusNotFound)
@@ -16,9 +16,7 @@ type getUserByLoginResponse struct {
 
 func (h *UserHandler) GetUserByLogin(w http.ResponseWriter, r *http.Request) {
 	const op = "http.userHandler.GetUserByID"
-	log := h.logger
-
-	log.Debug("request received", "method", r.Method, "path", r.URL.Path)
+	log := h.loggerFor(r)
 
 	login := r.URL.Query().Get("login")
 	if login == "" {
@@ -27,7 +25,7 @@ func (h *UserHandler) GetUserByLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.userService.GetUserByLogin(login)
+	user, err := h.userService.GetUserByLogin(r.Context(), login)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
 			http.Error(w, "user not found", http.StatusNotFound)
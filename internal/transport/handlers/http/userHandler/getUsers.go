@@ -16,11 +16,9 @@ type GetUsersResponse struct {
 
 func (h *UserHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
 	const op = "http.userHandler.GetUsers"
-	log := h.logger
+	log := h.loggerFor(r)
 
-	log.Debug("request received", "method", r.Method, "path", r.URL.Path)
-
-	users, err := h.userService.GetUsers()
+	users, err := h.userService.GetUsers(r.Context())
 	if err != nil {
 		if errors.Is(err, domain.ErrUsersNotFound) {
 			http.Error(w, "users not found", http.StatusNotFound)
@@ -36,9 +36,7 @@ func (r *updateUserRequest) Validate() error {
 func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	const op = "http.UserHandler.UpdateUser"
 
-	log := h.logger
-
-	log.Debug("request received", "method", r.Method, "path", r.URL.Path)
+	log := h.loggerFor(r)
 
 	var req updateUserRequest
 	decoder := json.NewDecoder(r.Body)
@@ -85,7 +83,7 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		Role:     req.Role,
 	}
 
-	if err := h.userService.UpdateUser(user); err != nil {
+	if err := h.userService.UpdateUser(r.Context(), user); err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
 			http.Error(w, "user not found", http.StatusNotFound)
 			log.Error("user not found", "error", fmt.Errorf("%s: %w", op, err).Error())
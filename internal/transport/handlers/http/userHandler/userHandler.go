@@ -1,20 +1,22 @@
 package userHandler
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/kourai55k/booking-service/internal/domain/models"
+	"github.com/kourai55k/booking-service/pkg/logctx"
 )
 
 //go:generate mockgen -source=userHandler.go -destination=mocks/mock_user_service.go -package=mocks
 type UserService interface {
-	GetUsers() ([]*models.User, error)
-	GetUserByID(id uint) (*models.User, error)
-	GetUserByLogin(login string) (*models.User, error)
-	CreateUser(user *models.User) (uint, error)
-	UpdateUser(user *models.User) error
-	DeleteUser(id uint) error
+	GetUsers(ctx context.Context) ([]*models.User, error)
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
+	GetUserByLogin(ctx context.Context, login string) (*models.User, error)
+	CreateUser(ctx context.Context, user *models.User) (uint, error)
+	UpdateUser(ctx context.Context, user *models.User) error
+	DeleteUser(ctx context.Context, id uint) error
 }
 
 type Logger interface {
@@ -33,6 +35,14 @@ func NewUserHandler(userService UserService, logger Logger) *UserHandler {
 	return &UserHandler{userService: userService, logger: logger}
 }
 
+// loggerFor returns the request-scoped logger set by RequestIDMiddleware, or the handler's own logger
+func (h *UserHandler) loggerFor(r *http.Request) Logger {
+	if log := logctx.FromContext(r.Context()); log != nil {
+		return log
+	}
+	return h.logger
+}
+
 // delete this after testing
 func (h *UserHandler) ProtectedHello(w http.ResponseWriter, r *http.Request) {
 	// Here you could use the user's information from the JWT token if needed
@@ -0,0 +1,802 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: booking/v1/common.proto
+
+package bookingv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Login         string                 `protobuf:"bytes,3,opt,name=login,proto3" json:"login,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_booking_v1_common_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_common_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_booking_v1_common_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type OpeningHours struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DayOfWeek     string                 `protobuf:"bytes,1,opt,name=day_of_week,json=dayOfWeek,proto3" json:"day_of_week,omitempty"`
+	OpenTime      string                 `protobuf:"bytes,2,opt,name=open_time,json=openTime,proto3" json:"open_time,omitempty"`
+	CloseTime     string                 `protobuf:"bytes,3,opt,name=close_time,json=closeTime,proto3" json:"close_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpeningHours) Reset() {
+	*x = OpeningHours{}
+	mi := &file_booking_v1_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpeningHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpeningHours) ProtoMessage() {}
+
+func (x *OpeningHours) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpeningHours.ProtoReflect.Descriptor instead.
+func (*OpeningHours) Descriptor() ([]byte, []int) {
+	return file_booking_v1_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OpeningHours) GetDayOfWeek() string {
+	if x != nil {
+		return x.DayOfWeek
+	}
+	return ""
+}
+
+func (x *OpeningHours) GetOpenTime() string {
+	if x != nil {
+		return x.OpenTime
+	}
+	return ""
+}
+
+func (x *OpeningHours) GetCloseTime() string {
+	if x != nil {
+		return x.CloseTime
+	}
+	return ""
+}
+
+type Restaurant struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Address      string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	OpeningHours []*OpeningHours        `protobuf:"bytes,5,rep,name=opening_hours,json=openingHours,proto3" json:"opening_hours,omitempty"`
+	OwnerId      uint64                 `protobuf:"varint,6,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	// How tables are assigned to parties booking without one: "tightest_fit" or "spread_load".
+	TableAssignment string        `protobuf:"bytes,7,opt,name=table_assignment,json=tableAssignment,proto3" json:"table_assignment,omitempty"`
+	BookingRules    *BookingRules `protobuf:"bytes,8,opt,name=booking_rules,json=bookingRules,proto3" json:"booking_rules,omitempty"`
+	// IANA name of the time zone the opening hours are in.
+	TimeZone      string `protobuf:"bytes,9,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Restaurant) Reset() {
+	*x = Restaurant{}
+	mi := &file_booking_v1_common_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Restaurant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Restaurant) ProtoMessage() {}
+
+func (x *Restaurant) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_common_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Restaurant.ProtoReflect.Descriptor instead.
+func (*Restaurant) Descriptor() ([]byte, []int) {
+	return file_booking_v1_common_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Restaurant) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Restaurant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Restaurant) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Restaurant) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Restaurant) GetOpeningHours() []*OpeningHours {
+	if x != nil {
+		return x.OpeningHours
+	}
+	return nil
+}
+
+func (x *Restaurant) GetOwnerId() uint64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *Restaurant) GetTableAssignment() string {
+	if x != nil {
+		return x.TableAssignment
+	}
+	return ""
+}
+
+func (x *Restaurant) GetBookingRules() *BookingRules {
+	if x != nil {
+		return x.BookingRules
+	}
+	return nil
+}
+
+func (x *Restaurant) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// The limits a restaurant takes reservations within, zero values don't limit.
+type BookingRules struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Default seating durations by party size, a reservation without an end time lasts
+	// the one with the smallest max_party_size that seats its party.
+	Durations []*SeatingDuration `protobuf:"bytes,1,rep,name=durations,proto3" json:"durations,omitempty"`
+	// Minutes a table is cleaned for after a seating, it stays taken meanwhile.
+	BufferMinutes uint64 `protobuf:"varint,2,opt,name=buffer_minutes,json=bufferMinutes,proto3" json:"buffer_minutes,omitempty"`
+	// Minutes before its start a reservation must be made.
+	MinLeadMinutes uint64 `protobuf:"varint,3,opt,name=min_lead_minutes,json=minLeadMinutes,proto3" json:"min_lead_minutes,omitempty"`
+	MaxDaysAhead   uint64 `protobuf:"varint,4,opt,name=max_days_ahead,json=maxDaysAhead,proto3" json:"max_days_ahead,omitempty"`
+	// Minutes before closing the last reservation may start, in the time zone of the reservation.
+	LastSeatingMinutes uint64 `protobuf:"varint,5,opt,name=last_seating_minutes,json=lastSeatingMinutes,proto3" json:"last_seating_minutes,omitempty"`
+	MinPartySize       uint64 `protobuf:"varint,6,opt,name=min_party_size,json=minPartySize,proto3" json:"min_party_size,omitempty"`
+	MaxPartySize       uint64 `protobuf:"varint,7,opt,name=max_party_size,json=maxPartySize,proto3" json:"max_party_size,omitempty"`
+	// Guests and parties that may arrive in every 15 minutes, counted from the full quarter hour.
+	MaxCoversPerInterval  uint64        `protobuf:"varint,8,opt,name=max_covers_per_interval,json=maxCoversPerInterval,proto3" json:"max_covers_per_interval,omitempty"`
+	MaxPartiesPerInterval uint64        `protobuf:"varint,9,opt,name=max_parties_per_interval,json=maxPartiesPerInterval,proto3" json:"max_parties_per_interval,omitempty"`
+	ChangePolicy          *ChangePolicy `protobuf:"bytes,10,opt,name=change_policy,json=changePolicy,proto3" json:"change_policy,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *BookingRules) Reset() {
+	*x = BookingRules{}
+	mi := &file_booking_v1_common_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingRules) ProtoMessage() {}
+
+func (x *BookingRules) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_common_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingRules.ProtoReflect.Descriptor instead.
+func (*BookingRules) Descriptor() ([]byte, []int) {
+	return file_booking_v1_common_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BookingRules) GetDurations() []*SeatingDuration {
+	if x != nil {
+		return x.Durations
+	}
+	return nil
+}
+
+func (x *BookingRules) GetBufferMinutes() uint64 {
+	if x != nil {
+		return x.BufferMinutes
+	}
+	return 0
+}
+
+func (x *BookingRules) GetMinLeadMinutes() uint64 {
+	if x != nil {
+		return x.MinLeadMinutes
+	}
+	return 0
+}
+
+func (x *BookingRules) GetMaxDaysAhead() uint64 {
+	if x != nil {
+		return x.MaxDaysAhead
+	}
+	return 0
+}
+
+func (x *BookingRules) GetLastSeatingMinutes() uint64 {
+	if x != nil {
+		return x.LastSeatingMinutes
+	}
+	return 0
+}
+
+func (x *BookingRules) GetMinPartySize() uint64 {
+	if x != nil {
+		return x.MinPartySize
+	}
+	return 0
+}
+
+func (x *BookingRules) GetMaxPartySize() uint64 {
+	if x != nil {
+		return x.MaxPartySize
+	}
+	return 0
+}
+
+func (x *BookingRules) GetMaxCoversPerInterval() uint64 {
+	if x != nil {
+		return x.MaxCoversPerInterval
+	}
+	return 0
+}
+
+func (x *BookingRules) GetMaxPartiesPerInterval() uint64 {
+	if x != nil {
+		return x.MaxPartiesPerInterval
+	}
+	return 0
+}
+
+func (x *BookingRules) GetChangePolicy() *ChangePolicy {
+	if x != nil {
+		return x.ChangePolicy
+	}
+	return nil
+}
+
+// What guests pay for late changes of their reservations, fees are in minor currency units.
+// Changes made by the restaurant are free.
+type ChangePolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Minutes before its start from which cancelling a reservation costs the cancellation fee.
+	CancellationCutoffMinutes uint64 `protobuf:"varint,1,opt,name=cancellation_cutoff_minutes,json=cancellationCutoffMinutes,proto3" json:"cancellation_cutoff_minutes,omitempty"`
+	CancellationFee           uint64 `protobuf:"varint,2,opt,name=cancellation_fee,json=cancellationFee,proto3" json:"cancellation_fee,omitempty"`
+	// Minutes before its start from which changing a reservation costs the modification fee.
+	ModificationCutoffMinutes uint64 `protobuf:"varint,3,opt,name=modification_cutoff_minutes,json=modificationCutoffMinutes,proto3" json:"modification_cutoff_minutes,omitempty"`
+	ModificationFee           uint64 `protobuf:"varint,4,opt,name=modification_fee,json=modificationFee,proto3" json:"modification_fee,omitempty"`
+	// Times a guest may change a reservation, 0 for any.
+	MaxModifications uint64 `protobuf:"varint,5,opt,name=max_modifications,json=maxModifications,proto3" json:"max_modifications,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ChangePolicy) Reset() {
+	*x = ChangePolicy{}
+	mi := &file_booking_v1_common_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePolicy) ProtoMessage() {}
+
+func (x *ChangePolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_common_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePolicy.ProtoReflect.Descriptor instead.
+func (*ChangePolicy) Descriptor() ([]byte, []int) {
+	return file_booking_v1_common_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ChangePolicy) GetCancellationCutoffMinutes() uint64 {
+	if x != nil {
+		return x.CancellationCutoffMinutes
+	}
+	return 0
+}
+
+func (x *ChangePolicy) GetCancellationFee() uint64 {
+	if x != nil {
+		return x.CancellationFee
+	}
+	return 0
+}
+
+func (x *ChangePolicy) GetModificationCutoffMinutes() uint64 {
+	if x != nil {
+		return x.ModificationCutoffMinutes
+	}
+	return 0
+}
+
+func (x *ChangePolicy) GetModificationFee() uint64 {
+	if x != nil {
+		return x.ModificationFee
+	}
+	return 0
+}
+
+func (x *ChangePolicy) GetMaxModifications() uint64 {
+	if x != nil {
+		return x.MaxModifications
+	}
+	return 0
+}
+
+type SeatingDuration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxPartySize  uint64                 `protobuf:"varint,1,opt,name=max_party_size,json=maxPartySize,proto3" json:"max_party_size,omitempty"`
+	Minutes       uint64                 `protobuf:"varint,2,opt,name=minutes,proto3" json:"minutes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SeatingDuration) Reset() {
+	*x = SeatingDuration{}
+	mi := &file_booking_v1_common_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SeatingDuration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatingDuration) ProtoMessage() {}
+
+func (x *SeatingDuration) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_common_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatingDuration.ProtoReflect.Descriptor instead.
+func (*SeatingDuration) Descriptor() ([]byte, []int) {
+	return file_booking_v1_common_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SeatingDuration) GetMaxPartySize() uint64 {
+	if x != nil {
+		return x.MaxPartySize
+	}
+	return 0
+}
+
+func (x *SeatingDuration) GetMinutes() uint64 {
+	if x != nil {
+		return x.Minutes
+	}
+	return 0
+}
+
+type Table struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number       uint64                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Capacity     uint64                 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	IsAvailable  bool                   `protobuf:"varint,4,opt,name=is_available,json=isAvailable,proto3" json:"is_available,omitempty"`
+	RestaurantId uint64                 `protobuf:"varint,5,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	// The smallest party seated at the table, 0 for any.
+	MinPartySize uint64 `protobuf:"varint,6,opt,name=min_party_size,json=minPartySize,proto3" json:"min_party_size,omitempty"`
+	// Features guests can ask for, like "window" or "accessible".
+	Attributes    []string `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Table) Reset() {
+	*x = Table{}
+	mi := &file_booking_v1_common_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Table) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Table) ProtoMessage() {}
+
+func (x *Table) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_common_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Table.ProtoReflect.Descriptor instead.
+func (*Table) Descriptor() ([]byte, []int) {
+	return file_booking_v1_common_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Table) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Table) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Table) GetCapacity() uint64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Table) GetIsAvailable() bool {
+	if x != nil {
+		return x.IsAvailable
+	}
+	return false
+}
+
+func (x *Table) GetRestaurantId() uint64 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *Table) GetMinPartySize() uint64 {
+	if x != nil {
+		return x.MinPartySize
+	}
+	return 0
+}
+
+func (x *Table) GetAttributes() []string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// Tables that can be joined to seat a party larger than any one of them.
+type TableCombination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TableIds      []uint64               `protobuf:"varint,2,rep,packed,name=table_ids,json=tableIds,proto3" json:"table_ids,omitempty"`
+	Capacity      uint64                 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	RestaurantId  uint64                 `protobuf:"varint,4,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableCombination) Reset() {
+	*x = TableCombination{}
+	mi := &file_booking_v1_common_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableCombination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableCombination) ProtoMessage() {}
+
+func (x *TableCombination) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_common_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableCombination.ProtoReflect.Descriptor instead.
+func (*TableCombination) Descriptor() ([]byte, []int) {
+	return file_booking_v1_common_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TableCombination) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TableCombination) GetTableIds() []uint64 {
+	if x != nil {
+		return x.TableIds
+	}
+	return nil
+}
+
+func (x *TableCombination) GetCapacity() uint64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *TableCombination) GetRestaurantId() uint64 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+var File_booking_v1_common_proto protoreflect.FileDescriptor
+
+const file_booking_v1_common_proto_rawDesc = "" +
+	"\n" +
+	"\x17booking/v1/common.proto\x12\n" +
+	"booking.v1\"T\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05login\x18\x03 \x01(\tR\x05login\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\"j\n" +
+	"\fOpeningHours\x12\x1e\n" +
+	"\vday_of_week\x18\x01 \x01(\tR\tdayOfWeek\x12\x1b\n" +
+	"\topen_time\x18\x02 \x01(\tR\bopenTime\x12\x1d\n" +
+	"\n" +
+	"close_time\x18\x03 \x01(\tR\tcloseTime\"\xcd\x02\n" +
+	"\n" +
+	"Restaurant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12=\n" +
+	"\ropening_hours\x18\x05 \x03(\v2\x18.booking.v1.OpeningHoursR\fopeningHours\x12\x19\n" +
+	"\bowner_id\x18\x06 \x01(\x04R\aownerId\x12)\n" +
+	"\x10table_assignment\x18\a \x01(\tR\x0ftableAssignment\x12=\n" +
+	"\rbooking_rules\x18\b \x01(\v2\x18.booking.v1.BookingRulesR\fbookingRules\x12\x1b\n" +
+	"\ttime_zone\x18\t \x01(\tR\btimeZone\"\xed\x03\n" +
+	"\fBookingRules\x129\n" +
+	"\tdurations\x18\x01 \x03(\v2\x1b.booking.v1.SeatingDurationR\tdurations\x12%\n" +
+	"\x0ebuffer_minutes\x18\x02 \x01(\x04R\rbufferMinutes\x12(\n" +
+	"\x10min_lead_minutes\x18\x03 \x01(\x04R\x0eminLeadMinutes\x12$\n" +
+	"\x0emax_days_ahead\x18\x04 \x01(\x04R\fmaxDaysAhead\x120\n" +
+	"\x14last_seating_minutes\x18\x05 \x01(\x04R\x12lastSeatingMinutes\x12$\n" +
+	"\x0emin_party_size\x18\x06 \x01(\x04R\fminPartySize\x12$\n" +
+	"\x0emax_party_size\x18\a \x01(\x04R\fmaxPartySize\x125\n" +
+	"\x17max_covers_per_interval\x18\b \x01(\x04R\x14maxCoversPerInterval\x127\n" +
+	"\x18max_parties_per_interval\x18\t \x01(\x04R\x15maxPartiesPerInterval\x12=\n" +
+	"\rchange_policy\x18\n" +
+	" \x01(\v2\x18.booking.v1.ChangePolicyR\fchangePolicy\"\x91\x02\n" +
+	"\fChangePolicy\x12>\n" +
+	"\x1bcancellation_cutoff_minutes\x18\x01 \x01(\x04R\x19cancellationCutoffMinutes\x12)\n" +
+	"\x10cancellation_fee\x18\x02 \x01(\x04R\x0fcancellationFee\x12>\n" +
+	"\x1bmodification_cutoff_minutes\x18\x03 \x01(\x04R\x19modificationCutoffMinutes\x12)\n" +
+	"\x10modification_fee\x18\x04 \x01(\x04R\x0fmodificationFee\x12+\n" +
+	"\x11max_modifications\x18\x05 \x01(\x04R\x10maxModifications\"Q\n" +
+	"\x0fSeatingDuration\x12$\n" +
+	"\x0emax_party_size\x18\x01 \x01(\x04R\fmaxPartySize\x12\x18\n" +
+	"\aminutes\x18\x02 \x01(\x04R\aminutes\"\xd9\x01\n" +
+	"\x05Table\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\x04R\x06number\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x04R\bcapacity\x12!\n" +
+	"\fis_available\x18\x04 \x01(\bR\visAvailable\x12#\n" +
+	"\rrestaurant_id\x18\x05 \x01(\x04R\frestaurantId\x12$\n" +
+	"\x0emin_party_size\x18\x06 \x01(\x04R\fminPartySize\x12\x1e\n" +
+	"\n" +
+	"attributes\x18\a \x03(\tR\n" +
+	"attributes\"\x80\x01\n" +
+	"\x10TableCombination\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1b\n" +
+	"\ttable_ids\x18\x02 \x03(\x04R\btableIds\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x04R\bcapacity\x12#\n" +
+	"\rrestaurant_id\x18\x04 \x01(\x04R\frestaurantIdBCZAgithub.com/kourai55k/booking-service/pkg/api/booking/v1;bookingv1b\x06proto3"
+
+var (
+	file_booking_v1_common_proto_rawDescOnce sync.Once
+	file_booking_v1_common_proto_rawDescData []byte
+)
+
+func file_booking_v1_common_proto_rawDescGZIP() []byte {
+	file_booking_v1_common_proto_rawDescOnce.Do(func() {
+		file_booking_v1_common_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_booking_v1_common_proto_rawDesc), len(file_booking_v1_common_proto_rawDesc)))
+	})
+	return file_booking_v1_common_proto_rawDescData
+}
+
+var file_booking_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_booking_v1_common_proto_goTypes = []any{
+	(*User)(nil),             // 0: booking.v1.User
+	(*OpeningHours)(nil),     // 1: booking.v1.OpeningHours
+	(*Restaurant)(nil),       // 2: booking.v1.Restaurant
+	(*BookingRules)(nil),     // 3: booking.v1.BookingRules
+	(*ChangePolicy)(nil),     // 4: booking.v1.ChangePolicy
+	(*SeatingDuration)(nil),  // 5: booking.v1.SeatingDuration
+	(*Table)(nil),            // 6: booking.v1.Table
+	(*TableCombination)(nil), // 7: booking.v1.TableCombination
+}
+var file_booking_v1_common_proto_depIdxs = []int32{
+	1, // 0: booking.v1.Restaurant.opening_hours:type_name -> booking.v1.OpeningHours
+	3, // 1: booking.v1.Restaurant.booking_rules:type_name -> booking.v1.BookingRules
+	5, // 2: booking.v1.BookingRules.durations:type_name -> booking.v1.SeatingDuration
+	4, // 3: booking.v1.BookingRules.change_policy:type_name -> booking.v1.ChangePolicy
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_booking_v1_common_proto_init() }
+func file_booking_v1_common_proto_init() {
+	if File_booking_v1_common_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_booking_v1_common_proto_rawDesc), len(file_booking_v1_common_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_booking_v1_common_proto_goTypes,
+		DependencyIndexes: file_booking_v1_common_proto_depIdxs,
+		MessageInfos:      file_booking_v1_common_proto_msgTypes,
+	}.Build()
+	File_booking_v1_common_proto = out.File
+	file_booking_v1_common_proto_goTypes = nil
+	file_booking_v1_common_proto_depIdxs = nil
+}
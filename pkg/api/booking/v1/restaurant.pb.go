@@ -0,0 +1,1687 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: booking/v1/restaurant.proto
+
+package bookingv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateRestaurantRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Name         string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Address      string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	OpeningHours []*OpeningHours        `protobuf:"bytes,4,rep,name=opening_hours,json=openingHours,proto3" json:"opening_hours,omitempty"`
+	// "tightest_fit" (the default) or "spread_load".
+	TableAssignment string        `protobuf:"bytes,5,opt,name=table_assignment,json=tableAssignment,proto3" json:"table_assignment,omitempty"`
+	BookingRules    *BookingRules `protobuf:"bytes,6,opt,name=booking_rules,json=bookingRules,proto3" json:"booking_rules,omitempty"`
+	// IANA name of the time zone the opening hours are in, like "Europe/Berlin", "UTC" by default.
+	TimeZone      string `protobuf:"bytes,7,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRestaurantRequest) Reset() {
+	*x = CreateRestaurantRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRestaurantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRestaurantRequest) ProtoMessage() {}
+
+func (x *CreateRestaurantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRestaurantRequest.ProtoReflect.Descriptor instead.
+func (*CreateRestaurantRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateRestaurantRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateRestaurantRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateRestaurantRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CreateRestaurantRequest) GetOpeningHours() []*OpeningHours {
+	if x != nil {
+		return x.OpeningHours
+	}
+	return nil
+}
+
+func (x *CreateRestaurantRequest) GetTableAssignment() string {
+	if x != nil {
+		return x.TableAssignment
+	}
+	return ""
+}
+
+func (x *CreateRestaurantRequest) GetBookingRules() *BookingRules {
+	if x != nil {
+		return x.BookingRules
+	}
+	return nil
+}
+
+func (x *CreateRestaurantRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type CreateRestaurantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRestaurantResponse) Reset() {
+	*x = CreateRestaurantResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRestaurantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRestaurantResponse) ProtoMessage() {}
+
+func (x *CreateRestaurantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRestaurantResponse.ProtoReflect.Descriptor instead.
+func (*CreateRestaurantResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateRestaurantResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetRestaurantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRestaurantsRequest) Reset() {
+	*x = GetRestaurantsRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRestaurantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRestaurantsRequest) ProtoMessage() {}
+
+func (x *GetRestaurantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRestaurantsRequest.ProtoReflect.Descriptor instead.
+func (*GetRestaurantsRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{2}
+}
+
+type GetRestaurantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Restaurants   []*Restaurant          `protobuf:"bytes,1,rep,name=restaurants,proto3" json:"restaurants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRestaurantsResponse) Reset() {
+	*x = GetRestaurantsResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRestaurantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRestaurantsResponse) ProtoMessage() {}
+
+func (x *GetRestaurantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRestaurantsResponse.ProtoReflect.Descriptor instead.
+func (*GetRestaurantsResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRestaurantsResponse) GetRestaurants() []*Restaurant {
+	if x != nil {
+		return x.Restaurants
+	}
+	return nil
+}
+
+type GetRestaurantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRestaurantRequest) Reset() {
+	*x = GetRestaurantRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRestaurantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRestaurantRequest) ProtoMessage() {}
+
+func (x *GetRestaurantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRestaurantRequest.ProtoReflect.Descriptor instead.
+func (*GetRestaurantRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRestaurantRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetRestaurantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Restaurant    *Restaurant            `protobuf:"bytes,1,opt,name=restaurant,proto3" json:"restaurant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRestaurantResponse) Reset() {
+	*x = GetRestaurantResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRestaurantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRestaurantResponse) ProtoMessage() {}
+
+func (x *GetRestaurantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRestaurantResponse.ProtoReflect.Descriptor instead.
+func (*GetRestaurantResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetRestaurantResponse) GetRestaurant() *Restaurant {
+	if x != nil {
+		return x.Restaurant
+	}
+	return nil
+}
+
+// Empty fields are left unchanged.
+type UpdateRestaurantRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Address         string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	OpeningHours    []*OpeningHours        `protobuf:"bytes,5,rep,name=opening_hours,json=openingHours,proto3" json:"opening_hours,omitempty"`
+	TableAssignment string                 `protobuf:"bytes,6,opt,name=table_assignment,json=tableAssignment,proto3" json:"table_assignment,omitempty"`
+	TimeZone        string                 `protobuf:"bytes,7,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateRestaurantRequest) Reset() {
+	*x = UpdateRestaurantRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRestaurantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRestaurantRequest) ProtoMessage() {}
+
+func (x *UpdateRestaurantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRestaurantRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRestaurantRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateRestaurantRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateRestaurantRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetOpeningHours() []*OpeningHours {
+	if x != nil {
+		return x.OpeningHours
+	}
+	return nil
+}
+
+func (x *UpdateRestaurantRequest) GetTableAssignment() string {
+	if x != nil {
+		return x.TableAssignment
+	}
+	return ""
+}
+
+func (x *UpdateRestaurantRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type UpdateRestaurantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRestaurantResponse) Reset() {
+	*x = UpdateRestaurantResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRestaurantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRestaurantResponse) ProtoMessage() {}
+
+func (x *UpdateRestaurantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRestaurantResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRestaurantResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{7}
+}
+
+type DeleteRestaurantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRestaurantRequest) Reset() {
+	*x = DeleteRestaurantRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRestaurantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRestaurantRequest) ProtoMessage() {}
+
+func (x *DeleteRestaurantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRestaurantRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRestaurantRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteRestaurantRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteRestaurantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRestaurantResponse) Reset() {
+	*x = DeleteRestaurantResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRestaurantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRestaurantResponse) ProtoMessage() {}
+
+func (x *DeleteRestaurantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRestaurantResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRestaurantResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{9}
+}
+
+// Replaces all the booking rules of the restaurant.
+type UpdateBookingRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId  uint64                 `protobuf:"varint,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	BookingRules  *BookingRules          `protobuf:"bytes,2,opt,name=booking_rules,json=bookingRules,proto3" json:"booking_rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBookingRulesRequest) Reset() {
+	*x = UpdateBookingRulesRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBookingRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBookingRulesRequest) ProtoMessage() {}
+
+func (x *UpdateBookingRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBookingRulesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBookingRulesRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateBookingRulesRequest) GetRestaurantId() uint64 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *UpdateBookingRulesRequest) GetBookingRules() *BookingRules {
+	if x != nil {
+		return x.BookingRules
+	}
+	return nil
+}
+
+type UpdateBookingRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBookingRulesResponse) Reset() {
+	*x = UpdateBookingRulesResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBookingRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBookingRulesResponse) ProtoMessage() {}
+
+func (x *UpdateBookingRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBookingRulesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateBookingRulesResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{11}
+}
+
+type CreateTableRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId  uint64                 `protobuf:"varint,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	Number        uint64                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Capacity      uint64                 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	MinPartySize  uint64                 `protobuf:"varint,4,opt,name=min_party_size,json=minPartySize,proto3" json:"min_party_size,omitempty"`
+	Attributes    []string               `protobuf:"bytes,5,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTableRequest) Reset() {
+	*x = CreateTableRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTableRequest) ProtoMessage() {}
+
+func (x *CreateTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTableRequest.ProtoReflect.Descriptor instead.
+func (*CreateTableRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateTableRequest) GetRestaurantId() uint64 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *CreateTableRequest) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *CreateTableRequest) GetCapacity() uint64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *CreateTableRequest) GetMinPartySize() uint64 {
+	if x != nil {
+		return x.MinPartySize
+	}
+	return 0
+}
+
+func (x *CreateTableRequest) GetAttributes() []string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type CreateTableResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTableResponse) Reset() {
+	*x = CreateTableResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTableResponse) ProtoMessage() {}
+
+func (x *CreateTableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTableResponse.ProtoReflect.Descriptor instead.
+func (*CreateTableResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateTableResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetTablesRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId uint64                 `protobuf:"varint,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	// Return only tables that are currently available.
+	OnlyAvailable bool `protobuf:"varint,2,opt,name=only_available,json=onlyAvailable,proto3" json:"only_available,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTablesRequest) Reset() {
+	*x = GetTablesRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTablesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTablesRequest) ProtoMessage() {}
+
+func (x *GetTablesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTablesRequest.ProtoReflect.Descriptor instead.
+func (*GetTablesRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetTablesRequest) GetRestaurantId() uint64 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *GetTablesRequest) GetOnlyAvailable() bool {
+	if x != nil {
+		return x.OnlyAvailable
+	}
+	return false
+}
+
+type GetTablesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tables        []*Table               `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTablesResponse) Reset() {
+	*x = GetTablesResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTablesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTablesResponse) ProtoMessage() {}
+
+func (x *GetTablesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTablesResponse.ProtoReflect.Descriptor instead.
+func (*GetTablesResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetTablesResponse) GetTables() []*Table {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+type GetTableRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTableRequest) Reset() {
+	*x = GetTableRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTableRequest) ProtoMessage() {}
+
+func (x *GetTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTableRequest.ProtoReflect.Descriptor instead.
+func (*GetTableRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetTableRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetTableResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Table         *Table                 `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTableResponse) Reset() {
+	*x = GetTableResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTableResponse) ProtoMessage() {}
+
+func (x *GetTableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTableResponse.ProtoReflect.Descriptor instead.
+func (*GetTableResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetTableResponse) GetTable() *Table {
+	if x != nil {
+		return x.Table
+	}
+	return nil
+}
+
+type UpdateTableRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number   uint64                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Capacity uint64                 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// Unset keeps the availability.
+	IsAvailable *bool `protobuf:"varint,4,opt,name=is_available,json=isAvailable,proto3,oneof" json:"is_available,omitempty"`
+	// 0 keeps the minimum party size, 1 removes it.
+	MinPartySize uint64 `protobuf:"varint,5,opt,name=min_party_size,json=minPartySize,proto3" json:"min_party_size,omitempty"`
+	// Replaces the attributes when set, an empty list removes them.
+	Attributes    *TableAttributes `protobuf:"bytes,6,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTableRequest) Reset() {
+	*x = UpdateTableRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTableRequest) ProtoMessage() {}
+
+func (x *UpdateTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTableRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTableRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateTableRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTableRequest) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *UpdateTableRequest) GetCapacity() uint64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *UpdateTableRequest) GetIsAvailable() bool {
+	if x != nil && x.IsAvailable != nil {
+		return *x.IsAvailable
+	}
+	return false
+}
+
+func (x *UpdateTableRequest) GetMinPartySize() uint64 {
+	if x != nil {
+		return x.MinPartySize
+	}
+	return 0
+}
+
+func (x *UpdateTableRequest) GetAttributes() *TableAttributes {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type TableAttributes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableAttributes) Reset() {
+	*x = TableAttributes{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableAttributes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableAttributes) ProtoMessage() {}
+
+func (x *TableAttributes) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableAttributes.ProtoReflect.Descriptor instead.
+func (*TableAttributes) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TableAttributes) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type UpdateTableResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTableResponse) Reset() {
+	*x = UpdateTableResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTableResponse) ProtoMessage() {}
+
+func (x *UpdateTableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTableResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTableResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{20}
+}
+
+type DeleteTableRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTableRequest) Reset() {
+	*x = DeleteTableRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTableRequest) ProtoMessage() {}
+
+func (x *DeleteTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTableRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTableRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteTableRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteTableResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTableResponse) Reset() {
+	*x = DeleteTableResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTableResponse) ProtoMessage() {}
+
+func (x *DeleteTableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTableResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTableResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{22}
+}
+
+type CreateTableCombinationRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId uint64                 `protobuf:"varint,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	TableIds     []uint64               `protobuf:"varint,2,rep,packed,name=table_ids,json=tableIds,proto3" json:"table_ids,omitempty"`
+	// The party the joined tables seat, 0 for the sum of their capacities.
+	Capacity      uint64 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTableCombinationRequest) Reset() {
+	*x = CreateTableCombinationRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTableCombinationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTableCombinationRequest) ProtoMessage() {}
+
+func (x *CreateTableCombinationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTableCombinationRequest.ProtoReflect.Descriptor instead.
+func (*CreateTableCombinationRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateTableCombinationRequest) GetRestaurantId() uint64 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *CreateTableCombinationRequest) GetTableIds() []uint64 {
+	if x != nil {
+		return x.TableIds
+	}
+	return nil
+}
+
+func (x *CreateTableCombinationRequest) GetCapacity() uint64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+type CreateTableCombinationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTableCombinationResponse) Reset() {
+	*x = CreateTableCombinationResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTableCombinationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTableCombinationResponse) ProtoMessage() {}
+
+func (x *CreateTableCombinationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTableCombinationResponse.ProtoReflect.Descriptor instead.
+func (*CreateTableCombinationResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateTableCombinationResponse) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetTableCombinationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId  uint64                 `protobuf:"varint,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTableCombinationsRequest) Reset() {
+	*x = GetTableCombinationsRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTableCombinationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTableCombinationsRequest) ProtoMessage() {}
+
+func (x *GetTableCombinationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTableCombinationsRequest.ProtoReflect.Descriptor instead.
+func (*GetTableCombinationsRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetTableCombinationsRequest) GetRestaurantId() uint64 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+type GetTableCombinationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Combinations  []*TableCombination    `protobuf:"bytes,1,rep,name=combinations,proto3" json:"combinations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTableCombinationsResponse) Reset() {
+	*x = GetTableCombinationsResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTableCombinationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTableCombinationsResponse) ProtoMessage() {}
+
+func (x *GetTableCombinationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTableCombinationsResponse.ProtoReflect.Descriptor instead.
+func (*GetTableCombinationsResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetTableCombinationsResponse) GetCombinations() []*TableCombination {
+	if x != nil {
+		return x.Combinations
+	}
+	return nil
+}
+
+type DeleteTableCombinationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RestaurantId  uint64                 `protobuf:"varint,1,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	Id            uint64                 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTableCombinationRequest) Reset() {
+	*x = DeleteTableCombinationRequest{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTableCombinationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTableCombinationRequest) ProtoMessage() {}
+
+func (x *DeleteTableCombinationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTableCombinationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTableCombinationRequest) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteTableCombinationRequest) GetRestaurantId() uint64 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *DeleteTableCombinationRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteTableCombinationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTableCombinationResponse) Reset() {
+	*x = DeleteTableCombinationResponse{}
+	mi := &file_booking_v1_restaurant_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTableCombinationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTableCombinationResponse) ProtoMessage() {}
+
+func (x *DeleteTableCombinationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_booking_v1_restaurant_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTableCombinationResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTableCombinationResponse) Descriptor() ([]byte, []int) {
+	return file_booking_v1_restaurant_proto_rawDescGZIP(), []int{28}
+}
+
+var File_booking_v1_restaurant_proto protoreflect.FileDescriptor
+
+const file_booking_v1_restaurant_proto_rawDesc = "" +
+	"\n" +
+	"\x1bbooking/v1/restaurant.proto\x12\n" +
+	"booking.v1\x1a\x17booking/v1/common.proto\"\xaf\x02\n" +
+	"\x17CreateRestaurantRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12=\n" +
+	"\ropening_hours\x18\x04 \x03(\v2\x18.booking.v1.OpeningHoursR\fopeningHours\x12)\n" +
+	"\x10table_assignment\x18\x05 \x01(\tR\x0ftableAssignment\x12=\n" +
+	"\rbooking_rules\x18\x06 \x01(\v2\x18.booking.v1.BookingRulesR\fbookingRules\x12\x1b\n" +
+	"\ttime_zone\x18\a \x01(\tR\btimeZone\"*\n" +
+	"\x18CreateRestaurantResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\x17\n" +
+	"\x15GetRestaurantsRequest\"R\n" +
+	"\x16GetRestaurantsResponse\x128\n" +
+	"\vrestaurants\x18\x01 \x03(\v2\x16.booking.v1.RestaurantR\vrestaurants\"&\n" +
+	"\x14GetRestaurantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"O\n" +
+	"\x15GetRestaurantResponse\x126\n" +
+	"\n" +
+	"restaurant\x18\x01 \x01(\v2\x16.booking.v1.RestaurantR\n" +
+	"restaurant\"\x80\x02\n" +
+	"\x17UpdateRestaurantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12=\n" +
+	"\ropening_hours\x18\x05 \x03(\v2\x18.booking.v1.OpeningHoursR\fopeningHours\x12)\n" +
+	"\x10table_assignment\x18\x06 \x01(\tR\x0ftableAssignment\x12\x1b\n" +
+	"\ttime_zone\x18\a \x01(\tR\btimeZone\"\x1a\n" +
+	"\x18UpdateRestaurantResponse\")\n" +
+	"\x17DeleteRestaurantRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\x1a\n" +
+	"\x18DeleteRestaurantResponse\"\x7f\n" +
+	"\x19UpdateBookingRulesRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\x04R\frestaurantId\x12=\n" +
+	"\rbooking_rules\x18\x02 \x01(\v2\x18.booking.v1.BookingRulesR\fbookingRules\"\x1c\n" +
+	"\x1aUpdateBookingRulesResponse\"\xb3\x01\n" +
+	"\x12CreateTableRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\x04R\frestaurantId\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\x04R\x06number\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x04R\bcapacity\x12$\n" +
+	"\x0emin_party_size\x18\x04 \x01(\x04R\fminPartySize\x12\x1e\n" +
+	"\n" +
+	"attributes\x18\x05 \x03(\tR\n" +
+	"attributes\"%\n" +
+	"\x13CreateTableResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"^\n" +
+	"\x10GetTablesRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\x04R\frestaurantId\x12%\n" +
+	"\x0eonly_available\x18\x02 \x01(\bR\ronlyAvailable\">\n" +
+	"\x11GetTablesResponse\x12)\n" +
+	"\x06tables\x18\x01 \x03(\v2\x11.booking.v1.TableR\x06tables\"!\n" +
+	"\x0fGetTableRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\";\n" +
+	"\x10GetTableResponse\x12'\n" +
+	"\x05table\x18\x01 \x01(\v2\x11.booking.v1.TableR\x05table\"\xf4\x01\n" +
+	"\x12UpdateTableRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\x04R\x06number\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x04R\bcapacity\x12&\n" +
+	"\fis_available\x18\x04 \x01(\bH\x00R\visAvailable\x88\x01\x01\x12$\n" +
+	"\x0emin_party_size\x18\x05 \x01(\x04R\fminPartySize\x12;\n" +
+	"\n" +
+	"attributes\x18\x06 \x01(\v2\x1b.booking.v1.TableAttributesR\n" +
+	"attributesB\x0f\n" +
+	"\r_is_available\")\n" +
+	"\x0fTableAttributes\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\x15\n" +
+	"\x13UpdateTableResponse\"$\n" +
+	"\x12DeleteTableRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\x15\n" +
+	"\x13DeleteTableResponse\"}\n" +
+	"\x1dCreateTableCombinationRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\x04R\frestaurantId\x12\x1b\n" +
+	"\ttable_ids\x18\x02 \x03(\x04R\btableIds\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x04R\bcapacity\"0\n" +
+	"\x1eCreateTableCombinationResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"B\n" +
+	"\x1bGetTableCombinationsRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\x04R\frestaurantId\"`\n" +
+	"\x1cGetTableCombinationsResponse\x12@\n" +
+	"\fcombinations\x18\x01 \x03(\v2\x1c.booking.v1.TableCombinationR\fcombinations\"T\n" +
+	"\x1dDeleteTableCombinationRequest\x12#\n" +
+	"\rrestaurant_id\x18\x01 \x01(\x04R\frestaurantId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x04R\x02id\" \n" +
+	"\x1eDeleteTableCombinationResponse2\x92\n" +
+	"\n" +
+	"\x11RestaurantService\x12]\n" +
+	"\x10CreateRestaurant\x12#.booking.v1.CreateRestaurantRequest\x1a$.booking.v1.CreateRestaurantResponse\x12W\n" +
+	"\x0eGetRestaurants\x12!.booking.v1.GetRestaurantsRequest\x1a\".booking.v1.GetRestaurantsResponse\x12T\n" +
+	"\rGetRestaurant\x12 .booking.v1.GetRestaurantRequest\x1a!.booking.v1.GetRestaurantResponse\x12]\n" +
+	"\x10UpdateRestaurant\x12#.booking.v1.UpdateRestaurantRequest\x1a$.booking.v1.UpdateRestaurantResponse\x12]\n" +
+	"\x10DeleteRestaurant\x12#.booking.v1.DeleteRestaurantRequest\x1a$.booking.v1.DeleteRestaurantResponse\x12c\n" +
+	"\x12UpdateBookingRules\x12%.booking.v1.UpdateBookingRulesRequest\x1a&.booking.v1.UpdateBookingRulesResponse\x12N\n" +
+	"\vCreateTable\x12\x1e.booking.v1.CreateTableRequest\x1a\x1f.booking.v1.CreateTableResponse\x12H\n" +
+	"\tGetTables\x12\x1c.booking.v1.GetTablesRequest\x1a\x1d.booking.v1.GetTablesResponse\x12E\n" +
+	"\bGetTable\x12\x1b.booking.v1.GetTableRequest\x1a\x1c.booking.v1.GetTableResponse\x12N\n" +
+	"\vUpdateTable\x12\x1e.booking.v1.UpdateTableRequest\x1a\x1f.booking.v1.UpdateTableResponse\x12N\n" +
+	"\vDeleteTable\x12\x1e.booking.v1.DeleteTableRequest\x1a\x1f.booking.v1.DeleteTableResponse\x12o\n" +
+	"\x16CreateTableCombination\x12).booking.v1.CreateTableCombinationRequest\x1a*.booking.v1.CreateTableCombinationResponse\x12i\n" +
+	"\x14GetTableCombinations\x12'.booking.v1.GetTableCombinationsRequest\x1a(.booking.v1.GetTableCombinationsResponse\x12o\n" +
+	"\x16DeleteTableCombination\x12).booking.v1.DeleteTableCombinationRequest\x1a*.booking.v1.DeleteTableCombinationResponseBCZAgithub.com/kourai55k/booking-service/pkg/api/booking/v1;bookingv1b\x06proto3"
+
+var (
+	file_booking_v1_restaurant_proto_rawDescOnce sync.Once
+	file_booking_v1_restaurant_proto_rawDescData []byte
+)
+
+func file_booking_v1_restaurant_proto_rawDescGZIP() []byte {
+	file_booking_v1_restaurant_proto_rawDescOnce.Do(func() {
+		file_booking_v1_restaurant_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_booking_v1_restaurant_proto_rawDesc), len(file_booking_v1_restaurant_proto_rawDesc)))
+	})
+	return file_booking_v1_restaurant_proto_rawDescData
+}
+
+var file_booking_v1_restaurant_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_booking_v1_restaurant_proto_goTypes = []any{
+	(*CreateRestaurantRequest)(nil),        // 0: booking.v1.CreateRestaurantRequest
+	(*CreateRestaurantResponse)(nil),       // 1: booking.v1.CreateRestaurantResponse
+	(*GetRestaurantsRequest)(nil),          // 2: booking.v1.GetRestaurantsRequest
+	(*GetRestaurantsResponse)(nil),         // 3: booking.v1.GetRestaurantsResponse
+	(*GetRestaurantRequest)(nil),           // 4: booking.v1.GetRestaurantRequest
+	(*GetRestaurantResponse)(nil),          // 5: booking.v1.GetRestaurantResponse
+	(*UpdateRestaurantRequest)(nil),        // 6: booking.v1.UpdateRestaurantRequest
+	(*UpdateRestaurantResponse)(nil),       // 7: booking.v1.UpdateRestaurantResponse
+	(*DeleteRestaurantRequest)(nil),        // 8: booking.v1.DeleteRestaurantRequest
+	(*DeleteRestaurantResponse)(nil),       // 9: booking.v1.DeleteRestaurantResponse
+	(*UpdateBookingRulesRequest)(nil),      // 10: booking.v1.UpdateBookingRulesRequest
+	(*UpdateBookingRulesResponse)(nil),     // 11: booking.v1.UpdateBookingRulesResponse
+	(*CreateTableRequest)(nil),             // 12: booking.v1.CreateTableRequest
+	(*CreateTableResponse)(nil),            // 13: booking.v1.CreateTableResponse
+	(*GetTablesRequest)(nil),               // 14: booking.v1.GetTablesRequest
+	(*GetTablesResponse)(nil),              // 15: booking.v1.GetTablesResponse
+	(*GetTableRequest)(nil),                // 16: booking.v1.GetTableRequest
+	(*GetTableResponse)(nil),               // 17: booking.v1.GetTableResponse
+	(*UpdateTableRequest)(nil),             // 18: booking.v1.UpdateTableRequest
+	(*TableAttributes)(nil),                // 19: booking.v1.TableAttributes
+	(*UpdateTableResponse)(nil),            // 20: booking.v1.UpdateTableResponse
+	(*DeleteTableRequest)(nil),             // 21: booking.v1.DeleteTableRequest
+	(*DeleteTableResponse)(nil),            // 22: booking.v1.DeleteTableResponse
+	(*CreateTableCombinationRequest)(nil),  // 23: booking.v1.CreateTableCombinationRequest
+	(*CreateTableCombinationResponse)(nil), // 24: booking.v1.CreateTableCombinationResponse
+	(*GetTableCombinationsRequest)(nil),    // 25: booking.v1.GetTableCombinationsRequest
+	(*GetTableCombinationsResponse)(nil),   // 26: booking.v1.GetTableCombinationsResponse
+	(*DeleteTableCombinationRequest)(nil),  // 27: booking.v1.DeleteTableCombinationRequest
+	(*DeleteTableCombinationResponse)(nil), // 28: booking.v1.DeleteTableCombinationResponse
+	(*OpeningHours)(nil),                   // 29: booking.v1.OpeningHours
+	(*BookingRules)(nil),                   // 30: booking.v1.BookingRules
+	(*Restaurant)(nil),                     // 31: booking.v1.Restaurant
+	(*Table)(nil),                          // 32: booking.v1.Table
+	(*TableCombination)(nil),               // 33: booking.v1.TableCombination
+}
+var file_booking_v1_restaurant_proto_depIdxs = []int32{
+	29, // 0: booking.v1.CreateRestaurantRequest.opening_hours:type_name -> booking.v1.OpeningHours
+	30, // 1: booking.v1.CreateRestaurantRequest.booking_rules:type_name -> booking.v1.BookingRules
+	31, // 2: booking.v1.GetRestaurantsResponse.restaurants:type_name -> booking.v1.Restaurant
+	31, // 3: booking.v1.GetRestaurantResponse.restaurant:type_name -> booking.v1.Restaurant
+	29, // 4: booking.v1.UpdateRestaurantRequest.opening_hours:type_name -> booking.v1.OpeningHours
+	30, // 5: booking.v1.UpdateBookingRulesRequest.booking_rules:type_name -> booking.v1.BookingRules
+	32, // 6: booking.v1.GetTablesResponse.tables:type_name -> booking.v1.Table
+	32, // 7: booking.v1.GetTableResponse.table:type_name -> booking.v1.Table
+	19, // 8: booking.v1.UpdateTableRequest.attributes:type_name -> booking.v1.TableAttributes
+	33, // 9: booking.v1.GetTableCombinationsResponse.combinations:type_name -> booking.v1.TableCombination
+	0,  // 10: booking.v1.RestaurantService.CreateRestaurant:input_type -> booking.v1.CreateRestaurantRequest
+	2,  // 11: booking.v1.RestaurantService.GetRestaurants:input_type -> booking.v1.GetRestaurantsRequest
+	4,  // 12: booking.v1.RestaurantService.GetRestaurant:input_type -> booking.v1.GetRestaurantRequest
+	6,  // 13: booking.v1.RestaurantService.UpdateRestaurant:input_type -> booking.v1.UpdateRestaurantRequest
+	8,  // 14: booking.v1.RestaurantService.DeleteRestaurant:input_type -> booking.v1.DeleteRestaurantRequest
+	10, // 15: booking.v1.RestaurantService.UpdateBookingRules:input_type -> booking.v1.UpdateBookingRulesRequest
+	12, // 16: booking.v1.RestaurantService.CreateTable:input_type -> booking.v1.CreateTableRequest
+	14, // 17: booking.v1.RestaurantService.GetTables:input_type -> booking.v1.GetTablesRequest
+	16, // 18: booking.v1.RestaurantService.GetTable:input_type -> booking.v1.GetTableRequest
+	18, // 19: booking.v1.RestaurantService.UpdateTable:input_type -> booking.v1.UpdateTableRequest
+	21, // 20: booking.v1.RestaurantService.DeleteTable:input_type -> booking.v1.DeleteTableRequest
+	23, // 21: booking.v1.RestaurantService.CreateTableCombination:input_type -> booking.v1.CreateTableCombinationRequest
+	25, // 22: booking.v1.RestaurantService.GetTableCombinations:input_type -> booking.v1.GetTableCombinationsRequest
+	27, // 23: booking.v1.RestaurantService.DeleteTableCombination:input_type -> booking.v1.DeleteTableCombinationRequest
+	1,  // 24: booking.v1.RestaurantService.CreateRestaurant:output_type -> booking.v1.CreateRestaurantResponse
+	3,  // 25: booking.v1.RestaurantService.GetRestaurants:output_type -> booking.v1.GetRestaurantsResponse
+	5,  // 26: booking.v1.RestaurantService.GetRestaurant:output_type -> booking.v1.GetRestaurantResponse
+	7,  // 27: booking.v1.RestaurantService.UpdateRestaurant:output_type -> booking.v1.UpdateRestaurantResponse
+	9,  // 28: booking.v1.RestaurantService.DeleteRestaurant:output_type -> booking.v1.DeleteRestaurantResponse
+	11, // 29: booking.v1.RestaurantService.UpdateBookingRules:output_type -> booking.v1.UpdateBookingRulesResponse
+	13, // 30: booking.v1.RestaurantService.CreateTable:output_type -> booking.v1.CreateTableResponse
+	15, // 31: booking.v1.RestaurantService.GetTables:output_type -> booking.v1.GetTablesResponse
+	17, // 32: booking.v1.RestaurantService.GetTable:output_type -> booking.v1.GetTableResponse
+	20, // 33: booking.v1.RestaurantService.UpdateTable:output_type -> booking.v1.UpdateTableResponse
+	22, // 34: booking.v1.RestaurantService.DeleteTable:output_type -> booking.v1.DeleteTableResponse
+	24, // 35: booking.v1.RestaurantService.CreateTableCombination:output_type -> booking.v1.CreateTableCombinationResponse
+	26, // 36: booking.v1.RestaurantService.GetTableCombinations:output_type -> booking.v1.GetTableCombinationsResponse
+	28, // 37: booking.v1.RestaurantService.DeleteTableCombination:output_type -> booking.v1.DeleteTableCombinationResponse
+	24, // [24:38] is the sub-list for method output_type
+	10, // [10:24] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_booking_v1_restaurant_proto_init() }
+func file_booking_v1_restaurant_proto_init() {
+	if File_booking_v1_restaurant_proto != nil {
+		return
+	}
+	file_booking_v1_common_proto_init()
+	file_booking_v1_restaurant_proto_msgTypes[18].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_booking_v1_restaurant_proto_rawDesc), len(file_booking_v1_restaurant_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   29,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_booking_v1_restaurant_proto_goTypes,
+		DependencyIndexes: file_booking_v1_restaurant_proto_depIdxs,
+		MessageInfos:      file_booking_v1_restaurant_proto_msgTypes,
+	}.Build()
+	File_booking_v1_restaurant_proto = out.File
+	file_booking_v1_restaurant_proto_goTypes = nil
+	file_booking_v1_restaurant_proto_depIdxs = nil
+}
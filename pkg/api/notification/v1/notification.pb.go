@@ -0,0 +1,663 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: notification/v1/notification.proto
+
+package notificationv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReservationEventType int32
+
+const (
+	ReservationEventType_RESERVATION_EVENT_TYPE_UNSPECIFIED ReservationEventType = 0
+	ReservationEventType_RESERVATION_EVENT_TYPE_CREATED     ReservationEventType = 1
+	ReservationEventType_RESERVATION_EVENT_TYPE_CHANGED     ReservationEventType = 2
+	ReservationEventType_RESERVATION_EVENT_TYPE_CANCELLED   ReservationEventType = 3
+)
+
+// Enum value maps for ReservationEventType.
+var (
+	ReservationEventType_name = map[int32]string{
+		0: "RESERVATION_EVENT_TYPE_UNSPECIFIED",
+		1: "RESERVATION_EVENT_TYPE_CREATED",
+		2: "RESERVATION_EVENT_TYPE_CHANGED",
+		3: "RESERVATION_EVENT_TYPE_CANCELLED",
+	}
+	ReservationEventType_value = map[string]int32{
+		"RESERVATION_EVENT_TYPE_UNSPECIFIED": 0,
+		"RESERVATION_EVENT_TYPE_CREATED":     1,
+		"RESERVATION_EVENT_TYPE_CHANGED":     2,
+		"RESERVATION_EVENT_TYPE_CANCELLED":   3,
+	}
+)
+
+func (x ReservationEventType) Enum() *ReservationEventType {
+	p := new(ReservationEventType)
+	*p = x
+	return p
+}
+
+func (x ReservationEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ReservationEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_notification_v1_notification_proto_enumTypes[0].Descriptor()
+}
+
+func (ReservationEventType) Type() protoreflect.EnumType {
+	return &file_notification_v1_notification_proto_enumTypes[0]
+}
+
+func (x ReservationEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ReservationEventType.Descriptor instead.
+func (ReservationEventType) EnumDescriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{0}
+}
+
+type Reservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	RestaurantId  uint64                 `protobuf:"varint,2,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	TableId       uint64                 `protobuf:"varint,3,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	UserId        uint64                 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PartySize     uint64                 `protobuf:"varint,5,opt,name=party_size,json=partySize,proto3" json:"party_size,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_notification_v1_notification_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Reservation) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Reservation) GetRestaurantId() uint64 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *Reservation) GetTableId() uint64 {
+	if x != nil {
+		return x.TableId
+	}
+	return 0
+}
+
+func (x *Reservation) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Reservation) GetPartySize() uint64 {
+	if x != nil {
+		return x.PartySize
+	}
+	return 0
+}
+
+func (x *Reservation) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *Reservation) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type NotifyOwnerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerId       uint64                 `protobuf:"varint,1,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Type          ReservationEventType   `protobuf:"varint,2,opt,name=type,proto3,enum=notification.v1.ReservationEventType" json:"type,omitempty"`
+	Reservation   *Reservation           `protobuf:"bytes,3,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyOwnerRequest) Reset() {
+	*x = NotifyOwnerRequest{}
+	mi := &file_notification_v1_notification_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyOwnerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyOwnerRequest) ProtoMessage() {}
+
+func (x *NotifyOwnerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyOwnerRequest.ProtoReflect.Descriptor instead.
+func (*NotifyOwnerRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NotifyOwnerRequest) GetOwnerId() uint64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *NotifyOwnerRequest) GetType() ReservationEventType {
+	if x != nil {
+		return x.Type
+	}
+	return ReservationEventType_RESERVATION_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *NotifyOwnerRequest) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+func (x *NotifyOwnerRequest) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type NotifyOwnerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyOwnerResponse) Reset() {
+	*x = NotifyOwnerResponse{}
+	mi := &file_notification_v1_notification_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyOwnerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyOwnerResponse) ProtoMessage() {}
+
+func (x *NotifyOwnerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyOwnerResponse.ProtoReflect.Descriptor instead.
+func (*NotifyOwnerResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{2}
+}
+
+// NotifyGuestRequest offers a freed table to a waitlisted guest.
+type NotifyGuestRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WaitlistEntryId uint64                 `protobuf:"varint,1,opt,name=waitlist_entry_id,json=waitlistEntryId,proto3" json:"waitlist_entry_id,omitempty"`
+	UserId          uint64                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Contact is where the guest asked to be notified, like a phone number or an e-mail address.
+	Contact string `protobuf:"bytes,3,opt,name=contact,proto3" json:"contact,omitempty"`
+	// Offered is the seating offered, without an id.
+	Offered *Reservation `protobuf:"bytes,4,opt,name=offered,proto3" json:"offered,omitempty"`
+	// ClaimToken claims the table with POST /holds/{claim_token}/reservation until expires_at.
+	ClaimToken    string                 `protobuf:"bytes,5,opt,name=claim_token,json=claimToken,proto3" json:"claim_token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyGuestRequest) Reset() {
+	*x = NotifyGuestRequest{}
+	mi := &file_notification_v1_notification_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyGuestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyGuestRequest) ProtoMessage() {}
+
+func (x *NotifyGuestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyGuestRequest.ProtoReflect.Descriptor instead.
+func (*NotifyGuestRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NotifyGuestRequest) GetWaitlistEntryId() uint64 {
+	if x != nil {
+		return x.WaitlistEntryId
+	}
+	return 0
+}
+
+func (x *NotifyGuestRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *NotifyGuestRequest) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+func (x *NotifyGuestRequest) GetOffered() *Reservation {
+	if x != nil {
+		return x.Offered
+	}
+	return nil
+}
+
+func (x *NotifyGuestRequest) GetClaimToken() string {
+	if x != nil {
+		return x.ClaimToken
+	}
+	return ""
+}
+
+func (x *NotifyGuestRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *NotifyGuestRequest) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type NotifyGuestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyGuestResponse) Reset() {
+	*x = NotifyGuestResponse{}
+	mi := &file_notification_v1_notification_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyGuestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyGuestResponse) ProtoMessage() {}
+
+func (x *NotifyGuestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyGuestResponse.ProtoReflect.Descriptor instead.
+func (*NotifyGuestResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{4}
+}
+
+// NotifyWalkInRequest tells a walk-in in the queue at the host stand that their table is ready.
+type NotifyWalkInRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	WalkInId     uint64                 `protobuf:"varint,1,opt,name=walk_in_id,json=walkInId,proto3" json:"walk_in_id,omitempty"`
+	RestaurantId uint64                 `protobuf:"varint,2,opt,name=restaurant_id,json=restaurantId,proto3" json:"restaurant_id,omitempty"`
+	// Name is the name the party gave at the host stand.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Contact is where the party asked to be notified, like a phone number.
+	Contact       string                 `protobuf:"bytes,4,opt,name=contact,proto3" json:"contact,omitempty"`
+	PartySize     uint64                 `protobuf:"varint,5,opt,name=party_size,json=partySize,proto3" json:"party_size,omitempty"`
+	TableId       uint64                 `protobuf:"varint,6,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyWalkInRequest) Reset() {
+	*x = NotifyWalkInRequest{}
+	mi := &file_notification_v1_notification_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyWalkInRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyWalkInRequest) ProtoMessage() {}
+
+func (x *NotifyWalkInRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyWalkInRequest.ProtoReflect.Descriptor instead.
+func (*NotifyWalkInRequest) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NotifyWalkInRequest) GetWalkInId() uint64 {
+	if x != nil {
+		return x.WalkInId
+	}
+	return 0
+}
+
+func (x *NotifyWalkInRequest) GetRestaurantId() uint64 {
+	if x != nil {
+		return x.RestaurantId
+	}
+	return 0
+}
+
+func (x *NotifyWalkInRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NotifyWalkInRequest) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+func (x *NotifyWalkInRequest) GetPartySize() uint64 {
+	if x != nil {
+		return x.PartySize
+	}
+	return 0
+}
+
+func (x *NotifyWalkInRequest) GetTableId() uint64 {
+	if x != nil {
+		return x.TableId
+	}
+	return 0
+}
+
+func (x *NotifyWalkInRequest) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type NotifyWalkInResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyWalkInResponse) Reset() {
+	*x = NotifyWalkInResponse{}
+	mi := &file_notification_v1_notification_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyWalkInResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyWalkInResponse) ProtoMessage() {}
+
+func (x *NotifyWalkInResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_v1_notification_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyWalkInResponse.ProtoReflect.Descriptor instead.
+func (*NotifyWalkInResponse) Descriptor() ([]byte, []int) {
+	return file_notification_v1_notification_proto_rawDescGZIP(), []int{6}
+}
+
+var File_notification_v1_notification_proto protoreflect.FileDescriptor
+
+const file_notification_v1_notification_proto_rawDesc = "" +
+	"\n" +
+	"\"notification/v1/notification.proto\x12\x0fnotification.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x87\x02\n" +
+	"\vReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12#\n" +
+	"\rrestaurant_id\x18\x02 \x01(\x04R\frestaurantId\x12\x19\n" +
+	"\btable_id\x18\x03 \x01(\x04R\atableId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x04R\x06userId\x12\x1d\n" +
+	"\n" +
+	"party_size\x18\x05 \x01(\x04R\tpartySize\x129\n" +
+	"\n" +
+	"start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"\xe7\x01\n" +
+	"\x12NotifyOwnerRequest\x12\x19\n" +
+	"\bowner_id\x18\x01 \x01(\x04R\aownerId\x129\n" +
+	"\x04type\x18\x02 \x01(\x0e2%.notification.v1.ReservationEventTypeR\x04type\x12>\n" +
+	"\vreservation\x18\x03 \x01(\v2\x1c.notification.v1.ReservationR\vreservation\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\x15\n" +
+	"\x13NotifyOwnerResponse\"\xc4\x02\n" +
+	"\x12NotifyGuestRequest\x12*\n" +
+	"\x11waitlist_entry_id\x18\x01 \x01(\x04R\x0fwaitlistEntryId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x04R\x06userId\x12\x18\n" +
+	"\acontact\x18\x03 \x01(\tR\acontact\x126\n" +
+	"\aoffered\x18\x04 \x01(\v2\x1c.notification.v1.ReservationR\aoffered\x12\x1f\n" +
+	"\vclaim_token\x18\x05 \x01(\tR\n" +
+	"claimToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12;\n" +
+	"\voccurred_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\x15\n" +
+	"\x13NotifyGuestResponse\"\xfd\x01\n" +
+	"\x13NotifyWalkInRequest\x12\x1c\n" +
+	"\n" +
+	"walk_in_id\x18\x01 \x01(\x04R\bwalkInId\x12#\n" +
+	"\rrestaurant_id\x18\x02 \x01(\x04R\frestaurantId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\acontact\x18\x04 \x01(\tR\acontact\x12\x1d\n" +
+	"\n" +
+	"party_size\x18\x05 \x01(\x04R\tpartySize\x12\x19\n" +
+	"\btable_id\x18\x06 \x01(\x04R\atableId\x12;\n" +
+	"\voccurred_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\x16\n" +
+	"\x14NotifyWalkInResponse*\xac\x01\n" +
+	"\x14ReservationEventType\x12&\n" +
+	"\"RESERVATION_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eRESERVATION_EVENT_TYPE_CREATED\x10\x01\x12\"\n" +
+	"\x1eRESERVATION_EVENT_TYPE_CHANGED\x10\x02\x12$\n" +
+	" RESERVATION_EVENT_TYPE_CANCELLED\x10\x032\xa6\x02\n" +
+	"\x13NotificationService\x12X\n" +
+	"\vNotifyOwner\x12#.notification.v1.NotifyOwnerRequest\x1a$.notification.v1.NotifyOwnerResponse\x12X\n" +
+	"\vNotifyGuest\x12#.notification.v1.NotifyGuestRequest\x1a$.notification.v1.NotifyGuestResponse\x12[\n" +
+	"\fNotifyWalkIn\x12$.notification.v1.NotifyWalkInRequest\x1a%.notification.v1.NotifyWalkInResponseBMZKgithub.com/kourai55k/booking-service/pkg/api/notification/v1;notificationv1b\x06proto3"
+
+var (
+	file_notification_v1_notification_proto_rawDescOnce sync.Once
+	file_notification_v1_notification_proto_rawDescData []byte
+)
+
+func file_notification_v1_notification_proto_rawDescGZIP() []byte {
+	file_notification_v1_notification_proto_rawDescOnce.Do(func() {
+		file_notification_v1_notification_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_notification_v1_notification_proto_rawDesc), len(file_notification_v1_notification_proto_rawDesc)))
+	})
+	return file_notification_v1_notification_proto_rawDescData
+}
+
+var file_notification_v1_notification_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_notification_v1_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_notification_v1_notification_proto_goTypes = []any{
+	(ReservationEventType)(0),     // 0: notification.v1.ReservationEventType
+	(*Reservation)(nil),           // 1: notification.v1.Reservation
+	(*NotifyOwnerRequest)(nil),    // 2: notification.v1.NotifyOwnerRequest
+	(*NotifyOwnerResponse)(nil),   // 3: notification.v1.NotifyOwnerResponse
+	(*NotifyGuestRequest)(nil),    // 4: notification.v1.NotifyGuestRequest
+	(*NotifyGuestResponse)(nil),   // 5: notification.v1.NotifyGuestResponse
+	(*NotifyWalkInRequest)(nil),   // 6: notification.v1.NotifyWalkInRequest
+	(*NotifyWalkInResponse)(nil),  // 7: notification.v1.NotifyWalkInResponse
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+}
+var file_notification_v1_notification_proto_depIdxs = []int32{
+	8,  // 0: notification.v1.Reservation.start_time:type_name -> google.protobuf.Timestamp
+	8,  // 1: notification.v1.Reservation.end_time:type_name -> google.protobuf.Timestamp
+	0,  // 2: notification.v1.NotifyOwnerRequest.type:type_name -> notification.v1.ReservationEventType
+	1,  // 3: notification.v1.NotifyOwnerRequest.reservation:type_name -> notification.v1.Reservation
+	8,  // 4: notification.v1.NotifyOwnerRequest.occurred_at:type_name -> google.protobuf.Timestamp
+	1,  // 5: notification.v1.NotifyGuestRequest.offered:type_name -> notification.v1.Reservation
+	8,  // 6: notification.v1.NotifyGuestRequest.expires_at:type_name -> google.protobuf.Timestamp
+	8,  // 7: notification.v1.NotifyGuestRequest.occurred_at:type_name -> google.protobuf.Timestamp
+	8,  // 8: notification.v1.NotifyWalkInRequest.occurred_at:type_name -> google.protobuf.Timestamp
+	2,  // 9: notification.v1.NotificationService.NotifyOwner:input_type -> notification.v1.NotifyOwnerRequest
+	4,  // 10: notification.v1.NotificationService.NotifyGuest:input_type -> notification.v1.NotifyGuestRequest
+	6,  // 11: notification.v1.NotificationService.NotifyWalkIn:input_type -> notification.v1.NotifyWalkInRequest
+	3,  // 12: notification.v1.NotificationService.NotifyOwner:output_type -> notification.v1.NotifyOwnerResponse
+	5,  // 13: notification.v1.NotificationService.NotifyGuest:output_type -> notification.v1.NotifyGuestResponse
+	7,  // 14: notification.v1.NotificationService.NotifyWalkIn:output_type -> notification.v1.NotifyWalkInResponse
+	12, // [12:15] is the sub-list for method output_type
+	9,  // [9:12] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_notification_v1_notification_proto_init() }
+func file_notification_v1_notification_proto_init() {
+	if File_notification_v1_notification_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notification_v1_notification_proto_rawDesc), len(file_notification_v1_notification_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_notification_v1_notification_proto_goTypes,
+		DependencyIndexes: file_notification_v1_notification_proto_depIdxs,
+		EnumInfos:         file_notification_v1_notification_proto_enumTypes,
+		MessageInfos:      file_notification_v1_notification_proto_msgTypes,
+	}.Build()
+	File_notification_v1_notification_proto = out.File
+	file_notification_v1_notification_proto_goTypes = nil
+	file_notification_v1_notification_proto_depIdxs = nil
+}
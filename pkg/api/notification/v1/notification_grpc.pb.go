@@ -0,0 +1,205 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: notification/v1/notification.proto
+
+package notificationv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	NotificationService_NotifyOwner_FullMethodName  = "/notification.v1.NotificationService/NotifyOwner"
+	NotificationService_NotifyGuest_FullMethodName  = "/notification.v1.NotificationService/NotifyGuest"
+	NotificationService_NotifyWalkIn_FullMethodName = "/notification.v1.NotificationService/NotifyWalkIn"
+)
+
+// NotificationServiceClient is the client API for NotificationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// NotificationService is the external service that notifies restaurant owners
+// (e.g. with a Telegram bot) about their reservations, waitlisted guests about tables offered to them,
+// and walk-ins that their table is ready.
+type NotificationServiceClient interface {
+	NotifyOwner(ctx context.Context, in *NotifyOwnerRequest, opts ...grpc.CallOption) (*NotifyOwnerResponse, error)
+	NotifyGuest(ctx context.Context, in *NotifyGuestRequest, opts ...grpc.CallOption) (*NotifyGuestResponse, error)
+	NotifyWalkIn(ctx context.Context, in *NotifyWalkInRequest, opts ...grpc.CallOption) (*NotifyWalkInResponse, error)
+}
+
+type notificationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNotificationServiceClient(cc grpc.ClientConnInterface) NotificationServiceClient {
+	return &notificationServiceClient{cc}
+}
+
+func (c *notificationServiceClient) NotifyOwner(ctx context.Context, in *NotifyOwnerRequest, opts ...grpc.CallOption) (*NotifyOwnerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotifyOwnerResponse)
+	err := c.cc.Invoke(ctx, NotificationService_NotifyOwner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) NotifyGuest(ctx context.Context, in *NotifyGuestRequest, opts ...grpc.CallOption) (*NotifyGuestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotifyGuestResponse)
+	err := c.cc.Invoke(ctx, NotificationService_NotifyGuest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationServiceClient) NotifyWalkIn(ctx context.Context, in *NotifyWalkInRequest, opts ...grpc.CallOption) (*NotifyWalkInResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotifyWalkInResponse)
+	err := c.cc.Invoke(ctx, NotificationService_NotifyWalkIn_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NotificationServiceServer is the server API for NotificationService service.
+// All implementations must embed UnimplementedNotificationServiceServer
+// for forward compatibility.
+//
+// NotificationService is the external service that notifies restaurant owners
+// (e.g. with a Telegram bot) about their reservations, waitlisted guests about tables offered to them,
+// and walk-ins that their table is ready.
+type NotificationServiceServer interface {
+	NotifyOwner(context.Context, *NotifyOwnerRequest) (*NotifyOwnerResponse, error)
+	NotifyGuest(context.Context, *NotifyGuestRequest) (*NotifyGuestResponse, error)
+	NotifyWalkIn(context.Context, *NotifyWalkInRequest) (*NotifyWalkInResponse, error)
+	mustEmbedUnimplementedNotificationServiceServer()
+}
+
+// UnimplementedNotificationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedNotificationServiceServer struct{}
+
+func (UnimplementedNotificationServiceServer) NotifyOwner(context.Context, *NotifyOwnerRequest) (*NotifyOwnerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NotifyOwner not implemented")
+}
+func (UnimplementedNotificationServiceServer) NotifyGuest(context.Context, *NotifyGuestRequest) (*NotifyGuestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NotifyGuest not implemented")
+}
+func (UnimplementedNotificationServiceServer) NotifyWalkIn(context.Context, *NotifyWalkInRequest) (*NotifyWalkInResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NotifyWalkIn not implemented")
+}
+func (UnimplementedNotificationServiceServer) mustEmbedUnimplementedNotificationServiceServer() {}
+func (UnimplementedNotificationServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeNotificationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NotificationServiceServer will
+// result in compilation errors.
+type UnsafeNotificationServiceServer interface {
+	mustEmbedUnimplementedNotificationServiceServer()
+}
+
+func RegisterNotificationServiceServer(s grpc.ServiceRegistrar, srv NotificationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedNotificationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&NotificationService_ServiceDesc, srv)
+}
+
+func _NotificationService_NotifyOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotifyOwnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).NotifyOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_NotifyOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).NotifyOwner(ctx, req.(*NotifyOwnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_NotifyGuest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotifyGuestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).NotifyGuest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_NotifyGuest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).NotifyGuest(ctx, req.(*NotifyGuestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NotificationService_NotifyWalkIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotifyWalkInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).NotifyWalkIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_NotifyWalkIn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).NotifyWalkIn(ctx, req.(*NotifyWalkInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NotificationService_ServiceDesc is the grpc.ServiceDesc for NotificationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NotificationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "notification.v1.NotificationService",
+	HandlerType: (*NotificationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "NotifyOwner",
+			Handler:    _NotificationService_NotifyOwner_Handler,
+		},
+		{
+			MethodName: "NotifyGuest",
+			Handler:    _NotificationService_NotifyGuest_Handler,
+		},
+		{
+			MethodName: "NotifyWalkIn",
+			Handler:    _NotificationService_NotifyWalkIn_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "notification/v1/notification.proto",
+}
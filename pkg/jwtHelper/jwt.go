@@ -2,7 +2,6 @@ package jwthelper
 
 import (
 	"errors"
-	"os"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
@@ -10,15 +9,27 @@ import (
 )
 
 var (
-	secretKey = []byte(os.Getenv("JWT_SECRET"))
+	secretKey []byte
 	tokenTTL  = 1 * time.Hour
 )
 
+// liveScope is the scope of stream tickets, tokens have none
+const liveScope = "live"
+
+// Configure sets the signing secret and the lifetime of new tokens, it must be called before tokens are used
+func Configure(secret []byte, ttl time.Duration) {
+	secretKey = secret
+	tokenTTL = ttl
+}
+
 // CustomClaims includes additional fields for user identity
 type CustomClaims struct {
 	UserID uint   `json:"user_id"`
 	Login  string `json:"login"`
 	Role   string `json:"role"`
+	// Scope and RestaurantID are only set on stream tickets, which are good for the live stream of that restaurant only
+	Scope        string `json:"scope,omitempty"`
+	RestaurantID uint   `json:"restaurant_id,omitempty"`
 	jwt.StandardClaims
 }
 
@@ -46,8 +57,55 @@ func GenerateToken(user *models.User) (string, error) {
 	return signedToken, nil
 }
 
-// ParseToken parses and validates JWT, returning custom claims
+// GenerateStreamTicket creates a signed ticket for the live stream of the restaurant that expires after ttl.
+// Browsers can't set headers on EventSource and WebSocket, so the ticket goes in the URL instead of the token.
+func GenerateStreamTicket(userID uint, role string, restaurantID uint, ttl time.Duration) (string, time.Time, error) {
+	expirationTime := time.Now().Add(ttl)
+
+	claims := &CustomClaims{
+		UserID:       userID,
+		Role:         role,
+		Scope:        liveScope,
+		RestaurantID: restaurantID,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: expirationTime.Unix(),
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+
+	signedTicket, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secretKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signedTicket, expirationTime, nil
+}
+
+// ParseToken parses and validates JWT, returning custom claims. Stream tickets aren't tokens.
 func ParseToken(tokenString string) (*CustomClaims, error) {
+	claims, err := parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Scope != "" {
+		return nil, errors.New("not a token")
+	}
+	return claims, nil
+}
+
+// ParseStreamTicket parses and validates a stream ticket for the live stream of the restaurant
+func ParseStreamTicket(ticket string, restaurantID uint) (*CustomClaims, error) {
+	claims, err := parse(ticket)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Scope != liveScope || claims.RestaurantID != restaurantID {
+		return nil, errors.New("not a ticket for the stream")
+	}
+	return claims, nil
+}
+
+func parse(tokenString string) (*CustomClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
@@ -0,0 +1,65 @@
+// Package logctx carries a request-scoped logger, request ID and user ID in a context.Context.
+package logctx
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type contextKey string
+
+const (
+	loggerKey    contextKey = "logger"
+	requestIDKey contextKey = "requestID"
+	userIDKey    contextKey = "userID"
+)
+
+// WithLogger returns a copy of ctx carrying log
+func WithLogger(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, log)
+}
+
+// FromContext returns the logger carried by ctx, or nil if there is none
+func FromContext(ctx context.Context) *slog.Logger {
+	log, _ := ctx.Value(loggerKey).(*slog.Logger)
+	return log
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the request ID carried by ctx, or "" if there is none
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithUserID returns a copy of ctx carrying the ID of the authenticated user
+func WithUserID(ctx context.Context, id uint) context.Context {
+	return context.WithValue(ctx, userIDKey, id)
+}
+
+// UserID returns the user ID carried by ctx, ok is false if there is none
+func UserID(ctx context.Context) (id uint, ok bool) {
+	id, ok = ctx.Value(userIDKey).(uint)
+	return id, ok
+}
+
+// Attrs returns the request ID, user ID and trace ID carried by ctx, for a slog handler to add to every record
+func Attrs(ctx context.Context) []slog.Attr {
+	var attrs []slog.Attr
+	if id := RequestID(ctx); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	if id, ok := UserID(ctx); ok {
+		attrs = append(attrs, slog.Uint64("user_id", uint64(id)))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+	}
+	return attrs
+}
@@ -0,0 +1,67 @@
+package prettyslog
+
+import (
+	"context"
+	"log/slog"
+)
+
+// ContextHandler adds the attributes returned by attrs for the context of a record to it,
+// so request IDs, user IDs and trace IDs get logged by anything that logs with a context.
+// Keys that were already added to the logger with With are not added twice.
+type ContextHandler struct {
+	slog.Handler
+	attrs func(context.Context) []slog.Attr
+	// top-level keys added with WithAttrs
+	baked map[string]struct{}
+	// whether a group was opened, context attrs would land inside it
+	grouped bool
+}
+
+// NewContextHandler wraps h, adding the attributes returned by attrs to every record
+func NewContextHandler(h slog.Handler, attrs func(context.Context) []slog.Attr) *ContextHandler {
+	return &ContextHandler{Handler: h, attrs: attrs}
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx == nil || h.grouped {
+		return h.Handler.Handle(ctx, r)
+	}
+
+	var add []slog.Attr
+	for _, a := range h.attrs(ctx) {
+		if _, ok := h.baked[a.Key]; !ok {
+			add = append(add, a)
+		}
+	}
+	if len(add) > 0 {
+		r = r.Clone()
+		r.AddAttrs(add...)
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.Handler = h.Handler.WithAttrs(attrs)
+	if !h.grouped {
+		h2.baked = make(map[string]struct{}, len(h.baked)+len(attrs))
+		for k := range h.baked {
+			h2.baked[k] = struct{}{}
+		}
+		for _, a := range attrs {
+			h2.baked[a.Key] = struct{}{}
+		}
+	}
+	return &h2
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.Handler = h.Handler.WithGroup(name)
+	h2.grouped = true
+	return &h2
+}
@@ -6,6 +6,8 @@ import (
 	"io"
 	stdLog "log"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/fatih/color"
 )
@@ -15,23 +17,40 @@ type PrettyHandlerOptions struct {
 }
 
 type PrettyHandler struct {
-	// opts PrettyHandlerOptions
-	slog.Handler
-	l     *stdLog.Logger
-	attrs []slog.Attr
+	opts slog.HandlerOptions
+	l    *stdLog.Logger
+	// attrs added with WithAttrs, each with the groups that were open at the time
+	attrs []groupedAttr
+	// groups opened with WithGroup, the record attrs go into the innermost one
+	groups []string
+}
+
+type groupedAttr struct {
+	groups []string
+	attr   slog.Attr
 }
 
 func (opts PrettyHandlerOptions) NewPrettyHandler(
 	out io.Writer,
 ) *PrettyHandler {
 	h := &PrettyHandler{
-		Handler: slog.NewJSONHandler(out, opts.SlogOpts),
-		l:       stdLog.New(out, "", 0),
+		l: stdLog.New(out, "", 0),
+	}
+	if opts.SlogOpts != nil {
+		h.opts = *opts.SlogOpts
 	}
 
 	return h
 }
 
+func (h *PrettyHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
 func (h *PrettyHandler) Handle(_ context.Context, r slog.Record) error {
 	level := r.Level.String() + ":"
 
@@ -46,18 +65,18 @@ func (h *PrettyHandler) Handle(_ context.Context, r slog.Record) error {
 		level = color.RedString(level)
 	}
 
-	fields := make(map[string]interface{}, r.NumAttrs())
+	fields := make(map[string]any, len(h.attrs)+r.NumAttrs())
+
+	for _, ga := range h.attrs {
+		h.addAttr(fields, ga.groups, ga.attr)
+	}
 
 	r.Attrs(func(a slog.Attr) bool {
-		fields[a.Key] = a.Value.Any()
+		h.addAttr(fields, h.groups, a)
 
 		return true
 	})
 
-	for _, a := range h.attrs {
-		fields[a.Key] = a.Value.Any()
-	}
-
 	var b []byte
 	var err error
 
@@ -81,17 +100,88 @@ func (h *PrettyHandler) Handle(_ context.Context, r slog.Record) error {
 	return nil
 }
 
+// addAttr puts a into fields under the nested maps of groups, following the slog.Handler rules:
+// empty attrs are dropped, so are groups without attrs, and groups with an empty key are inlined
+func (h *PrettyHandler) addAttr(fields map[string]any, groups []string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+
+	if a.Value.Kind() != slog.KindGroup && h.opts.ReplaceAttr != nil {
+		a = h.opts.ReplaceAttr(groups, a)
+		a.Value = a.Value.Resolve()
+	}
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		groupAttrs := a.Value.Group()
+		if len(groupAttrs) == 0 {
+			return
+		}
+		if a.Key != "" {
+			groups = append(slices.Clip(groups), a.Key)
+		}
+		for _, ga := range groupAttrs {
+			h.addAttr(fields, groups, ga)
+		}
+		return
+	}
+
+	group(fields, groups)[a.Key] = fieldValue(a.Value)
+}
+
+// group returns the map of the innermost of groups inside fields, creating the missing ones
+func group(fields map[string]any, groups []string) map[string]any {
+	for _, name := range groups {
+		g, ok := fields[name].(map[string]any)
+		if !ok {
+			g = make(map[string]any)
+			fields[name] = g
+		}
+		fields = g
+	}
+	return fields
+}
+
+// fieldValue converts v to what json.Marshal prints the way slog.JSONHandler does
+func fieldValue(v slog.Value) any {
+	switch v.Kind() {
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindAny:
+		switch a := v.Any().(type) {
+		case error:
+			return a.Error()
+		case json.Marshaler:
+			return a
+		}
+	}
+	return v.Any()
+}
+
 func (h *PrettyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &PrettyHandler{
-		Handler: h.Handler,
-		l:       h.l,
-		attrs:   attrs,
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.attrs = slices.Clip(h.attrs)
+	for _, a := range attrs {
+		h2.attrs = append(h2.attrs, groupedAttr{groups: h.groups, attr: a})
 	}
+
+	return &h2
 }
 
 func (h *PrettyHandler) WithGroup(name string) slog.Handler {
-	return &PrettyHandler{
-		Handler: h.Handler.WithGroup(name),
-		l:       h.l,
+	if name == "" {
+		return h
 	}
+
+	h2 := *h
+	h2.groups = append(slices.Clip(h.groups), name)
+
+	return &h2
 }